@@ -15,9 +15,11 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"internal/apiclient"
 	"internal/cmd"
+	"internal/cmd/utils"
 	"os"
 )
 
@@ -33,7 +35,13 @@ func main() {
 	apiclient.SetBuildParams(version, commit, date)
 	rootCmd.Version = fmt.Sprintf("%s date: %s [commit: %.7s]", version, date, commit)
 
-	if err := rootCmd.Execute(); err != nil {
+	err := rootCmd.Execute()
+	_ = apiclient.CloseTraceFile()
+	if err != nil {
+		var exitErr *utils.ExitCodeError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.Code)
+		}
 		os.Exit(1)
 	}
 }
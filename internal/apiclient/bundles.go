@@ -16,6 +16,7 @@ package apiclient
 
 import (
 	"archive/tar"
+	"archive/zip"
 	"bytes"
 	"compress/gzip"
 	"context"
@@ -118,18 +119,27 @@ func ClearEntityPayloadList() {
 	entityPayloadList = entityPayloadList[:0]
 }
 
-func ExtractTgz(gcsURL string) (folder string, err error) {
+// ExtractGCSArchive downloads the tgz or zip archive from Cloud Storage and extracts it to a
+// temporary folder, to be removed by the caller
+func ExtractGCSArchive(gcsURL string) (folder string, err error) {
 	ctx := context.Background()
 
 	folder, err = os.MkdirTemp("", "integration")
 	if err != nil {
 		return "", err
 	}
+	// do not leave a partial extraction behind
+	tempFolder := folder
+	defer func() {
+		if err != nil {
+			os.RemoveAll(tempFolder)
+		}
+	}()
 
 	// Parse the GCS URL
 	parsedURL, err := url.Parse(gcsURL)
 	if err != nil {
-		return "", fmt.Errorf("Error parsing GCS URL:", err)
+		return "", fmt.Errorf("Error parsing GCS URL: %w", err)
 	}
 	if parsedURL.Scheme != "gs" {
 		return "", fmt.Errorf("Invalid GCS URL scheme. Should be 'gs://'")
@@ -140,9 +150,13 @@ func ExtractTgz(gcsURL string) (folder string, err error) {
 	fileName := filepath.Base(gcsURL)
 
 	// Create a Google Cloud Storage client
-	client, err := storage.NewClient(ctx)
+	opts, err := HTTPClientOptions(ctx, storage.ScopeReadOnly)
 	if err != nil {
-		return "", fmt.Errorf("Error creating GCS client:", err)
+		return "", err
+	}
+	client, err := storage.NewClient(ctx, opts...)
+	if err != nil {
+		return "", fmt.Errorf("Error creating GCS client: %w", err)
 	}
 	defer client.Close()
 
@@ -153,33 +167,132 @@ func ExtractTgz(gcsURL string) (folder string, err error) {
 	// Create a reader to stream the object's content
 	reader, err := object.NewReader(ctx)
 	if err != nil {
-		return "", fmt.Errorf("Error creating object reader:", err)
+		return "", fmt.Errorf("Error creating object reader: %w", err)
 	}
 	defer reader.Close()
 
 	// Create the local file to save the download
 	localFile, err := os.Create(path.Join(folder, fileName))
 	if err != nil {
-		return "", fmt.Errorf("Error creating local file:", err)
+		return "", fmt.Errorf("Error creating local file: %w", err)
 	}
 	defer localFile.Close()
 
 	// Download the object and save it to the local file
 	if _, err := io.Copy(localFile, reader); err != nil {
-		return "", fmt.Errorf("Error downloading object:", err)
+		return "", fmt.Errorf("Error downloading object: %w", err)
+	}
+
+	if err = extractArchive(path.Join(folder, fileName), folder); err != nil {
+		return "", err
+	}
+	return folder, nil
+}
+
+// ExtractArchive extracts the local tgz or zip archive to a temporary folder, to be removed by the caller
+func ExtractArchive(archiveFile string) (folder string, err error) {
+	if folder, err = os.MkdirTemp("", "integration"); err != nil {
+		return "", err
+	}
+	if err = extractArchive(archiveFile, folder); err != nil {
+		os.RemoveAll(folder)
+		return "", err
+	}
+	return folder, nil
+}
+
+// extractArchive extracts the archive to folder. The archive type is detected from the first
+// bytes of the file, or from its extension when they are not recognized
+func extractArchive(archiveFile string, folder string) error {
+	file, err := os.Open(archiveFile)
+	if err != nil {
+		return fmt.Errorf("Error opening file: %w", err)
+	}
+	defer file.Close()
+
+	magic := make([]byte, 4)
+	n, _ := io.ReadFull(file, magic)
+	if _, err = file.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	switch {
+	case bytes.HasPrefix(magic[:n], []byte{0x1f, 0x8b}):
+		return extractTgz(file, folder)
+	case bytes.HasPrefix(magic[:n], []byte("PK\x03\x04")), bytes.HasPrefix(magic[:n], []byte("PK\x05\x06")):
+		return ExtractZip(archiveFile, folder)
+	case strings.HasSuffix(archiveFile, ".zip"):
+		return ExtractZip(archiveFile, folder)
+	case strings.HasSuffix(archiveFile, ".tgz"), strings.HasSuffix(archiveFile, ".tar.gz"):
+		return extractTgz(file, folder)
+	default:
+		return fmt.Errorf("%s is not a tgz or zip archive", archiveFile)
+	}
+}
+
+// ExtractZip extracts the zip archive to folder
+func ExtractZip(zipFile string, folder string) error {
+	zipReader, err := zip.OpenReader(zipFile)
+	if err != nil {
+		return fmt.Errorf("Error opening zip file: %w", err)
+	}
+	defer zipReader.Close()
+
+	for _, f := range zipReader.File {
+		if strings.Contains(f.Name, "..") {
+			continue
+		}
+		target := path.Join(folder, f.Name)
+		if f.FileInfo().IsDir() {
+			if err = os.MkdirAll(target, 0o755); err != nil {
+				return fmt.Errorf("Error creating directory: %w", err)
+			}
+			continue
+		}
+		if f.Mode()&os.ModeSymlink != 0 {
+			// links could point outside of folder, they are not followed
+			clilog.Warning.Printf("Skipping link %s in the archive\n", f.Name)
+			continue
+		}
+		if !f.Mode().IsRegular() {
+			return fmt.Errorf("Unsupported type: %s in %s", f.Mode().Type(), f.Name)
+		}
+		// zip archives do not always have entries for the folders
+		if err = os.MkdirAll(path.Dir(target), 0o755); err != nil {
+			return fmt.Errorf("Error creating directory: %w", err)
+		}
+		if err = extractZipFile(f, target); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func extractZipFile(f *zip.File, target string) error {
+	r, err := f.Open()
+	if err != nil {
+		return fmt.Errorf("Error reading zip entry: %w", err)
 	}
+	defer r.Close()
 
-	// Open the .tgz file
-	file, err := os.Open(path.Join(folder, fileName))
+	outFile, err := os.Create(target)
 	if err != nil {
-		return "", fmt.Errorf("Error opening file:", err)
+		return fmt.Errorf("Error creating file: %w", err)
 	}
-	defer file.Close() // Ensure file closure
+	_, err = io.Copy(outFile, r)
+	outFile.Close()
+	if err != nil {
+		return fmt.Errorf("Error writing file: %w", err)
+	}
+	return nil
+}
 
+// extractTgz extracts the gzipped tar archive to folder
+func extractTgz(r io.Reader, folder string) error {
 	// Create a gzip reader
-	gzipReader, err := gzip.NewReader(file)
+	gzipReader, err := gzip.NewReader(r)
 	if err != nil {
-		return "", fmt.Errorf("Error creating gzip reader:", err)
+		return fmt.Errorf("Error creating gzip reader: %w", err)
 	}
 	defer gzipReader.Close() // Ensure closure
 
@@ -193,7 +306,7 @@ func ExtractTgz(gcsURL string) (folder string, err error) {
 			break // End of archive
 		}
 		if err != nil {
-			return "", fmt.Errorf("Error reading tar entry:", err)
+			return fmt.Errorf("Error reading tar entry: %w", err)
 		}
 		if strings.Contains(header.Name, "..") {
 			continue
@@ -204,25 +317,31 @@ func ExtractTgz(gcsURL string) (folder string, err error) {
 		case tar.TypeDir:
 			// Create directory
 			if err := os.Mkdir(path.Join(folder, header.Name), 0o755); err != nil {
-				return "", fmt.Errorf("Error creating directory:", err)
+				return fmt.Errorf("Error creating directory: %w", err)
 			}
 		case tar.TypeReg:
 			// Create output file
 			outFile, err := os.Create(path.Join(folder, header.Name))
 			if err != nil {
-				return "", fmt.Errorf("Error creating file:", err)
+				return fmt.Errorf("Error creating file: %w", err)
 			}
-			defer outFile.Close()
 
 			// Copy contents from the tar to the output file
-			if _, err := io.Copy(outFile, tarReader); err != nil {
-				return "", fmt.Errorf("Error writing file:", err)
+			_, err = io.Copy(outFile, tarReader)
+			outFile.Close()
+			if err != nil {
+				return fmt.Errorf("Error writing file: %w", err)
 			}
+		case tar.TypeXGlobalHeader:
+			// git archives start with a pax header holding the commit id
+		case tar.TypeSymlink, tar.TypeLink:
+			// links could point outside of folder, they are not followed
+			clilog.Warning.Printf("Skipping link %s in the archive\n", header.Name)
 		default:
-			return "", fmt.Errorf("Unsupported type: %b in %s\n", header.Typeflag, header.Name)
+			return fmt.Errorf("Unsupported type: %b in %s\n", header.Typeflag, header.Name)
 		}
 	}
-	return folder, nil
+	return nil
 }
 
 func GetCloudDeployGCSLocations(cloudDeployProjectId string, cloudDeployLocation string,
@@ -277,7 +396,7 @@ func parseGCSURI(gcsURI string) (bucketName, objectPath string, err error) {
 	// Parse the GCS URL
 	parsedURL, err := url.Parse(gcsURI)
 	if err != nil {
-		return "", "", fmt.Errorf("Error parsing GCS URL:", err)
+		return "", "", fmt.Errorf("Error parsing GCS URL: %w", err)
 	}
 	if parsedURL.Scheme != "gs" {
 		return "", "", fmt.Errorf("Invalid GCS URL scheme. Should be 'gs://'")
@@ -318,7 +437,11 @@ func WriteManifest(deployOutputGCS string, version string) (err error) {
 
 func writeGCSFile(deployOutputGCS string, fileName string, contents string) (err error) {
 	ctx := context.Background()
-	client, err := storage.NewClient(ctx)
+	opts, err := HTTPClientOptions(ctx, storage.ScopeReadWrite)
+	if err != nil {
+		return err
+	}
+	client, err := storage.NewClient(ctx, opts...)
 	if err != nil {
 		return fmt.Errorf("storage.NewClient: %v", err)
 	}
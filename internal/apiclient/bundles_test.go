@@ -0,0 +1,92 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiclient
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path"
+	"testing"
+)
+
+func TestExtractArchive(t *testing.T) {
+	var zipArchive bytes.Buffer
+	zw := zip.NewWriter(&zipArchive)
+	// zip archives do not always have entries for the folders
+	for name, content := range map[string]string{"src/a.json": "{}", "../escape.json": "{}"} {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = w.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	zw.Close()
+
+	var tgzArchive bytes.Buffer
+	gw := gzip.NewWriter(&tgzArchive)
+	tw := tar.NewWriter(gw)
+	for _, header := range []tar.Header{
+		{Typeflag: tar.TypeDir, Name: "src/", Mode: 0o755},
+		{Typeflag: tar.TypeReg, Name: "src/a.json", Mode: 0o644, Size: 2},
+	} {
+		if err := tw.WriteHeader(&header); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := tw.Write([]byte("{}")); err != nil {
+		t.Fatal(err)
+	}
+	tw.Close()
+	gw.Close()
+
+	archiveFolder := t.TempDir()
+	// the archive type is detected from the contents, not the extension
+	for _, archive := range []struct {
+		name     string
+		contents []byte
+	}{
+		{"scaffold.bin", zipArchive.Bytes()},
+		{"scaffold.zip", tgzArchive.Bytes()},
+	} {
+		archiveFile := path.Join(archiveFolder, archive.name)
+		if err := os.WriteFile(archiveFile, archive.contents, 0o600); err != nil {
+			t.Fatal(err)
+		}
+		folder, err := ExtractArchive(archiveFile)
+		if err != nil {
+			t.Fatalf("ExtractArchive(%s) error = %v", archive.name, err)
+		}
+		defer os.RemoveAll(folder)
+		if content, err := os.ReadFile(path.Join(folder, "src", "a.json")); err != nil || string(content) != "{}" {
+			t.Errorf("ExtractArchive(%s) extracted file = %s, %v, want {}", archive.name, content, err)
+		}
+		if _, err := os.Stat(path.Join(path.Dir(folder), "escape.json")); err == nil {
+			t.Errorf("ExtractArchive(%s) extracted a file outside of the folder", archive.name)
+		}
+	}
+
+	textFile := path.Join(archiveFolder, "scaffold.txt")
+	if err := os.WriteFile(textFile, []byte("scaffold"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ExtractArchive(textFile); err == nil {
+		t.Errorf("ExtractArchive() succeeded, expected an error for a file that is not an archive")
+	}
+}
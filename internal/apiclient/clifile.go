@@ -39,6 +39,8 @@ type integrationCLI struct {
 	Nocheck   bool   `json:"nocheck,omitempty" default:"false"`
 	Api       API    `json:"api,omitempty" default:"prod"`
 	BasicInfo string `json:"basicInfo,omitempty" default:"false"`
+	// ProjectIDs caches project numbers resolved to project ids
+	ProjectIDs map[string]string `json:"projectIds,omitempty"`
 }
 
 func readPreferencesFile() (cliPref *integrationCLI, err error) {
@@ -110,6 +112,41 @@ func writeToken(token string) (err error) {
 	return writePerferencesFile(data)
 }
 
+func getCachedProjectID(projectNumber string) (projectID string) {
+	if IsSkipCache() {
+		return ""
+	}
+	cliPref, err := readPreferencesFile()
+	if err != nil {
+		return ""
+	}
+	return cliPref.ProjectIDs[projectNumber]
+}
+
+func writeProjectID(projectNumber string, projectID string) (err error) {
+	if IsSkipCache() {
+		return nil
+	}
+
+	cliPref, err := readPreferencesFile()
+	if err != nil {
+		return err
+	}
+
+	if cliPref.ProjectIDs == nil {
+		cliPref.ProjectIDs = map[string]string{}
+	}
+	cliPref.ProjectIDs[projectNumber] = projectID
+
+	data, err := json.Marshal(&cliPref)
+	if err != nil {
+		clilog.Debug.Printf("Error marshalling: %v\n", err)
+		return err
+	}
+	clilog.Debug.Println("Writing ", string(data))
+	return writePerferencesFile(data)
+}
+
 func getToken() (token string) {
 	cliPref, err := readPreferencesFile()
 	if err != nil {
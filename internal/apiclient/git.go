@@ -0,0 +1,128 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiclient
+
+import (
+	"fmt"
+	"internal/clilog"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+)
+
+// gitTokenEnv is the environment variable holding the token to download private repositories
+const gitTokenEnv = "GIT_TOKEN"
+
+// DownloadGitArchive downloads the GitHub or GitLab repository at ref and extracts it to a
+// temporary folder, so no git client is needed. An empty ref downloads the default branch.
+// It returns the temporary folder, to be removed by the caller, and the repository root in it
+func DownloadGitArchive(repoURL string, ref string) (folder string, repoFolder string, err error) {
+	archiveURL, err := getGitArchiveURL(repoURL, ref)
+	if err != nil {
+		return "", "", err
+	}
+
+	req, err := http.NewRequest(http.MethodGet, archiveURL, nil)
+	if err != nil {
+		return "", "", err
+	}
+	if token := os.Getenv(gitTokenEnv); token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	client, err := NewHTTPClient()
+	if err != nil {
+		return "", "", err
+	}
+	clilog.Debug.Println("Connecting to: ", archiveURL)
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", "", fmt.Errorf("unable to download %s: %w", repoURL, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", "", fmt.Errorf("unable to download %s at %q: %s; set %s for private repositories",
+			repoURL, ref, resp.Status, gitTokenEnv)
+	}
+
+	if folder, err = os.MkdirTemp("", "integration"); err != nil {
+		return "", "", err
+	}
+	if repoFolder, err = extractGitArchive(resp.Body, folder); err != nil {
+		os.RemoveAll(folder)
+		return "", "", err
+	}
+	return folder, repoFolder, nil
+}
+
+// getGitArchiveURL returns the archive API url of the repository at ref
+func getGitArchiveURL(repoURL string, ref string) (archiveURL string, err error) {
+	u, err := url.Parse(repoURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid git url %s: %w", repoURL, err)
+	}
+	if u.Scheme != "https" {
+		return "", fmt.Errorf("git url %s must be an https url", repoURL)
+	}
+	repoPath := strings.TrimSuffix(strings.Trim(u.Path, "/"), ".git")
+	if strings.Count(repoPath, "/") < 1 {
+		return "", fmt.Errorf("git url %s must include the owner and repository", repoURL)
+	}
+
+	switch {
+	case u.Host == "github.com":
+		if strings.Count(repoPath, "/") != 1 {
+			return "", fmt.Errorf("git url %s must be of the form https://github.com/owner/repo", repoURL)
+		}
+		archiveURL = "https://api.github.com/repos/" + repoPath + "/tarball"
+		if ref != "" {
+			archiveURL += "/" + url.PathEscape(ref)
+		}
+		return archiveURL, nil
+	case strings.HasPrefix(u.Host, "gitlab."):
+		a := url.URL{
+			Scheme: "https",
+			Host:   u.Host,
+			Path:   "/api/v4/projects/" + repoPath + "/repository/archive.tar.gz",
+			// the project path is passed url encoded as a single path segment
+			RawPath: "/api/v4/projects/" + url.PathEscape(repoPath) + "/repository/archive.tar.gz",
+		}
+		if ref != "" {
+			a.RawQuery = url.Values{"sha": []string{ref}}.Encode()
+		}
+		return a.String(), nil
+	default:
+		return "", fmt.Errorf("git url %s is not a GitHub or GitLab repository", repoURL)
+	}
+}
+
+// extractGitArchive extracts the archive to folder and returns the repository root, the
+// single top level folder of git archives
+func extractGitArchive(body io.Reader, folder string) (repoFolder string, err error) {
+	if err = extractTgz(body, folder); err != nil {
+		return "", err
+	}
+	entries, err := os.ReadDir(folder)
+	if err != nil {
+		return "", err
+	}
+	if len(entries) != 1 || !entries[0].IsDir() {
+		return "", fmt.Errorf("the git archive does not have a single top level folder")
+	}
+	return path.Join(folder, entries[0].Name()), nil
+}
@@ -0,0 +1,90 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiclient
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"internal/clilog"
+	"os"
+	"path"
+	"testing"
+)
+
+func TestGetGitArchiveURL(t *testing.T) {
+	tests := []struct {
+		repoURL, ref, want string
+		wantErr            bool
+	}{
+		{"https://github.com/owner/repo.git", "main", "https://api.github.com/repos/owner/repo/tarball/main", false},
+		{"https://github.com/owner/repo/", "", "https://api.github.com/repos/owner/repo/tarball", false},
+		{
+			"https://gitlab.com/group/sub/repo", "v1.0",
+			"https://gitlab.com/api/v4/projects/group%2Fsub%2Frepo/repository/archive.tar.gz?sha=v1.0", false,
+		},
+		{"git@github.com:owner/repo.git", "", "", true},
+		{"https://github.com/owner", "", "", true},
+		{"https://bitbucket.org/owner/repo", "", "", true},
+	}
+	for _, tt := range tests {
+		got, err := getGitArchiveURL(tt.repoURL, tt.ref)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("getGitArchiveURL(%s, %s) = %s, %v, want %s", tt.repoURL, tt.ref, got, err, tt.want)
+		}
+	}
+}
+
+func TestExtractGitArchive(t *testing.T) {
+	clilog.Init(false, false, true, false)
+	var archive bytes.Buffer
+	gw := gzip.NewWriter(&archive)
+	tw := tar.NewWriter(gw)
+	entries := []struct {
+		header  tar.Header
+		content string
+	}{
+		{tar.Header{Typeflag: tar.TypeXGlobalHeader, Name: "pax_global_header", PAXRecords: map[string]string{"comment": "abc"}}, ""},
+		{tar.Header{Typeflag: tar.TypeDir, Name: "owner-repo-abc/", Mode: 0o755}, ""},
+		{tar.Header{Typeflag: tar.TypeDir, Name: "owner-repo-abc/scaffold/", Mode: 0o755}, ""},
+		{tar.Header{Typeflag: tar.TypeReg, Name: "owner-repo-abc/scaffold/a.json", Mode: 0o644, Size: 2}, "{}"},
+		{tar.Header{Typeflag: tar.TypeSymlink, Name: "owner-repo-abc/scaffold/b.json", Linkname: "/etc/passwd"}, ""},
+	}
+	for _, e := range entries {
+		if err := tw.WriteHeader(&e.header); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(e.content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	tw.Close()
+	gw.Close()
+
+	folder := t.TempDir()
+	repoFolder, err := extractGitArchive(&archive, folder)
+	if err != nil {
+		t.Fatalf("extractGitArchive() error = %v", err)
+	}
+	if repoFolder != path.Join(folder, "owner-repo-abc") {
+		t.Errorf("extractGitArchive() = %s, want the top level folder", repoFolder)
+	}
+	if content, err := os.ReadFile(path.Join(repoFolder, "scaffold", "a.json")); err != nil || string(content) != "{}" {
+		t.Errorf("extracted file = %s, %v, want {}", content, err)
+	}
+	if _, err := os.Lstat(path.Join(repoFolder, "scaffold", "b.json")); !os.IsNotExist(err) {
+		t.Errorf("extractGitArchive() extracted the symlink, error = %v", err)
+	}
+}
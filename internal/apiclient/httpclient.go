@@ -17,15 +17,27 @@ package apiclient
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"internal/clilog"
 	"io"
 	"net/http"
 	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
 	"golang.org/x/time/rate"
+	"google.golang.org/api/option"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
 // RateLimitedHttpClient
@@ -34,10 +46,15 @@ type RateLimitedHTTPClient struct {
 	Ratelimiter *rate.Limiter
 }
 
-// allow 6 every 1 second (360 per min, limit is 480 per min)
+// allow a burst of 6, then 1 every second (60 per min, limit is 480 per min).
+// The limit is changed with SetRateLimit when --qps or --burst are set
 var integrationAPIRateLimit = rate.NewLimiter(rate.Every(time.Second), 6)
 
-// allow 1 every 1 second (60 per min, limit is 120 per min)
+// maxRetries is the number of times a request is sent again when the API returns 429
+const maxRetries = 3
+
+// allow 1 every 1 second (60 per min, limit is 120 per min).
+// The limit is changed with SetRateLimit when --qps or --burst are set
 var connectorsAPIRateLimit = rate.NewLimiter(rate.Every(time.Second), 1)
 
 // disable rate limit
@@ -108,9 +125,42 @@ func HttpClient(params ...string) (respBody []byte, err error) {
 		return nil, err
 	}
 
+	if resp.StatusCode == http.StatusUnauthorized {
+		if resp, err = retryUnauthorized(client, req, resp); err != nil {
+			clilog.Error.Println("error connecting: ", err)
+			return nil, err
+		}
+	}
+
 	return handleResponse(resp)
 }
 
+// retryUnauthorized sends the request once more with a new access token when the API returns
+// 401, so commands that outlive the token lifetime do not fail. The 401 response is returned
+// when the token cannot be refreshed
+func retryUnauthorized(client *RateLimitedHTTPClient, req *http.Request, resp *http.Response) (*http.Response, error) {
+	if req.Body != nil && req.GetBody == nil {
+		return resp, nil
+	}
+	expired := strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer ")
+	if err := refreshAccessToken(expired); err != nil {
+		clilog.Debug.Printf("unable to refresh the access token: %v\n", err)
+		return resp, nil
+	}
+	resp.Body.Close()
+
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = body
+	}
+	req.Header.Set("Authorization", "Bearer "+GetIntegrationToken())
+	clilog.Warning.Println("the access token expired, retrying the request with a new token")
+	return client.Do(req)
+}
+
 // PrettyPrint method prints formatted json
 func PrettyPrint(body []byte) error {
 	if GetCmdPrintHttpResponseSetting() && ClientPrintHttpResponse.Get() {
@@ -137,6 +187,14 @@ func PrettifyJson(body []byte) (prettyJson []byte, err error) {
 	return prettyJSON.Bytes(), err
 }
 
+// UnmarshalStrict unmarshals body into v and fails on the first field v does not declare,
+// which json.Unmarshal silently ignores
+func UnmarshalStrict(body []byte, v interface{}) error {
+	d := json.NewDecoder(bytes.NewReader(body))
+	d.DisallowUnknownFields()
+	return d.Decode(v)
+}
+
 func getRequest(params []string) (req *http.Request, err error) {
 	ctx := context.Background()
 	if params[2] == "DELETE" {
@@ -171,19 +229,59 @@ func setAuthHeader(req *http.Request) (*http.Request, error) {
 	return req, nil
 }
 
-// Do the HTTP request
+// SetRateLimit sets the requests per second and the burst of the integrations and connectors API rate limiters
+func SetRateLimit(qps float64, burst int) error {
+	if qps <= 0 {
+		return fmt.Errorf("qps must be greater than 0")
+	}
+	if burst < 1 {
+		return fmt.Errorf("burst must be at least 1")
+	}
+	for _, limiter := range []*rate.Limiter{integrationAPIRateLimit, connectorsAPIRateLimit} {
+		limiter.SetLimit(rate.Limit(qps))
+		limiter.SetBurst(burst)
+	}
+	return nil
+}
+
+// Do the HTTP request. A request rejected with 429 is sent again after the delay the API asks
+// for, or an exponential backoff, and each attempt waits for the rate limiter
 func (c *RateLimitedHTTPClient) Do(req *http.Request) (*http.Response, error) {
 	ctx := context.Background()
-	// Wait until the rate is below Apigee limits
-	err := c.Ratelimiter.Wait(ctx)
-	if err != nil {
-		return nil, err
+	for attempt := 0; ; attempt++ {
+		// Wait until the rate is below Apigee limits
+		err := c.Ratelimiter.Wait(ctx)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode != http.StatusTooManyRequests || attempt == maxRetries ||
+			(req.Body != nil && req.GetBody == nil) {
+			return resp, nil
+		}
+
+		delay := getRetryDelay(resp, attempt)
+		resp.Body.Close()
+		clilog.Warning.Printf("the API quota was exceeded, retrying in %s\n", delay)
+		time.Sleep(delay)
+
+		if req.GetBody != nil {
+			if req.Body, err = req.GetBody(); err != nil {
+				return nil, err
+			}
+		}
 	}
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, err
+}
+
+// getRetryDelay returns the Retry-After delay of the response, or 1s, 2s, 4s for each attempt
+func getRetryDelay(resp *http.Response, attempt int) time.Duration {
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second
 	}
-	return resp, nil
+	return time.Duration(1<<attempt) * time.Second
 }
 
 func getHttpClient() (client *RateLimitedHTTPClient, err error) {
@@ -200,26 +298,114 @@ func getHttpClient() (client *RateLimitedHTTPClient, err error) {
 		apiRateLimit = noAPIRateLimit
 	}
 
-	if GetProxyURL() != "" {
-		if proxyUrl, err := url.Parse(GetProxyURL()); err != nil {
-			integrationCLIAPIClient := &RateLimitedHTTPClient{
-				client: &http.Client{
-					Transport: &http.Transport{
-						Proxy: http.ProxyURL(proxyUrl),
-					},
-				},
-				Ratelimiter: apiRateLimit,
-			}
-			return integrationCLIAPIClient, err
-		}
+	transport, err := getTransport()
+	if err != nil {
 		return nil, err
-	} else {
-		integrationCLIAPIClient := &RateLimitedHTTPClient{
-			client:      http.DefaultClient,
-			Ratelimiter: apiRateLimit,
+	}
+	integrationCLIAPIClient := &RateLimitedHTTPClient{
+		client:      &http.Client{Transport: traceTransport(transport)},
+		Ratelimiter: apiRateLimit,
+	}
+	return integrationCLIAPIClient, nil
+}
+
+// NewHTTPClient returns a client that uses the proxy and proxy CA certificate settings
+func NewHTTPClient() (*http.Client, error) {
+	transport, err := getTransport()
+	if err != nil {
+		return nil, err
+	}
+	return &http.Client{Transport: traceTransport(transport)}, nil
+}
+
+// transport is reused while the proxy settings do not change, so connections are kept alive
+var transport struct {
+	sync.Mutex
+	proxyUrl, proxyCert string
+	t                   *http.Transport
+}
+
+// getTransport returns a transport that uses the proxy url when it is set, and otherwise
+// the HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment variables. The proxy CA certificate
+// is trusted in addition to the system roots, for proxies that intercept TLS
+func getTransport() (*http.Transport, error) {
+	transport.Lock()
+	defer transport.Unlock()
+
+	proxyUrl, proxyCert := GetProxyURL(), GetProxyCert()
+	if transport.t != nil && transport.proxyUrl == proxyUrl && transport.proxyCert == proxyCert {
+		return transport.t, nil
+	}
+
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	if proxyUrl != "" {
+		u, err := url.Parse(proxyUrl)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return nil, fmt.Errorf("invalid proxy url %q", proxyUrl)
 		}
-		return integrationCLIAPIClient, nil
+		t.Proxy = http.ProxyURL(u)
+	}
+	if proxyCert != "" {
+		tlsConfig, err := getProxyTLSConfig(proxyCert)
+		if err != nil {
+			return nil, err
+		}
+		t.TLSClientConfig = tlsConfig
+	}
+
+	transport.proxyUrl, transport.proxyCert, transport.t = proxyUrl, proxyCert, t
+	return t, nil
+}
+
+// getProxyTLSConfig returns a TLS configuration that trusts the proxy CA certificate in
+// addition to the system roots
+func getProxyTLSConfig(proxyCert string) (*tls.Config, error) {
+	pem, err := os.ReadFile(proxyCert)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read proxy CA certificate: %w", err)
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no PEM certificates were found in %s", proxyCert)
+	}
+	return &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}, nil
+}
+
+// GRPCClientOptions returns the options of the gRPC Google Cloud clients, such as Cloud KMS
+// and Secret Manager, so they trust the proxy CA certificate. The proxy is read from the
+// HTTPS_PROXY environment variable
+func GRPCClientOptions() ([]option.ClientOption, error) {
+	if GetProxyCert() == "" {
+		return nil, nil
+	}
+	tlsConfig, err := getProxyTLSConfig(GetProxyCert())
+	if err != nil {
+		return nil, err
+	}
+	return []option.ClientOption{
+		option.WithGRPCDialOption(grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig))),
+	}, nil
+}
+
+// HTTPClientOptions returns the options of the HTTP Google Cloud clients, such as Cloud Storage,
+// so they use the proxy settings and trust the proxy CA certificate. The client is authorized
+// with application default credentials for the scope
+func HTTPClientOptions(ctx context.Context, scope string) ([]option.ClientOption, error) {
+	if GetProxyCert() == "" {
+		return nil, nil
+	}
+	client, err := NewHTTPClient()
+	if err != nil {
+		return nil, err
+	}
+	authClient, err := google.DefaultClient(context.WithValue(ctx, oauth2.HTTPClient, client), scope)
+	if err != nil {
+		return nil, err
 	}
+	return []option.ClientOption{option.WithHTTPClient(authClient)}, nil
 }
 
 func handleResponse(resp *http.Response) (respBody []byte, err error) {
@@ -0,0 +1,126 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiclient
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"golang.org/x/time/rate"
+)
+
+func TestDoRetriesTooManyRequests(t *testing.T) {
+	NewIntegrationClient(IntegrationClientOptions{
+		SkipCache: true,
+		NoOutput:  true,
+	})
+
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if len(bodies) < 3 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c := &RateLimitedHTTPClient{client: http.DefaultClient, Ratelimiter: rate.NewLimiter(rate.Inf, 1)}
+	req, _ := http.NewRequest(http.MethodPost, server.URL, strings.NewReader(`{"a":1}`))
+	resp, err := c.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || len(bodies) != 3 {
+		t.Fatalf("Do() status = %d after %d requests, want 200 after 3", resp.StatusCode, len(bodies))
+	}
+	for _, body := range bodies {
+		if body != `{"a":1}` {
+			t.Errorf("retried request body = %q, want the original body", body)
+		}
+	}
+
+	if err = SetRateLimit(0, 1); err == nil {
+		t.Errorf("SetRateLimit(0, 1) succeeded, expected an error")
+	}
+	if err = SetRateLimit(2, 4); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = SetRateLimit(1, 6) }()
+	if connectorsAPIRateLimit.Limit() != 2 || connectorsAPIRateLimit.Burst() != 4 {
+		t.Errorf("SetRateLimit(2, 4) set the connectors limit to %v, %d", connectorsAPIRateLimit.Limit(),
+			connectorsAPIRateLimit.Burst())
+	}
+}
+
+func TestHttpClientRefreshesExpiredToken(t *testing.T) {
+	NewIntegrationClient(IntegrationClientOptions{
+		SkipCache: true,
+		NoOutput:  true,
+	})
+	defer func() {
+		tokenRefresh.refresh = nil
+		SetIntegrationToken("")
+	}()
+
+	var tokens []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tokens = append(tokens, r.Header.Get("Authorization"))
+		if r.Header.Get("Authorization") != "Bearer valid" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		_, _ = w.Write(body)
+	}))
+	defer server.Close()
+
+	// the token source returns an expired token, then a valid one
+	sourceTokens := []string{"expired", "valid"}
+	tokenRefresh.refresh = func() error {
+		SetIntegrationToken(sourceTokens[0])
+		sourceTokens = sourceTokens[1:]
+		return nil
+	}
+	if err := tokenRefresh.refresh(); err != nil {
+		t.Fatal(err)
+	}
+
+	respBody, err := HttpClient(server.URL, `{"a":1}`)
+	if err != nil {
+		t.Fatalf("HttpClient() error = %v", err)
+	}
+	if string(respBody) != `{"a":1}` {
+		t.Errorf("HttpClient() = %s, want the request body sent again", respBody)
+	}
+	if strings.Join(tokens, ",") != "Bearer expired,Bearer valid" {
+		t.Errorf("HttpClient() sent tokens %v, want the expired token then the valid one", tokens)
+	}
+
+	// a token that was passed in is not refreshed
+	tokens = nil
+	tokenRefresh.refresh = nil
+	SetIntegrationToken("expired")
+	if _, err = HttpClient(server.URL); err == nil || len(tokens) != 1 {
+		t.Errorf("HttpClient() = %v after %d requests, want an error after 1", err, len(tokens))
+	}
+}
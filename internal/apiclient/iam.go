@@ -459,3 +459,103 @@ func GetComputeEngineDefaultServiceAccount(projectId string) (serviceAccount str
 
 	return serviceAccount, nil
 }
+
+// getProjectIDFromNumber resolves a project number to the project id
+func getProjectIDFromNumber(projectNumber string) (projectID string, err error) {
+	getendpoint := fmt.Sprintf("https://cloudresourcemanager.googleapis.com/v3/projects/%s", projectNumber)
+
+	// this can run in the middle of another call, restore its print setting
+	printSetting := ClientPrintHttpResponse.Get()
+	ClientPrintHttpResponse.Set(false)
+	defer ClientPrintHttpResponse.Set(printSetting)
+
+	respBody, err := HttpClient(getendpoint)
+	if err != nil || respBody == nil {
+		return "", err
+	}
+
+	p := struct {
+		ProjectId string `json:"projectId,omitempty"`
+	}{}
+	if err = json.Unmarshal(respBody, &p); err != nil {
+		return "", err
+	}
+	return p.ProjectId, nil
+}
+
+// IAMCheck holds the permissions the caller needs on a resource
+type IAMCheck struct {
+	Endpoint    string
+	Name        string
+	Permissions []string
+}
+
+// Resource returns the resource name of the check, for ex: projects/p1/topics/t1
+func (c IAMCheck) Resource() string {
+	u, _ := url.Parse(c.Endpoint)
+	return strings.TrimPrefix(path.Join(u.Path, c.Name), "/v1/")
+}
+
+// ProjectIAMCheck returns a check for permissions on a project
+func ProjectIAMCheck(project string, permissions ...string) IAMCheck {
+	return IAMCheck{
+		Endpoint:    "https://cloudresourcemanager.googleapis.com/v1/projects",
+		Name:        project,
+		Permissions: permissions,
+	}
+}
+
+// PubSubIAMCheck returns a check for permissions on a topic
+func PubSubIAMCheck(project string, topic string, permissions ...string) IAMCheck {
+	return IAMCheck{
+		Endpoint:    fmt.Sprintf("https://pubsub.googleapis.com/v1/projects/%s/topics", project),
+		Name:        topic,
+		Permissions: permissions,
+	}
+}
+
+// TestIAMPermissions returns the permissions of the check the caller does not have
+func TestIAMPermissions(check IAMCheck) (missing []string, err error) {
+	type permissions struct {
+		Permissions []string `json:"permissions,omitempty"`
+	}
+
+	if DryRun() {
+		return nil, nil
+	}
+
+	u, _ := url.Parse(check.Endpoint)
+	u.Path = path.Join(u.Path, check.Name+":testIamPermissions")
+
+	payload, err := json.Marshal(permissions{Permissions: check.Permissions})
+	if err != nil {
+		return nil, err
+	}
+
+	ClientPrintHttpResponse.Set(false)
+	defer ClientPrintHttpResponse.Set(GetCmdPrintHttpResponseSetting())
+
+	respBody, err := HttpClient(u.String(), string(payload))
+	if err != nil {
+		return nil, err
+	}
+
+	granted := permissions{}
+	if err = json.Unmarshal(respBody, &granted); err != nil {
+		return nil, err
+	}
+
+	for _, p := range check.Permissions {
+		found := false
+		for _, g := range granted.Permissions {
+			if g == p {
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, p)
+		}
+	}
+	return missing, nil
+}
@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"internal/clilog"
 	"os"
+	"regexp"
 	"strings"
 	"sync"
 )
@@ -64,9 +65,12 @@ type IntegrationClientOptions struct {
 	NoOutput           bool   // Disable all statements to stdout
 	SuppressWarnings   bool   // Disable printing of warnings to stdout
 	ProxyUrl           string // use a proxy url
+	ProxyCert          string // CA certificate of a proxy that intercepts TLS
 	MetadataToken      bool   // use metadata outh2 token
 	ExportToFile       string // determine of the contents should be written to file
 	ConflictsAreErrors bool   // treat statusconflict as an error
+	ConnectorProjectID string // GCP Project ID of the connectors, when different from the integrations
+	ConnectorRegion    string // Region of the connectors, when different from the integrations
 }
 
 var options *IntegrationClientOptions
@@ -100,6 +104,14 @@ var ClientPrintHttpResponse = &clientPrintHttpResponse{enable: true}
 
 var cliVersion, cliCommitSha, cliBuildDate string
 
+// projectIDs caches project numbers resolved to project ids
+var projectIDs = struct {
+	ids map[string]string
+	sync.Mutex
+}{ids: map[string]string{}}
+
+var rProjectNumber = regexp.MustCompile(`^[0-9]+$`)
+
 // NewIntegrationClient sets up options to invoke Integration APIs
 func NewIntegrationClient(o IntegrationClientOptions) {
 	if options == nil {
@@ -189,6 +201,31 @@ func GetRegion() string {
 	return options.Region
 }
 
+// SetConnectorRegion sets the region of the connectors when it is different from the integration region
+func SetConnectorRegion(region string) {
+	options.ConnectorRegion = region
+}
+
+// SetConnectorProjectID sets the project of the connectors when it is different from the integration project
+func SetConnectorProjectID(projectID string) {
+	options.ConnectorProjectID = projectID
+}
+
+// UseConnectorContext switches the project and region to the ones of the connectors, when they are
+// set, until the returned function restores the integration project and region
+func UseConnectorContext() (restore func()) {
+	projectID, region := options.ProjectID, options.Region
+	if options.ConnectorProjectID != "" {
+		options.ProjectID = options.ConnectorProjectID
+	}
+	if options.ConnectorRegion != "" {
+		options.Region = options.ConnectorRegion
+	}
+	return func() {
+		options.ProjectID, options.Region = projectID, region
+	}
+}
+
 // SetIntegrationToken sets the access token for use with Integration API calls
 func SetIntegrationToken(token string) {
 	options.Token = token
@@ -211,11 +248,42 @@ func SetProjectID(projectID string) (err error) {
 	return nil
 }
 
-// GetProjectID gets the project id
+// GetProjectID gets the project id. A project number is resolved to
+// the project id the first time it is used
 func GetProjectID() string {
+	if rProjectNumber.MatchString(options.ProjectID) {
+		options.ProjectID = resolveProjectNumber(options.ProjectID)
+	}
 	return options.ProjectID
 }
 
+// resolveProjectNumber returns the project id for a project number, looking up
+// the in-memory and preferences caches before calling resource manager
+func resolveProjectNumber(projectNumber string) string {
+	projectIDs.Lock()
+	defer projectIDs.Unlock()
+
+	if projectID, ok := projectIDs.ids[projectNumber]; ok {
+		return projectID
+	}
+	projectID := getCachedProjectID(projectNumber)
+	if projectID == "" {
+		var err error
+		if projectID, err = getProjectIDFromNumber(projectNumber); err != nil || projectID == "" {
+			clilog.Warning.Printf("unable to resolve project number %s to a project id, using it as is: %v\n",
+				projectNumber, err)
+			projectIDs.ids[projectNumber] = projectNumber
+			return projectNumber
+		}
+		if err = writeProjectID(projectNumber, projectID); err != nil {
+			clilog.Debug.Println(err)
+		}
+	}
+	clilog.Debug.Printf("Project number %s resolved to %s\n", projectNumber, projectID)
+	projectIDs.ids[projectNumber] = projectID
+	return projectID
+}
+
 // SetServiceAccount
 func SetServiceAccount(serviceAccount string) {
 	options.ServiceAccount = serviceAccount
@@ -296,6 +364,16 @@ func SetProxyURL(proxyurl string) {
 	options.ProxyUrl = proxyurl
 }
 
+// GetProxyCert
+func GetProxyCert() string {
+	return options.ProxyCert
+}
+
+// SetProxyCert
+func SetProxyCert(proxyCert string) {
+	options.ProxyCert = proxyCert
+}
+
 // GetBaseIntegrationURL
 func GetBaseIntegrationURL() (integrationUrl string) {
 	if options.ProjectID == "" || options.Region == "" {
@@ -29,10 +29,12 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/lestrrat-go/jwx/v2/jwa"
 	"github.com/lestrrat-go/jwx/v2/jwt"
+	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/google"
 )
 
@@ -51,11 +53,25 @@ type serviceAccount struct {
 
 var account = serviceAccount{}
 
+// tokenRefresh holds how to get a new access token from the source of the current one.
+// refresh is nil when the token was passed in or read from the cache and cannot be refreshed
+var tokenRefresh struct {
+	sync.Mutex
+	refresh func() error
+}
+
 const tokenUri = "https://www.googleapis.com/oauth2/v4/token"
 
+// tokenScope is the only OAuth scope requested for access tokens, whether they
+// are generated from a service account key or application default credentials
+const tokenScope = "https://www.googleapis.com/auth/cloud-platform"
+
 func getPrivateKey(privateKey string) (interface{}, error) {
 	pemPrivateKey := fmt.Sprintf("%v", privateKey)
 	block, _ := pem.Decode([]byte(pemPrivateKey))
+	if block == nil {
+		return nil, errors.New("private key in the service account is not PEM encoded")
+	}
 	privKey, err := x509.ParsePKCS8PrivateKey(block.Bytes)
 	if err != nil {
 		clilog.Error.Println("error parsing Private Key: ", err)
@@ -65,8 +81,6 @@ func getPrivateKey(privateKey string) (interface{}, error) {
 }
 
 func generateJWT(privateKey string) (string, error) {
-	const scope = "https://www.googleapis.com/auth/cloud-platform"
-
 	privKey, err := getPrivateKey(privateKey)
 	if err != nil {
 		return "", err
@@ -82,7 +96,7 @@ func generateJWT(privateKey string) (string, error) {
 
 	_ = token.Set("aud", tokenUri)
 	_ = token.Set(jwt.IssuerKey, getServiceAccountProperty("ClientEmail"))
-	_ = token.Set("scope", scope)
+	_ = token.Set("scope", tokenScope)
 	_ = token.Set(jwt.IssuedAtKey, now.Unix())
 	_ = token.Set(jwt.ExpirationKey, now.Unix())
 
@@ -109,14 +123,17 @@ func generateAccessToken(privateKey string) (string, error) {
 
 	token, err := generateJWT(privateKey)
 	if err != nil {
-		return "", nil
+		return "", err
 	}
 
 	form := url.Values{}
 	form.Add("grant_type", grantType)
 	form.Add("assertion", token)
 
-	client := &http.Client{}
+	client, err := NewHTTPClient()
+	if err != nil {
+		return "", err
+	}
 	req, err := http.NewRequest("POST", tokenUri, strings.NewReader(form.Encode()))
 	if err != nil {
 		clilog.Error.Println("error in client: ", err)
@@ -169,13 +186,33 @@ func readServiceAccount(serviceAccountPath string) error {
 		return err
 	}
 
-	err = json.Unmarshal(content, &account)
-	if err != nil {
-		return err
+	if err = json.Unmarshal(content, &account); err != nil {
+		return fmt.Errorf("service account key %s is malformed: %w", serviceAccountPath, err)
+	}
+	if account.Type != "service_account" {
+		return fmt.Errorf("%s is not a service account key, type is %q", serviceAccountPath, account.Type)
 	}
 	return nil
 }
 
+// getCredentialType returns the type of the credentials file, such as service_account or authorized_user
+func getCredentialType(credentialsPath string) (string, error) {
+	content, err := os.ReadFile(credentialsPath)
+	if err != nil {
+		return "", err
+	}
+	credentials := struct {
+		Type string `json:"type"`
+	}{}
+	if err = json.Unmarshal(content, &credentials); err != nil {
+		return "", fmt.Errorf("credentials file %s is malformed: %w", credentialsPath, err)
+	}
+	if credentials.Type == "" {
+		return "", fmt.Errorf("credentials file %s has no type", credentialsPath)
+	}
+	return credentials.Type, nil
+}
+
 func getServiceAccountProperty(key string) (value string) {
 	r := reflect.ValueOf(&account)
 	field := reflect.Indirect(r).FieldByName(key)
@@ -194,7 +231,11 @@ func checkAccessToken() bool {
 	q.Set("access_token", GetIntegrationToken())
 	u.RawQuery = q.Encode()
 
-	client := &http.Client{}
+	client, err := NewHTTPClient()
+	if err != nil {
+		clilog.Error.Println("error in client:", err)
+		return false
+	}
 
 	clilog.Debug.Println("Connecting to : ", u.String())
 	req, err := http.NewRequest("GET", u.String(), nil)
@@ -225,14 +266,20 @@ func checkAccessToken() bool {
 // SetAccessToken read from cache or if not found or expired will generate a new one
 func SetAccessToken() error {
 	if GetIntegrationToken() == "" && GetServiceAccount() == "" {
-		SetIntegrationToken(getToken()) // read from configuration
-		if GetIntegrationToken() == "" {
-			return fmt.Errorf("either token or service account must be provided")
-		}
-		if checkAccessToken() { // check if the token is still valid
+		// reuse the cached token while it is still valid
+		SetIntegrationToken(getToken())
+		if GetIntegrationToken() != "" && checkAccessToken() {
+			// the source of a cached token is unknown, so it is not refreshed with another identity
+			setTokenRefresh(nil)
 			return nil
 		}
-		return fmt.Errorf("token expired: request a new access token or pass the service account")
+		// fall back to application default credentials
+		if err := GetDefaultAccessToken(); err != nil {
+			SetIntegrationToken("")
+			return fmt.Errorf("either token or service account must be provided, "+
+				"application default credentials were not available: %w", err)
+		}
+		return nil
 	}
 	if GetIntegrationToken() != "" {
 		// a token was passed, cache it
@@ -241,30 +288,59 @@ func SetAccessToken() error {
 			return nil
 		}
 	} else {
-		err := readServiceAccount(GetServiceAccount())
-		if err != nil { // Handle errors reading the config file
-			return fmt.Errorf("error reading config file: %s", err)
-		}
-		privateKey := getServiceAccountProperty("PrivateKey")
-		if privateKey == "" {
-			return fmt.Errorf("private key missing in the service account")
+		credentialsPath := GetServiceAccount()
+		credentialType, err := getCredentialType(credentialsPath)
+		if err != nil {
+			return err
 		}
-		if getServiceAccountProperty("ClientEmail") == "" {
-			return fmt.Errorf("client email missing in the service account")
+		refresh := func() error { return getServiceAccountAccessToken(credentialsPath) }
+		if credentialType != "service_account" {
+			// user credentials and workload identity federation are loaded by the google library
+			refresh = func() error { return getCredentialsFileAccessToken(credentialsPath) }
 		}
-		_, err = generateAccessToken(privateKey)
-		if err != nil {
-			return fmt.Errorf("fatal error generating access token: %s", err)
+		if err = refresh(); err != nil {
+			return err
 		}
+		setTokenRefresh(refresh)
 		return nil
 	}
 	return fmt.Errorf("token expired: request a new access token or pass the service account")
 }
 
+// getServiceAccountAccessToken generates an access token from the service account key
+func getServiceAccountAccessToken(serviceAccountPath string) error {
+	if err := readServiceAccount(serviceAccountPath); err != nil {
+		return err
+	}
+	privateKey := getServiceAccountProperty("PrivateKey")
+	if privateKey == "" {
+		return fmt.Errorf("private key missing in the service account")
+	}
+	if getServiceAccountProperty("ClientEmail") == "" {
+		return fmt.Errorf("client email missing in the service account")
+	}
+	if _, err := generateAccessToken(privateKey); err != nil {
+		return fmt.Errorf("fatal error generating access token: %s", err)
+	}
+	return nil
+}
+
 // GetDefaultAccessToken
 func GetDefaultAccessToken() (err error) {
-	ctx := context.Background()
-	tokenSource, err := google.DefaultTokenSource(ctx, "https://www.googleapis.com/auth/cloud-platform")
+	if err = getDefaultAccessToken(); err != nil {
+		return err
+	}
+	setTokenRefresh(getDefaultAccessToken)
+	return nil
+}
+
+func getDefaultAccessToken() (err error) {
+	client, err := NewHTTPClient()
+	if err != nil {
+		return err
+	}
+	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, client)
+	tokenSource, err := google.DefaultTokenSource(ctx, tokenScope)
 	if err != nil {
 		return err
 	}
@@ -276,8 +352,128 @@ func GetDefaultAccessToken() (err error) {
 	return nil
 }
 
+// getCredentialsFileAccessToken gets an access token from a credentials file that is not a
+// service account key, such as the authorized_user file written by gcloud auth application-default login
+func getCredentialsFileAccessToken(credentialsPath string) error {
+	content, err := os.ReadFile(credentialsPath)
+	if err != nil {
+		return err
+	}
+	client, err := NewHTTPClient()
+	if err != nil {
+		return err
+	}
+	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, client)
+	credentials, err := google.CredentialsFromJSON(ctx, content, tokenScope)
+	if err != nil {
+		return fmt.Errorf("unable to load the credentials file %s: %w", credentialsPath, err)
+	}
+	token, err := credentials.TokenSource.Token()
+	if err != nil {
+		return err
+	}
+	SetIntegrationToken(token.AccessToken)
+	return nil
+}
+
+// ImpersonateServiceAccount exchanges the current access token for a short-lived
+// token of the service account. delegates is the optional delegation chain, in order
+func ImpersonateServiceAccount(serviceAccount string, delegates []string) (err error) {
+	if err = impersonate(serviceAccount, delegates); err != nil {
+		return err
+	}
+	// the impersonated token is refreshed from a new token of the source credentials
+	tokenRefresh.Lock()
+	defer tokenRefresh.Unlock()
+	if refresh := tokenRefresh.refresh; refresh != nil {
+		tokenRefresh.refresh = func() error {
+			if err := refresh(); err != nil {
+				return err
+			}
+			return impersonate(serviceAccount, delegates)
+		}
+	}
+	return nil
+}
+
+func impersonate(serviceAccount string, delegates []string) (err error) {
+	const tokenLifetime = "3600s"
+
+	if GetIntegrationToken() == "" {
+		return fmt.Errorf("an access token is required to impersonate %s", serviceAccount)
+	}
+
+	request := struct {
+		Delegates []string `json:"delegates,omitempty"`
+		Scope     []string `json:"scope"`
+		Lifetime  string   `json:"lifetime"`
+	}{
+		Scope:    []string{tokenScope},
+		Lifetime: tokenLifetime,
+	}
+	for _, delegate := range delegates {
+		request.Delegates = append(request.Delegates, "projects/-/serviceAccounts/"+delegate)
+	}
+
+	payload, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	u := fmt.Sprintf("https://iamcredentials.googleapis.com/v1/projects/-/serviceAccounts/%s:generateAccessToken",
+		serviceAccount)
+
+	printSetting := ClientPrintHttpResponse.Get()
+	ClientPrintHttpResponse.Set(false)
+	defer ClientPrintHttpResponse.Set(printSetting)
+
+	// the request is not sent with HttpClient, so a 401 is not retried with a refreshed token
+	client, err := getHttpClient()
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, u, strings.NewReader(string(payload)))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+GetIntegrationToken())
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("unable to impersonate %s: %w", serviceAccount, err)
+	}
+	respBody, err := handleResponse(resp)
+	if err != nil {
+		return fmt.Errorf("unable to impersonate %s: %w", serviceAccount, err)
+	}
+
+	response := struct {
+		AccessToken string `json:"accessToken,omitempty"`
+		ExpireTime  string `json:"expireTime,omitempty"`
+	}{}
+	if err = json.Unmarshal(respBody, &response); err != nil {
+		return err
+	}
+	if response.AccessToken == "" {
+		return fmt.Errorf("no access token was returned to impersonate %s", serviceAccount)
+	}
+
+	clilog.Debug.Printf("Impersonating %s until %s\n", serviceAccount, response.ExpireTime)
+	// the impersonated token is not cached, it only lives for this command
+	SetIntegrationToken(response.AccessToken)
+	return nil
+}
+
 // GetMetadataAccessToken
 func GetMetadataAccessToken() (err error) {
+	if err = getMetadataAccessToken(); err != nil {
+		return err
+	}
+	setTokenRefresh(getMetadataAccessToken)
+	return nil
+}
+
+func getMetadataAccessToken() (err error) {
 	var req *http.Request
 	var tokenResponse map[string]interface{}
 
@@ -335,3 +531,26 @@ func GetMetadataAccessToken() (err error) {
 
 	return nil
 }
+
+// refreshAccessToken replaces the expired access token with a new one from the same source.
+// Requests that failed with the same token share one refresh
+func refreshAccessToken(expired string) error {
+	tokenRefresh.Lock()
+	defer tokenRefresh.Unlock()
+
+	if GetIntegrationToken() != expired {
+		return nil
+	}
+	if tokenRefresh.refresh == nil {
+		return errors.New("the access token was passed in or read from the cache and cannot be refreshed")
+	}
+	clilog.Debug.Println("the access token expired, getting a new one")
+	return tokenRefresh.refresh()
+}
+
+// setTokenRefresh records how to get a new access token from the source of the current one
+func setTokenRefresh(refresh func() error) {
+	tokenRefresh.Lock()
+	defer tokenRefresh.Unlock()
+	tokenRefresh.refresh = refresh
+}
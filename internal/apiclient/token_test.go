@@ -0,0 +1,80 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiclient
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSetAccessTokenWithAuthorizedUser(t *testing.T) {
+	NewIntegrationClient(IntegrationClientOptions{
+		SkipCache: true,
+		NoOutput:  true,
+	})
+	defer func() {
+		tokenRefresh.refresh = nil
+		SetIntegrationToken("")
+		SetServiceAccount("")
+	}()
+
+	var refreshTokens []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		refreshTokens = append(refreshTokens, r.PostForm.Get("refresh_token"))
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"access_token":"user-token-%d","token_type":"Bearer","expires_in":3600}`, len(refreshTokens))
+	}))
+	defer server.Close()
+
+	credentialsFile := filepath.Join(t.TempDir(), "credentials.json")
+	credentials := fmt.Sprintf(`{"type":"authorized_user","client_id":"id","client_secret":"secret",`+
+		`"refresh_token":"refresh","token_uri":%q}`, server.URL)
+	if err := os.WriteFile(credentialsFile, []byte(credentials), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	SetIntegrationToken("")
+	SetServiceAccount(credentialsFile)
+	if err := SetAccessToken(); err != nil {
+		t.Fatalf("SetAccessToken() error = %v", err)
+	}
+	if GetIntegrationToken() != "user-token-1" {
+		t.Errorf("SetAccessToken() token = %q, want the token of the user credentials", GetIntegrationToken())
+	}
+	if len(refreshTokens) != 1 || refreshTokens[0] != "refresh" {
+		t.Errorf("token endpoint got refresh tokens %v, want [refresh]", refreshTokens)
+	}
+
+	// an expired token is refreshed from the same credentials file
+	if err := refreshAccessToken("user-token-1"); err != nil {
+		t.Fatalf("refreshAccessToken() error = %v", err)
+	}
+	if GetIntegrationToken() != "user-token-2" {
+		t.Errorf("refreshAccessToken() token = %q, want user-token-2", GetIntegrationToken())
+	}
+
+	if err := os.WriteFile(credentialsFile, []byte(`{"client_id":"id"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	SetIntegrationToken("")
+	if err := SetAccessToken(); err == nil {
+		t.Errorf("SetAccessToken() with a credentials file without type succeeded, expected an error")
+	}
+}
@@ -0,0 +1,229 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const redacted = "REDACTED"
+
+// redactedHeaders are the headers that carry credentials
+var redactedHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie", "X-Goog-Api-Key"}
+
+// tracer writes the API requests and responses to the trace file
+var tracer struct {
+	sync.Mutex
+	w io.Writer
+}
+
+// SetTraceFile writes each API request and response to the file, with credentials redacted
+func SetTraceFile(traceFile string) error {
+	f, err := os.OpenFile(traceFile, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return fmt.Errorf("unable to open trace file: %w", err)
+	}
+	tracer.Lock()
+	defer tracer.Unlock()
+	tracer.w = f
+	return nil
+}
+
+// CloseTraceFile stops tracing and closes the trace file
+func CloseTraceFile() error {
+	tracer.Lock()
+	defer tracer.Unlock()
+	w := tracer.w
+	tracer.w = nil
+	if f, ok := w.(io.Closer); ok {
+		return f.Close()
+	}
+	return nil
+}
+
+func isTracing() bool {
+	tracer.Lock()
+	defer tracer.Unlock()
+	return tracer.w != nil
+}
+
+// traceTransport wraps the transport so the requests are traced when a trace file is set
+func traceTransport(rt http.RoundTripper) http.RoundTripper {
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		if !isTracing() {
+			return rt.RoundTrip(req)
+		}
+
+		var reqBody []byte
+		if req.Body != nil {
+			var err error
+			if reqBody, err = io.ReadAll(req.Body); err != nil {
+				return nil, err
+			}
+			req.Body.Close()
+			req.Body = io.NopCloser(bytes.NewReader(reqBody))
+		}
+
+		start := time.Now()
+		resp, err := rt.RoundTrip(req)
+
+		var b strings.Builder
+		fmt.Fprintf(&b, "--- %s\n", start.UTC().Format(time.RFC3339Nano))
+		fmt.Fprintf(&b, "> %s %s\n", req.Method, redactURL(req.URL))
+		writeTraceHeaders(&b, "> ", req.Header)
+		writeTraceBody(&b, "> ", req.Header.Get("Content-Type"), reqBody)
+
+		if err != nil {
+			fmt.Fprintf(&b, "< error: %v\n", err)
+		} else {
+			respBody, rerr := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			resp.Body = io.NopCloser(bytes.NewReader(respBody))
+			if rerr != nil {
+				return nil, rerr
+			}
+			fmt.Fprintf(&b, "< %s (%s)\n", resp.Status, time.Since(start).Round(time.Millisecond))
+			writeTraceHeaders(&b, "< ", resp.Header)
+			writeTraceBody(&b, "< ", resp.Header.Get("Content-Type"), respBody)
+		}
+
+		tracer.Lock()
+		_, _ = io.WriteString(tracer.w, b.String())
+		tracer.Unlock()
+		return resp, err
+	})
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func writeTraceHeaders(b *strings.Builder, prefix string, header http.Header) {
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		value := strings.Join(header[name], ", ")
+		for _, h := range redactedHeaders {
+			if strings.EqualFold(name, h) {
+				value = redacted
+			}
+		}
+		fmt.Fprintf(b, "%s%s: %s\n", prefix, name, value)
+	}
+}
+
+func writeTraceBody(b *strings.Builder, prefix string, contentType string, body []byte) {
+	if len(body) == 0 {
+		return
+	}
+	b.WriteString(prefix + "\n")
+	for _, line := range strings.Split(string(redactBody(contentType, body)), "\n") {
+		b.WriteString(prefix + line + "\n")
+	}
+}
+
+// isSecretKey returns true for the names of fields and parameters that hold credentials
+func isSecretKey(key string) bool {
+	key = strings.ToLower(key)
+	if strings.HasSuffix(key, "pagetoken") {
+		return false
+	}
+	for _, s := range []string{"password", "secret", "token", "privatekey", "private_key", "assertion", "apikey",
+		"sshclientcert", "passphrase", "jwt"} {
+		if strings.Contains(key, s) {
+			return true
+		}
+	}
+	return false
+}
+
+func redactURL(u *url.URL) string {
+	q := u.Query()
+	if len(q) == 0 {
+		return u.String()
+	}
+	for key := range q {
+		if isSecretKey(key) {
+			q.Set(key, redacted)
+		}
+	}
+	r := *u
+	r.RawQuery = q.Encode()
+	return r.String()
+}
+
+// redactBody returns the JSON or form body with the values of the secret fields redacted.
+// Other bodies are returned as is
+func redactBody(contentType string, body []byte) []byte {
+	if strings.HasPrefix(contentType, "application/x-www-form-urlencoded") {
+		form, err := url.ParseQuery(string(body))
+		if err != nil {
+			return body
+		}
+		for key := range form {
+			if isSecretKey(key) {
+				form.Set(key, redacted)
+			}
+		}
+		return []byte(form.Encode())
+	}
+
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err != nil {
+		return body
+	}
+	redactJSON(v)
+	redactedBody, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return body
+	}
+	return redactedBody
+}
+
+func redactJSON(v interface{}) {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		for key, field := range value {
+			switch field.(type) {
+			case map[string]interface{}, []interface{}:
+				// objects and lists, such as usernameAndPassword, are redacted field by field
+				redactJSON(field)
+			default:
+				if isSecretKey(key) {
+					value[key] = redacted
+				}
+			}
+		}
+	case []interface{}:
+		for _, item := range value {
+			redactJSON(item)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiclient
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestTraceTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(bytes.Replace(body, []byte("admin"), []byte("created"), 1))
+	}))
+	defer server.Close()
+
+	var trace bytes.Buffer
+	tracer.Lock()
+	tracer.w = &trace
+	tracer.Unlock()
+	defer func() {
+		tracer.Lock()
+		tracer.w = nil
+		tracer.Unlock()
+	}()
+
+	req, _ := http.NewRequest(http.MethodPost, server.URL+"/v1/authConfigs?access_token=abc&pageToken=next",
+		strings.NewReader(`{"decryptedCredential":{"usernameAndPassword":{"username":"admin","password":"s3cret"}}}`))
+	req.Header.Set("Authorization", "Bearer ya29.token")
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := (&http.Client{Transport: traceTransport(http.DefaultTransport)}).Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if !strings.Contains(string(body), `"username":"created"`) || !strings.Contains(string(body), "s3cret") {
+		t.Errorf("response body = %s, want the unredacted response", body)
+	}
+
+	got := trace.String()
+	for _, secret := range []string{"s3cret", "ya29.token", "access_token=abc"} {
+		if strings.Contains(got, secret) {
+			t.Errorf("trace contains %s:\n%s", secret, got)
+		}
+	}
+	for _, want := range []string{"> POST ", "pageToken=next", "> Authorization: REDACTED", `"username": "admin"`,
+		`"username": "created"`, "< 200 OK"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("trace does not contain %s:\n%s", want, got)
+		}
+	}
+}
+
+func TestRedactBody(t *testing.T) {
+	body := []byte(`{"decryptedCredential":{"sshPublicKey":{"username":"admin","sshClientCert":"cert",
+"sshClientCertPass":"pass"},"jwt":{"jwtHeader":"header","secret":"s3cret"},"passphrase":"open sesame",
+"oidcToken":{"audience":"aud"},"password":12345}}`)
+	got := string(redactBody("application/json", body))
+	for _, secret := range []string{`"cert"`, `"pass"`, "header", "s3cret", "sesame", "12345"} {
+		if strings.Contains(got, secret) {
+			t.Errorf("redactBody() contains %s:\n%s", secret, got)
+		}
+	}
+	if !strings.Contains(got, `"username": "admin"`) || !strings.Contains(got, `"audience": "aud"`) {
+		t.Errorf("redactBody() = %s, want the fields that are not secret", got)
+	}
+}
+
+func TestCloseTraceFile(t *testing.T) {
+	traceFile := t.TempDir() + "/trace.log"
+	if err := SetTraceFile(traceFile); err != nil {
+		t.Fatal(err)
+	}
+	f, _ := tracer.w.(*os.File)
+	if err := CloseTraceFile(); err != nil {
+		t.Fatalf("CloseTraceFile() error = %v", err)
+	}
+	if isTracing() {
+		t.Errorf("isTracing() = true after CloseTraceFile()")
+	}
+	if _, err := f.Write([]byte("x")); err == nil {
+		t.Errorf("trace file is still open after CloseTraceFile()")
+	}
+}
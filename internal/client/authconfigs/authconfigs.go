@@ -42,10 +42,19 @@ type authConfig struct {
 	LastModifierEmail   string               `json:"lastModifierEmail,omitempty"`
 	Visibility          string               `json:"visibility,omitempty"`
 	State               string               `json:"state,omitempty"`
+	CredentialType      string               `json:"credentialType,omitempty"`
 	Reason              string               `json:"reason,omitempty"`
 	ValidTime           string               `json:"validTime,omitempty"`
 }
 
+// Summary holds the fields of an authconfig shown in listings
+type Summary struct {
+	Id          string `json:"id,omitempty"`
+	DisplayName string `json:"displayName,omitempty"`
+	Type        string `json:"type,omitempty"`
+	State       string `json:"state,omitempty"`
+}
+
 type authConfigExternal struct {
 	DisplayName         string               `json:"displayName,omitempty"`
 	Description         string               `json:"description,omitempty"`
@@ -190,6 +199,66 @@ func List(pageSize int, pageToken string, filter string) (respBody []byte, err e
 	return respBody, err
 }
 
+// ListAll returns the authconfigs from all pages as a single list response
+func ListAll(filter string) (respBody []byte, err error) {
+	allConfigs := authConfigs{}
+	pageToken := ""
+
+	apiclient.ClientPrintHttpResponse.Set(false)
+	defer apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting())
+
+	for {
+		ac := authConfigs{}
+		if respBody, err = List(-1, pageToken, filter); err != nil {
+			return nil, err
+		}
+		if err = json.Unmarshal(respBody, &ac); err != nil {
+			return nil, err
+		}
+		allConfigs.AuthConfig = append(allConfigs.AuthConfig, ac.AuthConfig...)
+		if ac.NextPageToken == "" {
+			break
+		}
+		pageToken = ac.NextPageToken
+	}
+
+	return json.Marshal(allConfigs)
+}
+
+// GetSummaries returns a summary of each authconfig in a list response
+func GetSummaries(respBody []byte) (summaries []Summary, err error) {
+	ac := authConfigs{}
+	if err = json.Unmarshal(respBody, &ac); err != nil {
+		return nil, err
+	}
+	for _, config := range ac.AuthConfig {
+		summaries = append(summaries, getSummary(config))
+	}
+	return summaries, nil
+}
+
+// GetSummary returns a summary of the authconfig in a get response
+func GetSummary(respBody []byte) (summary Summary, err error) {
+	config := authConfig{}
+	if err = json.Unmarshal(respBody, &config); err != nil {
+		return summary, err
+	}
+	return getSummary(config), nil
+}
+
+func getSummary(config authConfig) Summary {
+	summary := Summary{
+		Id:          filepath.Base(config.Name),
+		DisplayName: config.DisplayName,
+		Type:        config.CredentialType,
+		State:       config.State,
+	}
+	if summary.Type == "" && config.DecryptedCredential != nil {
+		summary.Type = config.DecryptedCredential.CredentialType
+	}
+	return summary
+}
+
 // Find
 func Find(name string, pageToken string) (version string, err error) {
 	ac := authConfigs{}
@@ -251,10 +320,11 @@ func Export(folder string) (err error) {
 
 	for aconfigs.NextPageToken != "" {
 
-		if respBody, err = List(100, "", ""); err != nil {
+		if respBody, err = List(100, aconfigs.NextPageToken, ""); err != nil {
 			return err
 		}
 
+		aconfigs = authConfigs{}
 		if err = json.Unmarshal(respBody, &aconfigs); err != nil {
 			return err
 		}
@@ -15,10 +15,13 @@
 package authconfigs
 
 import (
+	"errors"
 	"internal/client/clienttest"
 	"internal/cmd/utils"
+	"internal/secmgr"
 	"os"
 	"path"
+	"strings"
 	"testing"
 )
 
@@ -86,3 +89,48 @@ func TestDelete(t *testing.T) {
 		t.Fatalf("Delete failed: %v", err)
 	}
 }
+
+func TestSecretPlaceholders(t *testing.T) {
+	contents := []byte(`{"displayName":"db user","decryptedCredential":{"credentialType":"USERNAME_AND_PASSWORD",
+"usernameAndPassword":{"username":"admin"}}}`)
+
+	placeholders, err := SetSecretPlaceholders(contents, "my-project")
+	if err != nil {
+		t.Fatalf("SetSecretPlaceholders() error = %v", err)
+	}
+	want := SecretPlaceholderPrefix + "projects/my-project/secrets/db-user-password/versions/latest"
+	if !strings.Contains(string(placeholders), `"password":"`+want+`"`) {
+		t.Fatalf("SetSecretPlaceholders() = %s, want a password placeholder %s", placeholders, want)
+	}
+
+	defer func() { accessSecret = secmgr.Access }()
+	accessSecret = func(secretVersion string) ([]byte, error) {
+		if secretVersion != strings.TrimPrefix(want, SecretPlaceholderPrefix) {
+			t.Errorf("accessSecret() secret version = %s", secretVersion)
+		}
+		return []byte("s3cret"), nil
+	}
+	resolved, err := ResolveSecrets(placeholders)
+	if err != nil {
+		t.Fatalf("ResolveSecrets() error = %v", err)
+	}
+	if !strings.Contains(string(resolved), `"password":"s3cret"`) || !strings.Contains(string(resolved), `"username":"admin"`) {
+		t.Errorf("ResolveSecrets() = %s, want the password from the secret", resolved)
+	}
+
+	accessSecret = func(string) ([]byte, error) { return nil, errors.New("permission denied") }
+	if _, err = ResolveSecrets(placeholders); err == nil {
+		t.Errorf("ResolveSecrets() succeeded, expected the secret error")
+	}
+	// the client secret of the oauth2 credentials is not returned either
+	for _, credentialType := range []string{"oauth2ClientCredentials", "oauth2AuthorizationCode"} {
+		contents = []byte(`{"displayName":"oauth client","decryptedCredential":{"` + credentialType + `":{"clientId":"id"}}}`)
+		if placeholders, err = SetSecretPlaceholders(contents, "my-project"); err != nil {
+			t.Fatalf("SetSecretPlaceholders() error = %v", err)
+		}
+		want = SecretPlaceholderPrefix + "projects/my-project/secrets/oauth-client-clientSecret/versions/latest"
+		if !strings.Contains(string(placeholders), `"clientSecret":"`+want+`"`) {
+			t.Errorf("SetSecretPlaceholders() = %s, want a client secret placeholder %s", placeholders, want)
+		}
+	}
+}
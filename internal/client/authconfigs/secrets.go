@@ -0,0 +1,114 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authconfigs
+
+import (
+	"encoding/json"
+	"fmt"
+	"internal/secmgr"
+	"regexp"
+	"strings"
+)
+
+// SecretPlaceholderPrefix marks a credential field that is read from a Secret Manager secret version
+// when the authconfig is created, for ex:
+// "password": "secretmanager:projects/my-project/secrets/my-authconfig-password/versions/latest"
+const SecretPlaceholderPrefix = "secretmanager:"
+
+// secretFields are the credential fields that the API does not return
+var secretFields = map[string][]string{
+	"usernameAndPassword":            {"password"},
+	"jwt":                            {"secret"},
+	"authToken":                      {"token"},
+	"oauth2ResourceOwnerCredentials": {"clientSecret", "password"},
+	"oauth2ClientCredentials":        {"clientSecret"},
+	"oauth2AuthorizationCode":        {"clientSecret"},
+}
+
+var rSecretIdChars = regexp.MustCompile(`[^a-zA-Z0-9_-]`)
+
+// accessSecret reads a secret version; it is a variable to replace Secret Manager in tests
+var accessSecret = secmgr.Access
+
+// SetSecretPlaceholders sets each secret field of the authconfig credential to a placeholder
+// for the secret projects/{project}/secrets/{displayName}-{field}/versions/latest
+func SetSecretPlaceholders(content []byte, project string) (placeholders []byte, err error) {
+	var a map[string]interface{}
+	if err = json.Unmarshal(content, &a); err != nil {
+		return nil, err
+	}
+	credential, ok := a["decryptedCredential"].(map[string]interface{})
+	if !ok {
+		return content, nil
+	}
+	displayName, _ := a["displayName"].(string)
+	for credentialType, fields := range secretFields {
+		c, ok := credential[credentialType].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for _, field := range fields {
+			secretId := rSecretIdChars.ReplaceAllString(displayName+"-"+field, "-")
+			secretVersion := fmt.Sprintf("projects/%s/secrets/%s/versions/latest", project, secretId)
+			c[field] = SecretPlaceholderPrefix + secretVersion
+		}
+	}
+	return json.Marshal(a)
+}
+
+// ResolveSecrets replaces the secret placeholders of the authconfig with the payload of the secret versions
+func ResolveSecrets(content []byte) (resolved []byte, err error) {
+	if !strings.Contains(string(content), SecretPlaceholderPrefix) {
+		return content, nil
+	}
+	var a interface{}
+	if err = json.Unmarshal(content, &a); err != nil {
+		return nil, err
+	}
+	if a, err = resolveSecrets(a); err != nil {
+		return nil, err
+	}
+	return json.Marshal(a)
+}
+
+func resolveSecrets(v interface{}) (interface{}, error) {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		for key, field := range value {
+			resolved, err := resolveSecrets(field)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", key, err)
+			}
+			value[key] = resolved
+		}
+	case []interface{}:
+		for i, item := range value {
+			resolved, err := resolveSecrets(item)
+			if err != nil {
+				return nil, err
+			}
+			value[i] = resolved
+		}
+	case string:
+		if secretVersion, found := strings.CutPrefix(value, SecretPlaceholderPrefix); found {
+			payload, err := accessSecret(secretVersion)
+			if err != nil {
+				return nil, fmt.Errorf("unable to read secret %s: %w", secretVersion, err)
+			}
+			return string(payload), nil
+		}
+	}
+	return v, nil
+}
@@ -55,6 +55,13 @@ type connection struct {
 	EventingConfig         *eventingConfig     `json:"eventingConfig,omitempty"`
 }
 
+type connectionState struct {
+	Status *struct {
+		State       string `json:"state,omitempty"`
+		Description string `json:"description,omitempty"`
+	} `json:"status,omitempty"`
+}
+
 type connectionRequest struct {
 	Labels                 *map[string]string   `json:"labels,omitempty"`
 	Description            *string              `json:"description,omitempty"`
@@ -251,9 +258,15 @@ type proxyDestinationConfig struct {
 
 const interval = 10
 
+// CheckJSON returns an error naming the first field of the connection that is not part of
+// the known schema. Such fields are dropped when the connection is created
+func CheckJSON(content []byte) error {
+	return apiclient.UnmarshalStrict(content, &connectionRequest{})
+}
+
 // Create
 func Create(name string, content []byte, serviceAccountName string, serviceAccountProject string,
-	encryptionKey string, grantPermission bool, createSecret bool, wait bool,
+	encryptionKey string, grantPermission bool, createSecret bool, wait bool, waitTimeout time.Duration,
 ) (respBody []byte, err error) {
 	if serviceAccountName != "" && strings.Contains(serviceAccountName, ".iam.gserviceaccount.com") {
 		serviceAccountName = strings.Split(serviceAccountName, "@")[0]
@@ -277,9 +290,17 @@ func Create(name string, content []byte, serviceAccountName string, serviceAccou
 		operationId := filepath.Base(o.Name)
 		clilog.Info.Printf("Checking connection status for %s in %d seconds\n", operationId, interval)
 
-		stop := apiclient.Every(interval*time.Second, func(time.Time) bool {
+		// a zero timeout waits until the operation is done
+		deadline := time.Now().Add(waitTimeout)
+
+		stop := apiclient.Every(interval*time.Second, func(t time.Time) bool {
 			var respBody []byte
 
+			if waitTimeout > 0 && t.After(deadline) {
+				err = fmt.Errorf("connector %s did not become active within %s", name, waitTimeout)
+				return false
+			}
+
 			if respBody, err = GetOperation(operationId); err != nil {
 				return false
 			}
@@ -307,6 +328,58 @@ func Create(name string, content []byte, serviceAccountName string, serviceAccou
 	return respBody, err
 }
 
+// GetIAMChecks returns the IAM permissions the caller needs to grant the connection
+// service account access to the resources used by the connection
+func GetIAMChecks(content []byte, createSecret bool) (checks []apiclient.IAMCheck, err error) {
+	c := connectionRequest{}
+	if err = json.Unmarshal(content, &c); err != nil {
+		return nil, err
+	}
+
+	if c.ConnectorDetails == nil {
+		return nil, nil
+	}
+
+	var projectID, topicName string
+	if c.ConfigVariables != nil {
+		for _, configVar := range *c.ConfigVariables {
+			if configVar.StringValue == nil {
+				continue
+			}
+			switch configVar.Key {
+			case "project_id":
+				projectID = *configVar.StringValue
+			case "topic_id":
+				topicName = *configVar.StringValue
+			}
+		}
+	}
+	if projectID == "$PROJECT_ID$" {
+		projectID = apiclient.GetProjectID()
+	}
+
+	if projectID != "" {
+		switch c.ConnectorDetails.Name {
+		case "pubsub":
+			if topicName != "" {
+				checks = append(checks, apiclient.PubSubIAMCheck(projectID, topicName, "pubsub.topics.setIamPolicy"))
+			}
+		case "bigquery":
+			checks = append(checks, apiclient.ProjectIAMCheck(projectID, "bigquery.datasets.update"))
+		case "gcs", "cloudsql-mysql", "cloudsql-postgresql", "cloudsql-sqlserver", "cloudspanner":
+			checks = append(checks, apiclient.ProjectIAMCheck(projectID, "resourcemanager.projects.setIamPolicy"))
+		}
+	}
+
+	if createSecret && c.AuthConfig != nil &&
+		((c.AuthConfig.UserPassword != nil && c.AuthConfig.UserPassword.PasswordDetails != nil) ||
+			(c.AuthConfig.Oauth2JwtBearer != nil && c.AuthConfig.Oauth2JwtBearer.ClientKeyDetails != nil)) {
+		checks = append(checks, apiclient.ProjectIAMCheck(apiclient.GetProjectID(), "secretmanager.secrets.setIamPolicy"))
+	}
+
+	return checks, nil
+}
+
 // create
 func create(name string, content []byte, serviceAccountName string, serviceAccountProject string,
 	encryptionKey string, grantPermission bool, createSecret bool,
@@ -827,6 +900,99 @@ func Get(name string, view string, minimal bool, overrides bool) (respBody []byt
 	return respBody, err
 }
 
+// GetConnectionHosts returns the destination hosts of a connection
+func GetConnectionHosts(respBody []byte) (hosts []string, err error) {
+	c := connection{}
+	if err = json.Unmarshal(respBody, &c); err != nil {
+		return nil, err
+	}
+	for _, d := range c.DestinationConfig {
+		for _, dest := range d.Destinations {
+			if dest.Host != "" {
+				hosts = append(hosts, dest.Host)
+			}
+		}
+	}
+	return hosts, nil
+}
+
+// GetState returns the lifecycle state of a connection, for ex: CREATING, ACTIVE or ERROR
+func GetState(name string) (state string, err error) {
+	u, _ := url.Parse(apiclient.GetBaseConnectorURL())
+	u.Path = path.Join(u.Path, name)
+
+	apiclient.ClientPrintHttpResponse.Set(false)
+	respBody, err := apiclient.HttpClient(u.String())
+	apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting())
+	if err != nil {
+		return "", err
+	}
+
+	c := connectionState{}
+	if err = json.Unmarshal(respBody, &c); err != nil {
+		return "", err
+	}
+	if c.Status == nil || c.Status.State == "" {
+		return "", fmt.Errorf("state not found for connection %s", name)
+	}
+
+	apiclient.PrettyPrint([]byte(fmt.Sprintf("{\"state\": %q}", c.Status.State)))
+	return c.Status.State, nil
+}
+
+// TestConnection checks the connection can reach its backend by refreshing the connection
+// schema metadata, which the connector reads from the backend. When the refresh fails,
+// the error holds the diagnostic message of the operation or of the connection status. A zero
+// waitTimeout waits for the refresh without limit
+func TestConnection(name string, waitTimeout time.Duration) (err error) {
+	var respBody []byte
+
+	apiclient.ClientPrintHttpResponse.Set(false)
+	defer apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting())
+
+	u, _ := url.Parse(apiclient.GetBaseConnectorURL())
+	u.Path = path.Join(u.Path, name, "connectionSchemaMetadata:refresh")
+	if respBody, err = apiclient.HttpClient(u.String(), ""); err != nil {
+		return fmt.Errorf("unable to test connection %s, the connector may not support it: %w", name, err)
+	}
+
+	o := operation{}
+	if err = json.Unmarshal(respBody, &o); err != nil {
+		return err
+	}
+	deadline := time.Now().Add(waitTimeout)
+	for !o.Done {
+		if waitTimeout > 0 && time.Now().After(deadline) {
+			return fmt.Errorf("the connection test of %s did not complete within %s", name, waitTimeout)
+		}
+		clilog.Info.Printf("Waiting %d seconds for the connection test of %s\n", interval, name)
+		time.Sleep(interval * time.Second)
+		if respBody, err = GetOperation(filepath.Base(o.Name)); err != nil {
+			return err
+		}
+		if err = json.Unmarshal(respBody, &o); err != nil {
+			return err
+		}
+	}
+	if o.Error != nil {
+		return fmt.Errorf("connection %s failed the connection test: %s", name, o.Error.Message)
+	}
+
+	u, _ = url.Parse(apiclient.GetBaseConnectorURL())
+	u.Path = path.Join(u.Path, name)
+	if respBody, err = apiclient.HttpClient(u.String()); err != nil {
+		return err
+	}
+	c := connectionState{}
+	if err = json.Unmarshal(respBody, &c); err != nil {
+		return err
+	}
+	if c.Status != nil && c.Status.State == "ERROR" {
+		return fmt.Errorf("connection %s is in state ERROR: %s", name, c.Status.Description)
+	}
+	return nil
+}
+
 // Get Connection details With region
 func GetConnectionDetailWithRegion(name string, region string, view string, minimal bool, overrides bool) (respBody []byte, err error) {
 	var connectionPayload []byte
@@ -948,6 +1114,55 @@ func List(pageSize int, pageToken string, filter string, orderBy string) (respBo
 	return respBody, err
 }
 
+// ListNames returns the names of all the connections in the region
+func ListNames() (names []string, err error) {
+	return listNames("connections", func(pageToken string) ([]byte, error) {
+		return List(maxPageSize, pageToken, "", "")
+	})
+}
+
+// listNames returns the short names of the resources in the field of every page returned by list
+func listNames(field string, list func(pageToken string) ([]byte, error)) (names []string, err error) {
+	var respBody []byte
+	pageToken := ""
+
+	apiclient.ClientPrintHttpResponse.Set(false)
+	defer apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting())
+
+	for {
+		page := map[string]json.RawMessage{}
+		resources := []struct {
+			Name string `json:"name,omitempty"`
+		}{}
+		nextPageToken := ""
+
+		if respBody, err = list(pageToken); err != nil {
+			return nil, err
+		}
+		if err = json.Unmarshal(respBody, &page); err != nil {
+			return nil, err
+		}
+		if raw, ok := page[field]; ok {
+			if err = json.Unmarshal(raw, &resources); err != nil {
+				return nil, err
+			}
+		}
+		for _, r := range resources {
+			names = append(names, path.Base(r.Name))
+		}
+		if raw, ok := page["nextPageToken"]; ok {
+			if err = json.Unmarshal(raw, &nextPageToken); err != nil {
+				return nil, err
+			}
+		}
+		if nextPageToken == "" {
+			break
+		}
+		pageToken = nextPageToken
+	}
+	return names, nil
+}
+
 func Patch(name string, content []byte, updateMask []string) (respBody []byte, err error) {
 	c := connectionRequest{}
 	if err = json.Unmarshal(content, &c); err != nil {
@@ -968,6 +1183,78 @@ func Patch(name string, content []byte, updateMask []string) (respBody []byte, e
 	return apiclient.HttpClient(u.String(), string(content), "PATCH")
 }
 
+// RotateSecret adds a new version with the payload to the secret referenced by an auth config
+// field of the connection and updates the connection to use the new version
+func RotateSecret(name string, field string, payload []byte) (respBody []byte, err error) {
+	apiclient.ClientPrintHttpResponse.Set(false)
+	respBody, err = Get(name, "", false, false)
+	apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting())
+	if err != nil {
+		return nil, err
+	}
+
+	c := connectionRequest{}
+	if err = json.Unmarshal(respBody, &c); err != nil {
+		return nil, err
+	}
+	if c.AuthConfig == nil {
+		return nil, fmt.Errorf("connection %s does not have an auth config", name)
+	}
+
+	s, err := getAuthSecret(c.AuthConfig, field)
+	if err != nil {
+		return nil, err
+	}
+
+	secretVersion, err := secmgr.AddVersion(s.SecretVersion, payload)
+	if err != nil {
+		return nil, err
+	}
+	clilog.Info.Printf("Created secret version %s\n", secretVersion)
+	s.SecretVersion = secretVersion
+
+	content, err := json.Marshal(connectionRequest{AuthConfig: c.AuthConfig})
+	if err != nil {
+		return nil, err
+	}
+	return Patch(name, content, []string{"authConfig"})
+}
+
+// getAuthSecret returns the secret of the auth config field
+func getAuthSecret(a *authConfig, field string) (s *secret, err error) {
+	switch field {
+	case "password":
+		if a.UserPassword != nil {
+			s = a.UserPassword.Password
+		} else if a.SshPublicKey != nil {
+			s = a.SshPublicKey.Password
+		}
+	case "clientKey":
+		if a.Oauth2JwtBearer != nil {
+			s = a.Oauth2JwtBearer.ClientKey
+		}
+	case "clientSecret":
+		if a.Oauth2ClientCredentials != nil {
+			s = a.Oauth2ClientCredentials.ClientSecret
+		}
+	case "sshClientCert":
+		if a.SshPublicKey != nil {
+			s = a.SshPublicKey.SshClientCert
+		}
+	case "sslClientCertPass":
+		if a.SshPublicKey != nil {
+			s = a.SshPublicKey.SslClientCertPass
+		}
+	default:
+		return nil, fmt.Errorf("unsupported field %s; must be one of password, clientKey, clientSecret, "+
+			"sshClientCert or sslClientCertPass", field)
+	}
+	if s == nil || s.SecretVersion == "" {
+		return nil, fmt.Errorf("field %s does not reference a secret in the %s auth config", field, a.AuthType)
+	}
+	return s, nil
+}
+
 func readSecretFile(name string) (payload []byte, err error) {
 	if _, err := os.Stat(name); os.IsNotExist(err) {
 		return nil, fmt.Errorf("unable to open secret file %s, err: %w", name, err)
@@ -981,7 +1268,7 @@ func readSecretFile(name string) (payload []byte, err error) {
 }
 
 // Import
-func Import(folder string, createSecret bool, wait bool) (err error) {
+func Import(folder string, createSecret bool, wait bool, waitTimeout time.Duration) (err error) {
 	apiclient.ClientPrintHttpResponse.Set(false)
 	defer apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting())
 	errs := []string{}
@@ -1004,7 +1291,7 @@ func Import(folder string, createSecret bool, wait bool) (err error) {
 		}
 
 		if _, err := Get(name, "", false, false); err != nil { // create only if connection doesn't exist
-			_, err = Create(name, content, "", "", "", false, createSecret, wait)
+			_, err = Create(name, content, "", "", "", false, createSecret, wait, waitTimeout)
 			if err != nil {
 				errs = append(errs, err.Error())
 			}
@@ -1026,7 +1313,7 @@ func Import(folder string, createSecret bool, wait bool) (err error) {
 }
 
 // Export
-func Export(folder string) (err error) {
+func Export(folder string, customFolder string, fileSplitter string) (err error) {
 	apiclient.SetExportToFile(folder)
 	apiclient.ClientPrintHttpResponse.Set(false)
 	defer apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting())
@@ -1057,23 +1344,16 @@ func Export(folder string) (err error) {
 	}
 
 	for _, lconnection := range lconnections.Connections {
-		lconnection.ConnectorDetails = new(connectorDetails)
-		lconnection.ConnectorDetails.Name = getConnectorName(*lconnection.ConnectorVersion)
-		if lconnection.ConnectorDetails.Provider != "customconnector" {
-			lconnection.ConnectorDetails.Version = new(int)
-			*lconnection.ConnectorDetails.Version = getConnectorVersion(*lconnection.ConnectorVersion)
-		} else {
-			lconnection.ConnectorDetails.VersionId = new(string)
-			*lconnection.ConnectorDetails.VersionId = getConnectorVersionId(*lconnection.ConnectorVersion)
-		}
-
-		lconnection.ConnectorVersion = nil
-		fileName := getConnectionName(*lconnection.Name) + ".json"
-		lconnection.Name = nil
-		connectionPayload, err := json.Marshal(lconnection)
+		connectionName := getConnectionName(*lconnection.Name)
+		// use the same shape as scaffold so the files can be used by apply
+		connectionPayload, err := Get(connectionName, "", true, true)
 		if err != nil {
 			return err
 		}
+		if connectionPayload, err = apiclient.PrettifyJson(connectionPayload); err != nil {
+			return err
+		}
+		fileName := connectionName + ".json"
 		if err = apiclient.WriteByteArrayToFile(
 			path.Join(apiclient.GetExportToFile(), fileName),
 			false,
@@ -1082,6 +1362,29 @@ func Export(folder string) (err error) {
 			return err
 		}
 		clilog.Info.Printf("Downloaded %s\n", fileName)
+
+		if customFolder == "" || getConnectorProvider(*lconnection.ConnectorVersion) != "customconnector" {
+			continue
+		}
+
+		customName := getConnectorName(*lconnection.ConnectorVersion)
+		customVersion := getConnectorVersionId(*lconnection.ConnectorVersion)
+		customPayload, err := GetCustomVersion(customName, customVersion, true)
+		if err != nil {
+			return err
+		}
+		if customPayload, err = apiclient.PrettifyJson(customPayload); err != nil {
+			return err
+		}
+		fileName = customName + fileSplitter + customVersion + ".json"
+		if err = apiclient.WriteByteArrayToFile(
+			path.Join(customFolder, fileName),
+			false,
+			customPayload); err != nil {
+			clilog.Error.Println(err)
+			return err
+		}
+		clilog.Info.Printf("Downloaded %s\n", fileName)
 	}
 
 	return nil
@@ -1164,3 +1467,87 @@ func isGoogleConnection(connectionName string) bool {
 	}
 	return false
 }
+
+// cloneServerFields are the fields of a connection that the server sets
+var cloneServerFields = []string{
+	"name", "createTime", "updateTime", "status", "etag", "envoyImageLocation", "imageLocation",
+	"serviceDirectory", "tlsServiceDirectory", "host", "connectorVersionLaunchStage",
+	"connectorVersionInfraConfig", "subscriptionType", "connectionRevision", "eventingRuntimeData",
+	"isTrustedTester",
+}
+
+// GetCloneConfig returns the configuration of the connection without the fields the server sets
+// and with the connector version as connector details, so it can be created with another name.
+// The overrides are merged into the configuration as a JSON merge patch, where a null removes a field
+func GetCloneConfig(name string, overrides []byte) (content []byte, err error) {
+	var respBody []byte
+
+	apiclient.ClientPrintHttpResponse.Set(false)
+	defer apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting())
+
+	if respBody, err = Get(name, "", false, false); err != nil {
+		return nil, err
+	}
+	c := map[string]interface{}{}
+	if err = json.Unmarshal(respBody, &c); err != nil {
+		return nil, err
+	}
+	for _, field := range cloneServerFields {
+		delete(c, field)
+	}
+
+	connectorVersion, _ := c["connectorVersion"].(string)
+	if strings.Count(connectorVersion, "/") != 9 {
+		return nil, fmt.Errorf("connection %s has an unexpected connector version %q", name, connectorVersion)
+	}
+	details := connectorDetails{
+		Name:     getConnectorName(connectorVersion),
+		Provider: getConnectorProvider(connectorVersion),
+	}
+	if details.Provider == "customconnector" {
+		versionId := getConnectorVersionId(connectorVersion)
+		details.VersionId = &versionId
+	} else {
+		version := getConnectorVersion(connectorVersion)
+		details.Version = &version
+	}
+	c["connectorDetails"] = details
+	delete(c, "connectorVersion")
+
+	var config interface{} = c
+	if len(overrides) > 0 {
+		var patch interface{}
+		if err = json.Unmarshal(overrides, &patch); err != nil {
+			return nil, fmt.Errorf("invalid overrides: %w", err)
+		}
+		// round trip the connector details so the patch can merge into them
+		if content, err = json.Marshal(c); err != nil {
+			return nil, err
+		}
+		if err = json.Unmarshal(content, &config); err != nil {
+			return nil, err
+		}
+		config = mergePatch(config, patch)
+	}
+	return json.Marshal(config)
+}
+
+// mergePatch applies the patch to the target as described in RFC 7396
+func mergePatch(target interface{}, patch interface{}) interface{} {
+	p, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+	t, ok := target.(map[string]interface{})
+	if !ok {
+		t = map[string]interface{}{}
+	}
+	for key, value := range p {
+		if value == nil {
+			delete(t, key)
+		} else {
+			t[key] = mergePatch(t[key], value)
+		}
+	}
+	return t
+}
@@ -56,6 +56,12 @@ type configVariableTemplate struct {
 
 const waitTime = 1 * time.Second
 
+// CheckCustomJSON returns an error naming the first field of the custom connection that is not
+// part of the known schema. Such fields are dropped when the custom connection is created
+func CheckCustomJSON(content []byte) error {
+	return apiclient.UnmarshalStrict(content, &customConnectorOverrides{})
+}
+
 // CreateCustom
 func CreateCustom(name string, description string, displayName string,
 	connType string, labels map[string]string,
@@ -96,6 +102,14 @@ func DeleteCustom(name string, force bool) (respBody []byte, err error) {
 	return respBody, err
 }
 
+// DeleteCustomVersion
+func DeleteCustomVersion(connName string, connVersion string) (respBody []byte, err error) {
+	u, _ := url.Parse(apiclient.GetBaseCustomConnectorURL())
+	u.Path = path.Join(u.Path, connName, "customConnectorVersions", connVersion)
+	respBody, err = apiclient.HttpClient(u.String(), "", "DELETE")
+	return respBody, err
+}
+
 // GetCustom
 func GetCustom(name string) (respBody []byte, err error) {
 	u, _ := url.Parse(apiclient.GetBaseCustomConnectorURL())
@@ -122,6 +136,13 @@ func ListCustom(pageSize int, pageToken string, filter string) (respBody []byte,
 	return respBody, err
 }
 
+// ListCustomNames returns the names of all the custom connectors in the project
+func ListCustomNames() (names []string, err error) {
+	return listNames("customConnectors", func(pageToken string) ([]byte, error) {
+		return ListCustom(-1, pageToken, "")
+	})
+}
+
 // CreateCustomVersion
 func CreateCustomVersion(connName string, versionName string, content []byte,
 	serviceAccountName string, serviceAccountProject string,
@@ -215,6 +236,13 @@ func ListCustomVersions(connName string, pageSize int, pageToken string) (respBo
 	return respBody, err
 }
 
+// ListCustomVersionNames returns the names of all the versions of a custom connector
+func ListCustomVersionNames(connName string) (names []string, err error) {
+	return listNames("customConnectorVersions", func(pageToken string) ([]byte, error) {
+		return ListCustomVersions(connName, -1, pageToken)
+	})
+}
+
 func GetCustomFromConnection(contents []byte) (respBody []byte, err error) {
 	c := connection{}
 	err = json.Unmarshal(respBody, &c)
@@ -37,11 +37,13 @@ type endpoint struct {
 	CreateTime        string `json:"createTime,omitempty"`
 	UpdateTime        string `json:"updateTime,omitempty"`
 	ServiceAttachment string `json:"serviceAttachment,omitempty"`
+	Description       string `json:"description,omitempty"`
 	EndpointIP        string `json:"endpointIp,omitempty"`
 }
 
 type endpointExternal struct {
 	ServiceAttachment string `json:"serviceAttachment,omitempty"`
+	Description       string `json:"description,omitempty"`
 }
 
 // CreateEndpoint
@@ -67,6 +69,9 @@ func CreateEndpoint(name string, serviceAttachment string, description string, w
 	u.RawQuery = q.Encode()
 
 	respBody, err = apiclient.HttpClient(u.String(), payload)
+	if err != nil || respBody == nil {
+		return respBody, err
+	}
 
 	if wait {
 		apiclient.ClientPrintHttpResponse.Set(false)
@@ -109,6 +114,33 @@ func CreateEndpoint(name string, serviceAttachment string, description string, w
 	return
 }
 
+// UpdateEndpointDescription patches the description of an endpoint attachment. The service
+// attachment of an endpoint attachment cannot be changed
+func UpdateEndpointDescription(name string, description string) (respBody []byte, err error) {
+	e := endpointExternal{
+		Description: description,
+	}
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+
+	u, _ := url.Parse(apiclient.GetBaseConnectorEndpointAttachURL())
+	u.Path = path.Join(u.Path, name)
+
+	q := u.Query()
+	q.Set("updateMask", "description")
+	u.RawQuery = q.Encode()
+
+	return apiclient.HttpClient(u.String(), string(payload), "PATCH")
+}
+
+// CheckEndpointJSON returns an error naming the first field of the endpoint attachment that is
+// not part of the known schema. Such fields are ignored when the endpoint attachment is created
+func CheckEndpointJSON(content []byte) error {
+	return apiclient.UnmarshalStrict(content, &endpointExternal{})
+}
+
 // GetEndpoint
 func GetEndpoint(name string, overrides bool) (respBody []byte, err error) {
 	u, _ := url.Parse(apiclient.GetBaseConnectorEndpointAttachURL())
@@ -158,6 +190,13 @@ func ListEndpoints(pageSize int, pageToken string, filter string, orderBy string
 	return respBody, err
 }
 
+// ListEndpointNames returns the names of all the endpoint attachments in the region
+func ListEndpointNames() (names []string, err error) {
+	return listNames("endpointAttachments", func(pageToken string) ([]byte, error) {
+		return ListEndpoints(-1, pageToken, "", "")
+	})
+}
+
 // DeleteEndpoint
 func DeleteEndpoint(name string) (respBody []byte, err error) {
 	u, _ := url.Parse(apiclient.GetBaseConnectorEndpointAttachURL())
@@ -193,9 +232,38 @@ func FindEndpoint(name string) (found bool) {
 	}
 }
 
+// FindEndpointByHost returns the endpoint attachment whose IP matches the host
+func FindEndpointByHost(host string) (name string, respBody []byte, err error) {
+	var pageToken string
+
+	apiclient.ClientPrintHttpResponse.Set(false)
+	defer apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting())
+
+	for {
+		if respBody, err = ListEndpoints(maxPageSize, pageToken, "", ""); err != nil {
+			return "", nil, err
+		}
+		l := endpoints{}
+		if err = json.Unmarshal(respBody, &l); err != nil {
+			return "", nil, err
+		}
+		for _, e := range l.EndpointAttachments {
+			if e.EndpointIP != "" && e.EndpointIP == host {
+				respBody, err = json.Marshal(convertInternalToExternal(e))
+				return e.Name[strings.LastIndex(e.Name, "/")+1:], respBody, err
+			}
+		}
+		if l.NextPageToken == "" {
+			return "", nil, nil
+		}
+		pageToken = l.NextPageToken
+	}
+}
+
 // convertInternalToExternal
 func convertInternalToExternal(internalVersion endpoint) (externalVersion endpointExternal) {
 	externalVersion = endpointExternal{}
 	externalVersion.ServiceAttachment = internalVersion.ServiceAttachment
+	externalVersion.Description = internalVersion.Description
 	return externalVersion
 }
@@ -59,7 +59,7 @@ func CreateEventSubscription(connName string, subscriptionId string, contents []
 		return nil, err
 	}
 	u, _ := url.Parse(apiclient.GetBaseConnectorURL())
-	u.Path = path.Join(u.Path, "connectors", connName, "eventSubscriptions")
+	u.Path = path.Join(u.Path, connName, "eventSubscriptions")
 	q := u.Query()
 	q.Set("eventSubscriptionId", subscriptionId)
 	u.RawQuery = q.Encode()
@@ -70,7 +70,7 @@ func CreateEventSubscription(connName string, subscriptionId string, contents []
 // GetEventSubscription
 func GetEventSubscription(name string, connName string, overrides bool) (respBody []byte, err error) {
 	u, _ := url.Parse(apiclient.GetBaseConnectorURL())
-	u.Path = path.Join(u.Path, "connectors", connName, "eventSubscriptions", name)
+	u.Path = path.Join(u.Path, connName, "eventSubscriptions", name)
 	respBody, err = apiclient.HttpClient(u.String())
 	return respBody, err
 }
@@ -78,7 +78,7 @@ func GetEventSubscription(name string, connName string, overrides bool) (respBod
 // DeleteEventSubscription
 func DeleteEventSubscription(name string, connName string) (respBody []byte, err error) {
 	u, _ := url.Parse(apiclient.GetBaseConnectorURL())
-	u.Path = path.Join(u.Path, "connectors", connName, "eventSubscriptions", name)
+	u.Path = path.Join(u.Path, connName, "eventSubscriptions", name)
 	respBody, err = apiclient.HttpClient(u.String(), "", "DELETE")
 	return respBody, err
 }
@@ -86,7 +86,7 @@ func DeleteEventSubscription(name string, connName string) (respBody []byte, err
 // RetryEventSubscription
 func RetryEventSubscription(name string, connName string) (respBody []byte, err error) {
 	u, _ := url.Parse(apiclient.GetBaseConnectorURL())
-	u.Path = path.Join(u.Path, "connectors", connName, "eventSubscriptions", name+":retry")
+	u.Path = path.Join(u.Path, connName, "eventSubscriptions", name+":retry")
 	respBody, err = apiclient.HttpClient(u.String(), "")
 	return respBody, err
 }
@@ -94,7 +94,7 @@ func RetryEventSubscription(name string, connName string) (respBody []byte, err
 // ListEventSubscriptions
 func ListEventSubscriptions(connName string, pageSize int, pageToken string, filter string, orderBy string) (respBody []byte, err error) {
 	u, _ := url.Parse(apiclient.GetBaseConnectorURL())
-	u.Path = path.Join(u.Path, "connectors", connName, "eventSubscriptions")
+	u.Path = path.Join(u.Path, connName, "eventSubscriptions")
 	q := u.Query()
 	if pageSize != -1 {
 		q.Set("pageSize", strconv.Itoa(pageSize))
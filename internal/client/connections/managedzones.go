@@ -16,19 +16,40 @@ package connections
 
 import (
 	"encoding/json"
+	"fmt"
 	"internal/apiclient"
+	"internal/clilog"
 	"net/url"
 	"path"
+	"slices"
 	"strconv"
+	"strings"
+	"time"
 )
 
 type zone struct {
 	DNS           string `json:"dns,omitempty"`
-	Description   string `json:"desciption,omitempty"`
+	Description   string `json:"description,omitempty"`
 	TargetProject string `json:"targetProject,omitempty"`
 	TargetVPC     string `json:"targetVpc,omitempty"`
 }
 
+type zones struct {
+	ManagedZones  []managedZone `json:"managedZones,omitempty"`
+	NextPageToken string        `json:"nextPageToken,omitempty"`
+}
+
+type managedZone struct {
+	Name string `json:"name,omitempty"`
+	zone
+}
+
+// CheckZoneJSON returns an error naming the first field of the managed zone that is not part
+// of the known schema. Such fields are dropped when the managed zone is created
+func CheckZoneJSON(content []byte) error {
+	return apiclient.UnmarshalStrict(content, &zone{})
+}
+
 // CreateZone
 func CreateZone(name string, content []byte) (respBody []byte, err error) {
 	u, _ := url.Parse(apiclient.GetBaseConnectorZonesURL())
@@ -47,6 +68,55 @@ func CreateZone(name string, content []byte) (respBody []byte, err error) {
 	return respBody, err
 }
 
+// UpdateZone patches the fields of a managed zone listed in updateMask
+func UpdateZone(name string, content []byte, updateMask []string) (respBody []byte, err error) {
+	z := zone{}
+	if err = json.Unmarshal(content, &z); err != nil {
+		return nil, err
+	}
+
+	u, _ := url.Parse(apiclient.GetBaseConnectorZonesURL())
+	u.Path = path.Join(u.Path, name)
+
+	if len(updateMask) != 0 {
+		q := u.Query()
+		q.Set("updateMask", strings.Join(updateMask, ","))
+		u.RawQuery = q.Encode()
+	}
+
+	return apiclient.HttpClient(u.String(), string(content), "PATCH")
+}
+
+// DiffZone compares the deployed managed zone with the local configuration and
+// returns the update mask along with a readable line for each field that differs
+func DiffZone(deployed []byte, content []byte) (updateMask []string, diff []string, err error) {
+	current, desired := zone{}, zone{}
+	if err = json.Unmarshal(deployed, &current); err != nil {
+		return nil, nil, err
+	}
+	if err = json.Unmarshal(content, &desired); err != nil {
+		return nil, nil, err
+	}
+
+	fields := []struct {
+		name    string
+		current string
+		desired string
+	}{
+		{"dns", current.DNS, desired.DNS},
+		{"description", current.Description, desired.Description},
+		{"targetProject", current.TargetProject, desired.TargetProject},
+		{"targetVpc", current.TargetVPC, desired.TargetVPC},
+	}
+	for _, f := range fields {
+		if f.current != f.desired {
+			updateMask = append(updateMask, f.name)
+			diff = append(diff, fmt.Sprintf("  %s: %q -> %q", f.name, f.current, f.desired))
+		}
+	}
+	return updateMask, diff, nil
+}
+
 // GetZone
 func GetZone(name string, overrides bool) (respBody []byte, err error) {
 	u, _ := url.Parse(apiclient.GetBaseConnectorZonesURL())
@@ -73,6 +143,29 @@ func DeleteZone(name string) (respBody []byte, err error) {
 	return respBody, err
 }
 
+// WaitForZoneDeletion waits until the managed zone is no longer listed. The zone is looked up
+// in the list rather than with GetZone so the last check does not print a not found error.
+// A zero timeout waits until the zone is gone
+func WaitForZoneDeletion(name string, waitTimeout time.Duration) (err error) {
+	var names []string
+
+	deadline := time.Now().Add(waitTimeout)
+	for {
+		if names, err = ListZoneNames(); err != nil {
+			return err
+		}
+		if !slices.Contains(names, name) {
+			clilog.Info.Printf("Managed zone %s was deleted\n", name)
+			return nil
+		}
+		if waitTimeout > 0 && time.Now().After(deadline) {
+			return fmt.Errorf("managed zone %s was not deleted within %s", name, waitTimeout)
+		}
+		clilog.Info.Printf("Waiting %d seconds for managed zone %s to be deleted\n", interval, name)
+		time.Sleep(interval * time.Second)
+	}
+}
+
 // ListZones
 func ListZones(pageSize int, pageToken string, filter string, orderBy string) (respBody []byte, err error) {
 	u, _ := url.Parse(apiclient.GetBaseConnectorZonesURL())
@@ -94,3 +187,91 @@ func ListZones(pageSize int, pageToken string, filter string, orderBy string) (r
 	respBody, err = apiclient.HttpClient(u.String())
 	return respBody, err
 }
+
+// ZoneSummary is the name, DNS suffix and target network of a managed zone
+type ZoneSummary struct {
+	Name          string `json:"name,omitempty"`
+	DNS           string `json:"dns,omitempty"`
+	TargetProject string `json:"targetProject,omitempty"`
+	TargetVPC     string `json:"targetVpc,omitempty"`
+}
+
+// ListAllZones returns the managed zones of every page in a single list response
+func ListAllZones(filter string, orderBy string) (respBody []byte, err error) {
+	allZones := zones{}
+	pageToken := ""
+
+	apiclient.ClientPrintHttpResponse.Set(false)
+	defer apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting())
+
+	for {
+		z := zones{}
+		if respBody, err = ListZones(maxPageSize, pageToken, filter, orderBy); err != nil {
+			return nil, err
+		}
+		if err = json.Unmarshal(respBody, &z); err != nil {
+			return nil, err
+		}
+		allZones.ManagedZones = append(allZones.ManagedZones, z.ManagedZones...)
+		if z.NextPageToken == "" {
+			break
+		}
+		pageToken = z.NextPageToken
+	}
+
+	return json.Marshal(allZones)
+}
+
+// GetZoneSummaries returns a summary of each managed zone in a list response
+func GetZoneSummaries(respBody []byte) (summaries []ZoneSummary, err error) {
+	l := zones{}
+	if err = json.Unmarshal(respBody, &l); err != nil {
+		return nil, err
+	}
+	for _, z := range l.ManagedZones {
+		summaries = append(summaries, ZoneSummary{
+			Name:          path.Base(z.Name),
+			DNS:           z.DNS,
+			TargetProject: z.TargetProject,
+			TargetVPC:     z.TargetVPC,
+		})
+	}
+	return summaries, nil
+}
+
+// ListZoneNames returns the names of all the managed zones in the project
+func ListZoneNames() (names []string, err error) {
+	return listNames("managedZones", func(pageToken string) ([]byte, error) {
+		return ListZones(-1, pageToken, "", "")
+	})
+}
+
+// FindZoneByHost returns the managed zone whose DNS suffix matches the host
+func FindZoneByHost(host string) (name string, respBody []byte, err error) {
+	var pageToken string
+
+	apiclient.ClientPrintHttpResponse.Set(false)
+	defer apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting())
+
+	fqdn := strings.TrimSuffix(host, ".") + "."
+	for {
+		if respBody, err = ListZones(maxPageSize, pageToken, "", ""); err != nil {
+			return "", nil, err
+		}
+		l := zones{}
+		if err = json.Unmarshal(respBody, &l); err != nil {
+			return "", nil, err
+		}
+		for _, z := range l.ManagedZones {
+			dns := strings.TrimSuffix(z.DNS, ".") + "."
+			if z.DNS != "" && (fqdn == dns || strings.HasSuffix(fqdn, "."+dns)) {
+				respBody, err = json.Marshal(z.zone)
+				return z.Name[strings.LastIndex(z.Name, "/")+1:], respBody, err
+			}
+		}
+		if l.NextPageToken == "" {
+			return "", nil, nil
+		}
+		pageToken = l.NextPageToken
+	}
+}
@@ -0,0 +1,176 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integrations
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+)
+
+// Change is a semantic difference between two integration versions
+type Change struct {
+	Kind   string   `json:"kind"` // added, removed or changed
+	Type   string   `json:"type"` // task, trigger, configVariable, parameter or integration
+	Id     string   `json:"id"`
+	Name   string   `json:"name,omitempty"`
+	Fields []string `json:"fields,omitempty"` // the fields that changed
+}
+
+// element is a task, trigger or variable of a normalized integration version
+type element struct {
+	name  string
+	value map[string]interface{}
+}
+
+// NormalizeVersion returns the integration version without the fields set by the
+// server or the designer, such as the version name, state and task positions
+func NormalizeVersion(content []byte) (normalized []byte, err error) {
+	iversion := integrationVersion{}
+	if err = json.Unmarshal(content, &iversion); err != nil {
+		return nil, err
+	}
+	eversion := convertInternalToExternal(iversion)
+	eversion.SnapshotNumber = ""
+	eversion.UserLabel = nil
+	return json.Marshal(eversion)
+}
+
+// DiffVersions compares two integration versions after normalizing them and returns
+// the tasks, triggers, config variables, parameters and integration settings that changed
+func DiffVersions(a []byte, b []byte) (changes []Change, err error) {
+	var elementsA, elementsB map[string]map[string]element
+
+	if elementsA, err = getElements(a); err != nil {
+		return nil, err
+	}
+	if elementsB, err = getElements(b); err != nil {
+		return nil, err
+	}
+
+	for _, elementType := range []string{"integration", "trigger", "task", "configVariable", "parameter"} {
+		changes = append(changes, diffElements(elementType, elementsA[elementType], elementsB[elementType])...)
+	}
+	return changes, nil
+}
+
+// getElements indexes the normalized version by element type and id
+func getElements(content []byte) (elements map[string]map[string]element, err error) {
+	normalized, err := NormalizeVersion(content)
+	if err != nil {
+		return nil, err
+	}
+	eversion := integrationVersionExternal{}
+	if err = json.Unmarshal(normalized, &eversion); err != nil {
+		return nil, err
+	}
+
+	elements = map[string]map[string]element{
+		"integration":    {},
+		"trigger":        {},
+		"task":           {},
+		"configVariable": {},
+		"parameter":      {},
+	}
+	add := func(elementType string, id string, name string, v interface{}) error {
+		value, err := toMap(v)
+		if err != nil {
+			return err
+		}
+		elements[elementType][id] = element{name: name, value: value}
+		return nil
+	}
+
+	for _, t := range eversion.TriggerConfigs {
+		if err = add("trigger", t.TriggerNumber, t.Label, t); err != nil {
+			return nil, err
+		}
+	}
+	for _, t := range eversion.TaskConfigs {
+		if err = add("task", t.TaskId, t.DisplayName, t); err != nil {
+			return nil, err
+		}
+	}
+	for _, c := range eversion.IntegrationConfigParameters {
+		if err = add("configVariable", c.Parameter.Key, "", c); err != nil {
+			return nil, err
+		}
+	}
+	for _, p := range eversion.IntegrationParameters {
+		if err = add("parameter", p.Key, "", p); err != nil {
+			return nil, err
+		}
+	}
+
+	// the remaining settings are compared as a single element
+	eversion.TriggerConfigs = nil
+	eversion.TaskConfigs = nil
+	eversion.IntegrationConfigParameters = nil
+	eversion.IntegrationParameters = nil
+	if err = add("integration", "settings", "", eversion); err != nil {
+		return nil, err
+	}
+	return elements, nil
+}
+
+func diffElements(elementType string, a map[string]element, b map[string]element) (changes []Change) {
+	for _, id := range sortedIds(a, b) {
+		ea, inA := a[id]
+		eb, inB := b[id]
+		switch {
+		case !inA:
+			changes = append(changes, Change{Kind: "added", Type: elementType, Id: id, Name: eb.name})
+		case !inB:
+			changes = append(changes, Change{Kind: "removed", Type: elementType, Id: id, Name: ea.name})
+		default:
+			if fields := diffFields(ea.value, eb.value); len(fields) > 0 {
+				changes = append(changes, Change{Kind: "changed", Type: elementType, Id: id, Name: eb.name, Fields: fields})
+			}
+		}
+	}
+	return changes
+}
+
+// diffFields returns the top level fields that differ, sorted by name
+func diffFields(a map[string]interface{}, b map[string]interface{}) (fields []string) {
+	for _, field := range sortedIds(a, b) {
+		if !reflect.DeepEqual(a[field], b[field]) {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+func sortedIds[V any](a map[string]V, b map[string]V) (ids []string) {
+	for id := range a {
+		ids = append(ids, id)
+	}
+	for id := range b {
+		if _, ok := a[id]; !ok {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func toMap(v interface{}) (m map[string]interface{}, err error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(b, &m)
+	return m, err
+}
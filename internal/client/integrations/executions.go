@@ -16,12 +16,16 @@ package integrations
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"internal/apiclient"
 	"net/url"
 	"path"
 	"regexp"
+	"sort"
 	"strconv"
+	"strings"
+	"time"
 )
 
 type execute struct {
@@ -59,6 +63,61 @@ type booleanarray struct {
 	BooleanValues []bool
 }
 
+type testExecutionResponse struct {
+	ExecutionId     string      `json:"executionId,omitempty"`
+	ExecutionFailed bool        `json:"executionFailed,omitempty"`
+	Parameters      interface{} `json:"parameters,omitempty"`
+}
+
+type listExecutions struct {
+	Executions    []json.RawMessage `json:"executions,omitempty"`
+	NextPageToken string            `json:"nextPageToken,omitempty"`
+}
+
+type execution struct {
+	Name             string            `json:"name,omitempty"`
+	State            string            `json:"state,omitempty"`
+	CreateTime       string            `json:"createTime,omitempty"`
+	UpdateTime       string            `json:"updateTime,omitempty"`
+	ExecutionDetails *executionDetails `json:"executionDetails,omitempty"`
+}
+
+type executionDetails struct {
+	State              string              `json:"state,omitempty"`
+	ExecutionSnapshots []executionSnapshot `json:"executionSnapshots,omitempty"`
+}
+
+type executionSnapshot struct {
+	ExecutionSnapshotMetadata *struct {
+		Task       string `json:"task,omitempty"`
+		TaskLabel  string `json:"taskLabel,omitempty"`
+		TaskNumber string `json:"taskNumber,omitempty"`
+	} `json:"executionSnapshotMetadata,omitempty"`
+	Params               map[string]eventparameter `json:"params,omitempty"`
+	TaskExecutionDetails []struct {
+		TaskNumber         string `json:"taskNumber,omitempty"`
+		TaskExecutionState string `json:"taskExecutionState,omitempty"`
+	} `json:"taskExecutionDetails,omitempty"`
+}
+
+// ExecutionSummary holds the state of an execution and of each of its tasks
+type ExecutionSummary struct {
+	ExecutionId string        `json:"executionId,omitempty"`
+	State       string        `json:"state,omitempty"`
+	CreateTime  string        `json:"createTime,omitempty"`
+	UpdateTime  string        `json:"updateTime,omitempty"`
+	Tasks       []TaskSummary `json:"tasks,omitempty"`
+}
+
+// TaskSummary holds the state of a task in an execution and the error it reported
+type TaskSummary struct {
+	TaskNumber string `json:"taskNumber,omitempty"`
+	Task       string `json:"task,omitempty"`
+	Label      string `json:"label,omitempty"`
+	State      string `json:"state,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
 type executionResponse struct {
 	ExecutionId      string              `json:"executionId,omitempty"`
 	EventParameters  *parametersInternal `json:"eventParameters,omitempty"`
@@ -88,6 +147,132 @@ func ListExecutions(name string, pageSize int, pageToken string, filter string,
 	return respBody, err
 }
 
+// ListAllExecutions lists the executions from all pages, up to limit executions when limit is positive
+func ListAllExecutions(name string, pageSize int, pageToken string, filter string, orderBy string,
+	limit int,
+) (respBody []byte, err error) {
+	allExecutions := listExecutions{}
+
+	apiclient.ClientPrintHttpResponse.Set(false)
+	defer apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting())
+
+	for {
+		executions := listExecutions{}
+		if respBody, err = ListExecutions(name, pageSize, pageToken, filter, orderBy); err != nil {
+			return nil, err
+		}
+		if err = json.Unmarshal(respBody, &executions); err != nil {
+			return nil, err
+		}
+		allExecutions.Executions = append(allExecutions.Executions, executions.Executions...)
+
+		if limit > 0 && len(allExecutions.Executions) >= limit {
+			allExecutions.Executions = allExecutions.Executions[:limit]
+			break
+		}
+		if executions.NextPageToken == "" {
+			break
+		}
+		pageToken = executions.NextPageToken
+	}
+
+	return json.Marshal(allExecutions)
+}
+
+// GetExecution returns an execution of an integration
+func GetExecution(name string, executionID string) (respBody []byte, err error) {
+	u, _ := url.Parse(apiclient.GetBaseIntegrationURL())
+	u.Path = path.Join(u.Path, "integrations", name, "executions", executionID)
+	respBody, err = apiclient.HttpClient(u.String())
+	return respBody, err
+}
+
+// GetExecutionSummary returns the state of the execution, of each task and the errors reported by the tasks
+func GetExecutionSummary(respBody []byte) (summary ExecutionSummary, err error) {
+	e := execution{}
+	if err = json.Unmarshal(respBody, &e); err != nil {
+		return summary, err
+	}
+
+	summary = ExecutionSummary{
+		ExecutionId: path.Base(e.Name),
+		State:       e.State,
+		CreateTime:  e.CreateTime,
+		UpdateTime:  e.UpdateTime,
+	}
+	if e.ExecutionDetails == nil {
+		return summary, nil
+	}
+	if summary.State == "" {
+		summary.State = e.ExecutionDetails.State
+	}
+
+	// the last snapshot of a task holds its final state
+	tasks := map[string]*TaskSummary{}
+	for _, snapshot := range e.ExecutionDetails.ExecutionSnapshots {
+		for _, detail := range snapshot.TaskExecutionDetails {
+			task, ok := tasks[detail.TaskNumber]
+			if !ok {
+				task = &TaskSummary{TaskNumber: detail.TaskNumber}
+				tasks[detail.TaskNumber] = task
+			}
+			task.State = detail.TaskExecutionState
+			if snapshot.ExecutionSnapshotMetadata != nil &&
+				snapshot.ExecutionSnapshotMetadata.TaskNumber == detail.TaskNumber {
+				task.Task = snapshot.ExecutionSnapshotMetadata.Task
+				task.Label = snapshot.ExecutionSnapshotMetadata.TaskLabel
+				if errorInfo, ok := snapshot.Params["ErrorInfo"]; ok && errorInfo.Value.JsonValue != nil {
+					task.Error = *errorInfo.Value.JsonValue
+				}
+			}
+		}
+	}
+	// order the task numbers numerically
+	taskNumbers := sortedIds(tasks, nil)
+	sort.SliceStable(taskNumbers, func(i, j int) bool { return len(taskNumbers[i]) < len(taskNumbers[j]) })
+	for _, taskNumber := range taskNumbers {
+		summary.Tasks = append(summary.Tasks, *tasks[taskNumber])
+	}
+	return summary, nil
+}
+
+// IsExecutionDone returns true if the execution state is terminal
+func IsExecutionDone(state string) bool {
+	return state == "SUCCEEDED" || state == "FAILED" || state == "CANCELLED"
+}
+
+// ErrWaitTimeout is returned by WaitForExecution when the execution is not done within the timeout
+var ErrWaitTimeout = errors.New("timed out waiting for the execution")
+
+// WaitForExecution polls the execution every interval until it reaches a terminal state.
+// When the timeout is reached first, the last summary is returned with an error that wraps
+// ErrWaitTimeout. Other errors come from reading the execution
+func WaitForExecution(name string, executionID string, timeout time.Duration, interval time.Duration,
+) (summary ExecutionSummary, err error) {
+	var respBody []byte
+
+	apiclient.ClientPrintHttpResponse.Set(false)
+	defer apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting())
+
+	deadline := time.Now().Add(timeout)
+	for {
+		if respBody, err = GetExecution(name, executionID); err != nil {
+			return summary, err
+		}
+		if summary, err = GetExecutionSummary(respBody); err != nil {
+			return summary, err
+		}
+		if IsExecutionDone(summary.State) {
+			return summary, nil
+		}
+		if timeout > 0 && time.Now().Add(interval).After(deadline) {
+			return summary, fmt.Errorf("%w: execution %s is in state %s after %s", ErrWaitTimeout,
+				executionID, summary.State, timeout)
+		}
+		time.Sleep(interval)
+	}
+}
+
 // Execute
 func Execute(name string, content []byte) (respBody []byte, err error) {
 	e := execute{}
@@ -128,6 +313,103 @@ func Execute(name string, content []byte) (respBody []byte, err error) {
 	return respBody, err
 }
 
+// ExecuteVersion runs a specific integration version, including draft versions, with the test API
+func ExecuteVersion(name string, version string, content []byte) (respBody []byte, err error) {
+	request := map[string]json.RawMessage{}
+	e := execute{}
+
+	if err = json.Unmarshal(content, &e); err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(content, &request); err != nil {
+		return nil, err
+	}
+
+	regExTrigger := regexp.MustCompile(`api_trigger\/\w+`)
+	if !regExTrigger.MatchString(e.TriggerId) {
+		return nil, fmt.Errorf("triggerId must match the format api_trigger/*")
+	}
+
+	apiclient.ClientPrintHttpResponse.Set(false)
+	defer apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting())
+
+	// the test API runs the version passed in the request
+	if request["integrationVersion"], err = Get(name, version, false, false, false); err != nil {
+		return nil, err
+	}
+	// the test API does not accept these fields of the execute request
+	delete(request, "doNotPropagateError")
+	delete(request, "requestId")
+
+	payload, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	u, _ := url.Parse(apiclient.GetBaseIntegrationURL())
+	u.Path = path.Join(u.Path, "integrations", name, "versions", version+":test")
+	if respBody, err = apiclient.HttpClient(u.String(), string(payload)); err != nil {
+		return nil, err
+	}
+
+	tresp := testExecutionResponse{}
+	if err = json.Unmarshal(respBody, &tresp); err != nil {
+		return nil, err
+	}
+
+	respBody, err = json.Marshal(map[string]interface{}{
+		"executionId":      tresp.ExecutionId,
+		"executionFailed":  tresp.ExecutionFailed,
+		"outputParameters": tresp.Parameters,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting())
+	return respBody, apiclient.PrettyPrint(respBody)
+}
+
+// GetApiTriggerId returns the id of the only API trigger of the version, or of the published
+// version when no version is passed
+func GetApiTriggerId(name string, version string) (triggerId string, err error) {
+	var respBody []byte
+
+	if version == "" {
+		if version, err = getVersionId(name, "state=ACTIVE"); err != nil {
+			return "", fmt.Errorf("unable to find the published version of %s: %w", name, err)
+		}
+	}
+
+	apiclient.ClientPrintHttpResponse.Set(false)
+	defer apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting())
+
+	if respBody, err = Get(name, version, false, false, false); err != nil {
+		return "", err
+	}
+
+	iversion := integrationVersion{}
+	if err = json.Unmarshal(respBody, &iversion); err != nil {
+		return "", err
+	}
+
+	triggerIds := []string{}
+	for _, t := range iversion.TriggerConfigs {
+		if t.TriggerType == "API" {
+			triggerIds = append(triggerIds, strings.TrimPrefix(t.TriggerId, "api_trigger/"))
+		}
+	}
+	switch len(triggerIds) {
+	case 0:
+		return "", fmt.Errorf("version %s of %s does not have an API trigger", version, name)
+	case 1:
+		return triggerIds[0], nil
+	default:
+		return "", fmt.Errorf("version %s of %s has more than one API trigger, pass one of %s as the trigger id",
+			version, name, strings.Join(triggerIds, ", "))
+	}
+}
+
 func Cancel(name string, executionID string, cancelReason string) (respBody []byte, err error) {
 	u, _ := url.Parse(apiclient.GetBaseIntegrationURL())
 	u.Path = path.Join(u.Path, "integrations", name, "executions", executionID, ":cancel")
@@ -0,0 +1,140 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integrations
+
+import (
+	"encoding/json"
+	"internal/apiclient"
+	"internal/client/connections"
+	"internal/clilog"
+	"regexp"
+	"sort"
+)
+
+var rConnectionName = regexp.MustCompile(`projects/([^/]+)/locations/([^/]+)/connections/([^/]+)`)
+
+var rLocation = regexp.MustCompile(`locations/([^/"]+)`)
+
+// GenerateOverrides returns an overrides skeleton to apply the integration version to the
+// current project and region. It starts from the overrides extracted from the integration and
+// keeps the connection overrides of the connections in another project or region, checking
+// they exist in the current region. Scheduler and connector event triggers are moved to the
+// current region. Config variables referencing another region are reported, they are set in
+// the config variables file and not in the overrides
+func GenerateOverrides(content []byte) (overridesBytes []byte, err error) {
+	iversion := integrationVersion{}
+	if err = json.Unmarshal(content, &iversion); err != nil {
+		return nil, err
+	}
+	project, region := apiclient.GetProjectID(), apiclient.GetRegion()
+
+	o, err := extractOverrides(iversion)
+	if err != nil {
+		return nil, err
+	}
+
+	connectionNames := map[string]string{}
+	for _, task := range iversion.TaskConfigs {
+		if task.Task != "GenericConnectorTask" {
+			continue
+		}
+		if connectionNames[task.TaskId], err = getTaskConnectionName(task, iversion.IntegrationConfigParameters); err != nil {
+			return nil, err
+		}
+	}
+
+	connectionOverrides := []connectionoverrides{}
+	for _, co := range o.ConnectionOverrides {
+		match := rConnectionName.FindStringSubmatch(connectionNames[co.TaskId])
+		if match == nil || (match[1] == project && match[2] == region) {
+			continue
+		}
+		if !connectionExists(co.Parameters.ConnectionName) {
+			clilog.Warning.Printf("connection %s used by task %s was not found in project %s and region %s, "+
+				"create it before applying the overrides\n", co.Parameters.ConnectionName, co.TaskId, project, region)
+		}
+		connectionOverrides = append(connectionOverrides, co)
+	}
+	o.ConnectionOverrides = connectionOverrides
+
+	for i, to := range o.TriggerOverrides {
+		if to.CloudSchedulerLocation != nil && *to.CloudSchedulerLocation != region {
+			*o.TriggerOverrides[i].CloudSchedulerLocation = region
+		}
+		if to.TriggerType == "INTEGRATION_CONNECTOR_TRIGGER" && to.Properties["Region"] != "" {
+			properties := map[string]string{}
+			for k, v := range to.Properties {
+				properties[k] = v
+			}
+			properties["Region"] = region
+			properties["Project name"] = project
+			o.TriggerOverrides[i].Properties = properties
+		}
+	}
+
+	for _, key := range getConfigVariableRegions(iversion, region) {
+		clilog.Warning.Printf("config variable %s references another region than %s, "+
+			"update it in the config variables file\n", key, region)
+	}
+
+	return json.Marshal(o)
+}
+
+// getConfigVariableRegions returns the config variables whose value or default value
+// references a location other than region
+func getConfigVariableRegions(iversion integrationVersion, region string) (keys []string) {
+	for _, c := range iversion.IntegrationConfigParameters {
+		var values []string
+		if c.Value != nil {
+			values = append(values, getStringValues(*c.Value)...)
+		}
+		if c.Parameter.DefaultValue != nil {
+			values = append(values, getStringValues(*c.Parameter.DefaultValue)...)
+		}
+		for _, v := range values {
+			if match := rLocation.FindStringSubmatch(v); match != nil && match[1] != region {
+				keys = append(keys, c.Parameter.Key)
+				break
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func getStringValues(v valueType) (values []string) {
+	if v.StringValue != nil {
+		values = append(values, *v.StringValue)
+	}
+	if v.JsonValue != nil {
+		values = append(values, *v.JsonValue)
+	}
+	if v.StringArray != nil {
+		values = append(values, v.StringArray.StringValues...)
+	}
+	return values
+}
+
+// connectionExists returns true when the connection is found in the current project and region
+func connectionExists(connectionName string) bool {
+	apiclient.ClientPrintHttpResponse.Set(false)
+	defer apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting())
+
+	_, err := connections.Get(connectionName, "BASIC", false, false)
+	if err != nil {
+		clilog.Debug.Printf("unable to get connection %s: %v\n", connectionName, err)
+	}
+	return err == nil
+}
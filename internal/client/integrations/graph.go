@@ -0,0 +1,160 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integrations
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	GraphFormatDot     = "dot"
+	GraphFormatMermaid = "mermaid"
+)
+
+type graphNode struct {
+	id, label, kind string
+}
+
+type graphEdge struct {
+	from, to, label string
+}
+
+// Graph returns the triggers, tasks and error catchers of the integration version and the
+// edges between them as a Graphviz DOT or Mermaid flowchart. Tasks are labeled with their
+// display name and task type, and edges with their condition
+func Graph(content []byte, format string) (graph string, err error) {
+	iversion := integrationVersion{}
+	if err = json.Unmarshal(content, &iversion); err != nil {
+		return "", err
+	}
+	nodes, edges := getGraph(iversion)
+
+	switch format {
+	case GraphFormatDot:
+		return getDotGraph(nodes, edges), nil
+	case GraphFormatMermaid:
+		return getMermaidGraph(nodes, edges), nil
+	default:
+		return "", fmt.Errorf("unsupported graph format %q, must be one of %s or %s", format, GraphFormatDot, GraphFormatMermaid)
+	}
+}
+
+func getGraph(iversion integrationVersion) (nodes []graphNode, edges []graphEdge) {
+	taskNode := func(taskId string) string { return "task_" + taskId }
+
+	for i, trigger := range iversion.TriggerConfigs {
+		id := "trigger_" + strconv.Itoa(i+1)
+		label := trigger.Label
+		if label == "" {
+			label = trigger.TriggerId
+		}
+		nodes = append(nodes, graphNode{id, label, trigger.TriggerType})
+		for _, next := range trigger.StartTasks {
+			edges = append(edges, graphEdge{id, taskNode(next.TaskId), getEdgeLabel(next)})
+		}
+	}
+
+	for _, task := range iversion.TaskConfigs {
+		label := task.DisplayName
+		if label == "" {
+			label = task.TaskId
+		}
+		nodes = append(nodes, graphNode{taskNode(task.TaskId), label, task.Task})
+		for _, next := range task.NextTasks {
+			edges = append(edges, graphEdge{taskNode(task.TaskId), taskNode(next.TaskId), getEdgeLabel(next)})
+		}
+	}
+
+	for i, catcher := range iversion.ErrorCatcherConfigs {
+		id := "catcher_" + strconv.Itoa(i+1)
+		label := catcher.Label
+		if label == "" {
+			label = catcher.ErrorCatcherId
+		}
+		nodes = append(nodes, graphNode{id, label, "ErrorCatcher"})
+		for _, start := range catcher.StartErrorTasks {
+			edges = append(edges, graphEdge{id, taskNode(start.TaskId), ""})
+		}
+	}
+	return nodes, edges
+}
+
+// getEdgeLabel returns the condition of the edge, or its display name when it has no condition
+func getEdgeLabel(next nextTask) string {
+	if next.Condition != "" {
+		return next.Condition
+	}
+	return next.DisplayName
+}
+
+func getDotGraph(nodes []graphNode, edges []graphEdge) string {
+	var b strings.Builder
+
+	b.WriteString("digraph integration {\n")
+	b.WriteString("  rankdir=TB;\n")
+	b.WriteString("  node [shape=box, style=rounded];\n")
+	for _, n := range nodes {
+		shape := ""
+		if strings.HasPrefix(n.id, "trigger_") || strings.HasPrefix(n.id, "catcher_") {
+			shape = ", shape=ellipse"
+		}
+		fmt.Fprintf(&b, "  %s [label=%s%s];\n", n.id, dotQuote(n.label+"\n"+n.kind), shape)
+	}
+	for _, e := range edges {
+		if e.label == "" {
+			fmt.Fprintf(&b, "  %s -> %s;\n", e.from, e.to)
+		} else {
+			fmt.Fprintf(&b, "  %s -> %s [label=%s];\n", e.from, e.to, dotQuote(e.label))
+		}
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+func getMermaidGraph(nodes []graphNode, edges []graphEdge) string {
+	var b strings.Builder
+
+	b.WriteString("flowchart TD\n")
+	for _, n := range nodes {
+		label := mermaidQuote(n.label) + "<br/>" + mermaidQuote(n.kind)
+		if strings.HasPrefix(n.id, "trigger_") || strings.HasPrefix(n.id, "catcher_") {
+			fmt.Fprintf(&b, "  %s([\"%s\"])\n", n.id, label)
+		} else {
+			fmt.Fprintf(&b, "  %s[\"%s\"]\n", n.id, label)
+		}
+	}
+	for _, e := range edges {
+		if e.label == "" {
+			fmt.Fprintf(&b, "  %s --> %s\n", e.from, e.to)
+		} else {
+			fmt.Fprintf(&b, "  %s -->|\"%s\"| %s\n", e.from, mermaidQuote(e.label), e.to)
+		}
+	}
+	return b.String()
+}
+
+func dotQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	return `"` + strings.ReplaceAll(s, "\n", `\n`) + `"`
+}
+
+// mermaidQuote escapes the characters that end a quoted mermaid label
+func mermaidQuote(s string) string {
+	return strings.NewReplacer(`"`, "#quot;", "|", "#124;", "\n", " ", "<", "#lt;", ">", "#gt;").Replace(s)
+}
@@ -54,7 +54,7 @@ type integrationVersion struct {
 	Description                   string                   `json:"description,omitempty"`
 	TaskConfigsInternal           []map[string]interface{} `json:"taskConfigsInternal,omitempty"`
 	TriggerConfigsInternal        []map[string]interface{} `json:"triggerConfigsInternal,omitempty"`
-	IntegrationParametersInternal parametersInternal       `json:"integrationParametersInternal,omitempty"`
+	IntegrationParametersInternal map[string]interface{}   `json:"integrationParametersInternal,omitempty"`
 	Origin                        string                   `json:"origin,omitempty"`
 	Status                        string                   `json:"status,omitempty"`
 	SnapshotNumber                string                   `json:"snapshotNumber,omitempty"`
@@ -108,6 +108,15 @@ type basicIntegrationVersion struct {
 	State          string `json:"state,omitempty"`
 }
 
+// VersionSummary holds the fields of an integration version shown in listings
+type VersionSummary struct {
+	Version        string `json:"version,omitempty"`
+	State          string `json:"state,omitempty"`
+	SnapshotNumber string `json:"snapshotNumber,omitempty"`
+	UserLabel      string `json:"userLabel,omitempty"`
+	CreateTime     string `json:"createTime,omitempty"`
+}
+
 type listintegrations struct {
 	Integrations  []integration `json:"integrations,omitempty"`
 	NextPageToken string        `json:"nextPageToken,omitempty"`
@@ -145,6 +154,7 @@ type parameterExternal struct {
 	Searchable      bool       `json:"searchable,omitempty"`
 	JsonSchema      string     `json:"jsonSchema,omitempty"`
 	Masked          bool       `json:"masked,omitempty"`
+	DisplayName     string     `json:"displayName,omitempty"`
 }
 
 type parameterConfig struct {
@@ -172,7 +182,7 @@ type triggerconfig struct {
 	Description              string                   `json:"description,omitempty"`
 	StartTasks               []nextTask               `json:"startTasks,omitempty"`
 	NextTasksExecutionPolicy string                   `json:"nextTasksExecutionPolicy,omitempty"`
-	AlertConfig              []map[string]interface{} `json:"alterConfig,omitempty"`
+	AlertConfig              []map[string]interface{} `json:"alertConfig,omitempty"`
 	Properties               map[string]string        `json:"properties,omitempty"`
 	CloudSchedulerConfig     *cloudSchedulerConfig    `json:"cloudSchedulerConfig,omitempty"`
 	ErrorCatcherId           string                   `json:"errorCatcherId,omitempty"`
@@ -219,7 +229,7 @@ type valueType struct {
 	BooleanValue *bool            `json:"booleanValue,omitempty"`
 	StringArray  *stringarraytype `json:"stringArray,omitempty"`
 	JsonValue    *string          `json:"jsonValue,omitempty"`
-	DoubleValue  float64          `json:"doubleValue,omitempty"`
+	DoubleValue  *float64         `json:"doubleValue,omitempty"`
 	IntArray     *intarray        `json:"intArray,omitempty"`
 	DoubleArray  *doublearray     `json:"doubleArray,omitempty"`
 	BooleanArray *booleanarray    `json:"booleanArray,omitempty"`
@@ -280,25 +290,8 @@ func CreateVersion(name string, content []byte, overridesContent []byte, snapsho
 	eversion := convertInternalToExternal(iversion)
 
 	// merge overrides if overrides were provided
-	if len(overridesContent) > 0 {
-		o := overrides{
-			IntegrationOverrides: integrationoverrides{
-				RunAsServiceAccount:       nil,
-				DatabasePersistencePolicy: "DATABASE_PERSISTENCE_POLICY_UNSPECIFIED",
-				EnableVariableMasking:     false,
-				CloudLoggingDetails: cloudLoggingDetails{
-					EnableCloudLogging:   false,
-					CloudLoggingSeverity: "CLOUD_LOGGING_SEVERITY_UNSPECIFIED",
-				},
-			},
-		}
-
-		if err = json.Unmarshal(overridesContent, &o); err != nil {
-			return nil, err
-		}
-		if eversion, err = mergeOverrides(eversion, o, grantPermission); err != nil {
-			return nil, err
-		}
+	if eversion, err = applyOverrides(eversion, overridesContent, grantPermission); err != nil {
+		return nil, err
 	}
 
 	if snapshot != "" {
@@ -318,6 +311,7 @@ func CreateVersion(name string, content []byte, overridesContent []byte, snapsho
 		apiclient.ClientPrintHttpResponse.Set(false)
 	}
 
+	invalidateVersions(name)
 	u, _ := url.Parse(apiclient.GetBaseIntegrationURL())
 	u.Path = path.Join(u.Path, "integrations", name, "versions")
 	respBody, err = apiclient.HttpClient(u.String(), string(content))
@@ -349,6 +343,7 @@ func Upload(name string, content []byte) (respBody []byte, err error) {
 			"stringified integration json and optionally the file format")
 	}
 
+	invalidateVersions(name)
 	u, _ := url.Parse(apiclient.GetBaseIntegrationURL())
 	u.Path = path.Join(u.Path, "integrations", name, "versions:upload")
 	respBody, err = apiclient.HttpClient(u.String(), string(content))
@@ -369,12 +364,32 @@ func Patch(name string, version string, content []byte) (respBody []byte, err er
 		return nil, err
 	}
 
+	invalidateVersions(name)
 	u, _ := url.Parse(apiclient.GetBaseIntegrationURL())
 	u.Path = path.Join(u.Path, "integrations", name, "versions", version)
 	respBody, err = apiclient.HttpClient(u.String(), string(content), "PATCH")
 	return respBody, err
 }
 
+// SetUserLabel sets the user label of an integration version, an empty label clears it
+func SetUserLabel(name string, version string, userLabel string) (respBody []byte, err error) {
+	payload, err := json.Marshal(struct {
+		UserLabel string `json:"userLabel"`
+	}{userLabel})
+	if err != nil {
+		return nil, err
+	}
+
+	invalidateVersions(name)
+	u, _ := url.Parse(apiclient.GetBaseIntegrationURL())
+	u.Path = path.Join(u.Path, "integrations", name, "versions", version)
+	q := u.Query()
+	q.Set("updateMask", "userLabel")
+	u.RawQuery = q.Encode()
+
+	return apiclient.HttpClient(u.String(), string(payload), "PATCH")
+}
+
 // TakeOverEditLock
 func TakeoverEditLock(name string, version string) (respBody []byte, err error) {
 	u, _ := url.Parse(apiclient.GetBaseIntegrationURL())
@@ -421,21 +436,10 @@ func ListVersions(name string, pageSize int, pageToken string, filter string, or
 				return nil, err
 			}
 			apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting())
-			listIvers := listIntegrationVersions{}
-			listBIvers := listbasicIntegrationVersions{}
-
-			if err = json.Unmarshal(respBody, &listIvers); err != nil {
+			newResp, err := GetBasicVersions(respBody)
+			if err != nil {
 				return nil, err
 			}
-
-			for _, iVer := range listIvers.IntegrationVersions {
-				basicIVer := basicIntegrationVersion{}
-				basicIVer.SnapshotNumber = iVer.SnapshotNumber
-				basicIVer.Version = getVersion(iVer.Name)
-				basicIVer.State = iVer.State
-				listBIvers.BasicIntegrationVersions = append(listBIvers.BasicIntegrationVersions, basicIVer)
-			}
-			newResp, err := json.Marshal(listBIvers)
 			if clientPrintSetting {
 				apiclient.PrettyPrint(newResp)
 			}
@@ -502,6 +506,81 @@ func ListVersions(name string, pageSize int, pageToken string, filter string, or
 	return nil, err
 }
 
+// ListAllVersions follows the page tokens of the versions list and returns the
+// versions in a single list response. A limit of 0 returns all the versions
+func ListAllVersions(name string, pageSize int, pageToken string, filter string, orderBy string,
+	limit int,
+) (respBody []byte, err error) {
+	allVersions := listIntegrationVersions{}
+
+	apiclient.ClientPrintHttpResponse.Set(false)
+	defer apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting())
+
+	for {
+		iversions := listIntegrationVersions{}
+		if respBody, err = listVersionsPage(name, pageSize, pageToken, filter, orderBy,
+			false, false, false); err != nil {
+			return nil, err
+		}
+		if err = json.Unmarshal(respBody, &iversions); err != nil {
+			return nil, err
+		}
+		allVersions.IntegrationVersions = append(allVersions.IntegrationVersions, iversions.IntegrationVersions...)
+
+		if limit > 0 && len(allVersions.IntegrationVersions) >= limit {
+			allVersions.IntegrationVersions = allVersions.IntegrationVersions[:limit]
+			break
+		}
+		if iversions.NextPageToken == "" {
+			break
+		}
+		pageToken = iversions.NextPageToken
+	}
+
+	return json.Marshal(allVersions)
+}
+
+// GetBasicVersions returns the version, snapshot number and state of each version in a list response
+func GetBasicVersions(respBody []byte) (basicResp []byte, err error) {
+	listIvers := listIntegrationVersions{}
+	listBIvers := listbasicIntegrationVersions{}
+
+	if err = json.Unmarshal(respBody, &listIvers); err != nil {
+		return nil, err
+	}
+
+	for _, iVer := range listIvers.IntegrationVersions {
+		basicIVer := basicIntegrationVersion{}
+		basicIVer.SnapshotNumber = iVer.SnapshotNumber
+		basicIVer.Version = getVersion(iVer.Name)
+		basicIVer.State = iVer.State
+		listBIvers.BasicIntegrationVersions = append(listBIvers.BasicIntegrationVersions, basicIVer)
+	}
+	return json.Marshal(listBIvers)
+}
+
+// GetVersionSummaries returns a summary of each version in a list response
+func GetVersionSummaries(respBody []byte) (summaries []VersionSummary, err error) {
+	listIvers := listIntegrationVersions{}
+	if err = json.Unmarshal(respBody, &listIvers); err != nil {
+		return nil, err
+	}
+
+	for _, iVer := range listIvers.IntegrationVersions {
+		summary := VersionSummary{
+			Version:        getVersion(iVer.Name),
+			State:          iVer.State,
+			SnapshotNumber: iVer.SnapshotNumber,
+			CreateTime:     iVer.CreateTime,
+		}
+		if iVer.UserLabel != nil {
+			summary.UserLabel = *iVer.UserLabel
+		}
+		summaries = append(summaries, summary)
+	}
+	return summaries, nil
+}
+
 // List
 func List(pageSize int, pageToken string, filter string, orderBy string) (respBody []byte, err error) {
 	u, _ := url.Parse(apiclient.GetBaseIntegrationURL())
@@ -536,6 +615,10 @@ func Get(name string, version string, basicInfo bool, minimal bool, override boo
 	apiclient.ClientPrintHttpResponse.Set(false)
 
 	respBody, err := apiclient.HttpClient(u.String())
+	if err != nil {
+		apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting())
+		return nil, err
+	}
 
 	if !override && !minimal && !basicInfo {
 		apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting())
@@ -636,6 +719,19 @@ func GetByUserlabel(name string, userLabel string, basicInfo bool, minimal bool,
 	return Get(name, version, false, minimal, override)
 }
 
+// GetConfigVariableTypes returns the declared data type of each config variable of the integration
+func GetConfigVariableTypes(contents []byte) (types map[string]string, err error) {
+	iversion := integrationVersion{}
+	if err = json.Unmarshal(contents, &iversion); err != nil {
+		return nil, err
+	}
+	types = make(map[string]string)
+	for _, param := range iversion.IntegrationConfigParameters {
+		types[param.Parameter.Key] = param.Parameter.DataType
+	}
+	return types, nil
+}
+
 // GetConfigVariables
 func GetConfigVariables(contents []byte) (respBody []byte, err error) {
 	iversion := integrationVersion{}
@@ -682,6 +778,7 @@ func GetConfigVariables(contents []byte) (respBody []byte, err error) {
 
 // Delete
 func Delete(name string) (respBody []byte, err error) {
+	invalidateVersions(name)
 	u, _ := url.Parse(apiclient.GetBaseIntegrationURL())
 	u.Path = path.Join(u.Path, "integrations", name)
 	respBody, err = apiclient.HttpClient(u.String(), "", "DELETE")
@@ -690,12 +787,50 @@ func Delete(name string) (respBody []byte, err error) {
 
 // DeleteVersion
 func DeleteVersion(name string, version string) (respBody []byte, err error) {
+	invalidateVersions(name)
 	u, _ := url.Parse(apiclient.GetBaseIntegrationURL())
 	u.Path = path.Join(u.Path, "integrations", name, "versions", version)
 	respBody, err = apiclient.HttpClient(u.String(), "", "DELETE")
 	return respBody, err
 }
 
+// the requests of ListAllVersions and DeleteWithVersions; they are variables to replace the API in tests
+var (
+	listVersionsPage  = ListVersions
+	unpublishVersion  = Unpublish
+	deleteVersion     = DeleteVersion
+	deleteIntegration = Delete
+)
+
+// DeleteWithVersions deletes each of the versions, unpublishing the published ones first,
+// and then the integration. The integration is kept when a version could not be deleted
+func DeleteWithVersions(name string, versions []VersionSummary) (err error) {
+	apiclient.ClientPrintHttpResponse.Set(false)
+	defer apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting())
+
+	errs := []string{}
+	for _, v := range versions {
+		if v.State == "ACTIVE" {
+			clilog.Info.Printf("Unpublishing version %s of %s\n", v.Version, name)
+			if _, err = unpublishVersion(name, v.Version); err != nil {
+				errs = append(errs, fmt.Sprintf("version %s: %v", v.Version, err))
+				continue
+			}
+		}
+		clilog.Info.Printf("Deleting version %s of %s\n", v.Version, name)
+		if _, err = deleteVersion(name, v.Version); err != nil {
+			errs = append(errs, fmt.Sprintf("version %s: %v", v.Version, err))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("integration %s was not deleted:\n%s", name, strings.Join(errs, "\n"))
+	}
+
+	clilog.Info.Printf("Deleting integration %s\n", name)
+	_, err = deleteIntegration(name)
+	return err
+}
+
 // DeleteByUserlabel
 func DeleteByUserlabel(name string, userLabel string) (respBody []byte, err error) {
 	apiclient.ClientPrintHttpResponse.Set(false)
@@ -794,11 +929,120 @@ func PublishUserLabel(name string, userlabel string, configVariables []byte) (re
 	return changeState(name, "", "userLabel="+userlabel, configVariables, ":publish")
 }
 
+// publishVersion and findVersion are variables to simulate conflicts in tests
+var (
+	publishVersion = Publish
+	findVersion    = getVersionId
+)
+
+// PublishWithRetry publishes a version. If the integration was changed by a concurrent
+// publish, the latest version matching the user label or snapshot is fetched again and
+// the publish is retried once. Without a user label or snapshot the conflict is returned
+func PublishWithRetry(name string, version string, userLabel string, snapshot string,
+	configVariables []byte,
+) (respBody []byte, err error) {
+	respBody, err = publishVersion(name, version, configVariables)
+	if !isConflict(respBody, err) {
+		return respBody, err
+	}
+	// the versions changed concurrently, do not reuse the cached version ids
+	invalidateVersions(name)
+
+	if userLabel != "" {
+		if version, err = findVersion(name, "userLabel="+userLabel); err != nil {
+			return nil, err
+		}
+	} else if snapshot != "" {
+		if version, err = findVersion(name, "snapshotNumber="+snapshot); err != nil {
+			return nil, err
+		}
+	} else {
+		// without a user label or snapshot the version cannot be resolved again
+		if err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("publishing integration %s version %s conflicted: %s", name, version, string(respBody))
+	}
+
+	clilog.Warning.Printf("Publishing integration %s conflicted with another change, retrying with version %s\n",
+		name, version)
+	respBody, err = publishVersion(name, version, configVariables)
+	if err == nil && isConflict(respBody, nil) {
+		return nil, fmt.Errorf("publishing integration %s version %s conflicted again: %s", name, version, string(respBody))
+	}
+	return respBody, err
+}
+
+// isConflict returns true if the response or error of a call reports a 409 conflict.
+// Conflicts are returned as a response body when conflicts are not treated as errors
+func isConflict(respBody []byte, err error) bool {
+	if err != nil {
+		return strings.HasPrefix(err.Error(), "Conflict - ")
+	}
+	e := struct {
+		Error struct {
+			Code   int    `json:"code,omitempty"`
+			Status string `json:"status,omitempty"`
+		} `json:"error,omitempty"`
+	}{}
+	if json.Unmarshal(respBody, &e) != nil {
+		return false
+	}
+	return e.Error.Code == 409 || e.Error.Status == "ABORTED"
+}
+
 // PublishSnapshot
 func PublishSnapshot(name string, snapshot string, configVariables []byte) (respBody []byte, err error) {
 	return changeState(name, "", "snapshotNumber="+snapshot, configVariables, ":publish")
 }
 
+// Reconfigure sets the config variables of a published version by publishing it again with
+// the config variables, so no new version is created. If the API rejects the version because it
+// is already published, it is unpublished and then published with the config variables
+func Reconfigure(name string, version string, filter string, configVariables []byte) (respBody []byte, err error) {
+	if version == "" {
+		if version, err = getVersionId(name, filter); err != nil {
+			return nil, err
+		}
+	}
+
+	apiclient.ClientPrintHttpResponse.Set(false)
+	defer apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting())
+
+	if respBody, err = Get(name, version, false, false, false); err != nil {
+		return nil, err
+	}
+	iversion := integrationVersion{}
+	if err = json.Unmarshal(respBody, &iversion); err != nil {
+		return nil, err
+	}
+	if iversion.State != "ACTIVE" {
+		return nil, fmt.Errorf("integration %s version %s is in state %s, only a published version can be reconfigured",
+			name, version, iversion.State)
+	}
+
+	if respBody, err = Publish(name, version, configVariables); err == nil || !isAlreadyPublished(err) {
+		return respBody, err
+	}
+	clilog.Warning.Printf("Integration %s version %s is already published, unpublishing it first\n", name, version)
+	if _, err = Unpublish(name, version); err != nil {
+		return nil, fmt.Errorf("unable to unpublish integration %s version %s: %w", name, version, err)
+	}
+	if respBody, err = Publish(name, version, configVariables); err != nil {
+		clilog.Error.Printf("Integration %s version %s was unpublished and could not be published again\n",
+			name, version)
+		return nil, fmt.Errorf("integration %s version %s is no longer published, publish it again: %w",
+			name, version, err)
+	}
+	return respBody, nil
+}
+
+// isAlreadyPublished returns true if publishing a version failed because it is already published
+func isAlreadyPublished(err error) bool {
+	message := strings.ToLower(err.Error())
+	return strings.Contains(message, "already published") || strings.Contains(message, "already active")
+}
+
 // DownloadSnapshot
 func DownloadSnapshot(name string, snapshot string) (respBody []byte, err error) {
 	var version string
@@ -849,6 +1093,79 @@ func GetAuthConfigs(integration []byte) (authcfgs []string, err error) {
 	return authcfgs, err
 }
 
+// ListNames returns the names of all the integrations in the region
+func ListNames() (names []string, err error) {
+	var respBody []byte
+	pageToken := ""
+	names = []string{}
+
+	apiclient.ClientPrintHttpResponse.Set(false)
+	defer apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting())
+
+	for {
+		l := listintegrations{}
+		if respBody, err = List(maxPageSize, pageToken, "", ""); err != nil {
+			return nil, err
+		}
+		if err = json.Unmarshal(respBody, &l); err != nil {
+			return nil, err
+		}
+		for _, i := range l.Integrations {
+			names = append(names, path.Base(i.Name))
+		}
+		if l.NextPageToken == "" {
+			break
+		}
+		pageToken = l.NextPageToken
+	}
+	return names, nil
+}
+
+// FindAuthConfigUsers returns the published integration versions that reference the authconfig
+// by id or by display name
+func FindAuthConfigUsers(authConfigId string, displayName string) (users []string, err error) {
+	var respBody []byte
+
+	names, err := ListNames()
+	if err != nil {
+		return nil, err
+	}
+
+	apiclient.ClientPrintHttpResponse.Set(false)
+	defer apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting())
+
+	for _, name := range names {
+		iversions := listIntegrationVersions{}
+		if respBody, err = ListAllVersions(name, maxPageSize, "", "state=ACTIVE", "", 0); err != nil {
+			return nil, err
+		}
+		if err = json.Unmarshal(respBody, &iversions); err != nil {
+			return nil, err
+		}
+		for _, iversion := range iversions.IntegrationVersions {
+			if referencesAuthConfig(iversion, authConfigId, displayName) {
+				users = append(users, fmt.Sprintf("%s (version %s)", name, getVersion(iversion.Name)))
+			}
+		}
+	}
+	return users, nil
+}
+
+// referencesAuthConfig returns true if any task of the version uses the authconfig
+func referencesAuthConfig(iversion integrationVersion, authConfigId string, displayName string) bool {
+	for _, taskConfig := range iversion.TaskConfigs {
+		if p, ok := taskConfig.Parameters["authConfig"]; ok && p.Value.JsonValue != nil &&
+			getAuthConfigUuid(*p.Value.JsonValue) == authConfigId {
+			return true
+		}
+		if p, ok := taskConfig.Parameters["authConfigName"]; ok && p.Value.StringValue != nil &&
+			displayName != "" && *p.Value.StringValue == displayName {
+			return true
+		}
+	}
+	return false
+}
+
 // GetSfdcInstances
 func GetSfdcInstances(integration []byte) (instances map[string]string, err error) {
 	iversion := integrationVersion{}
@@ -943,10 +1260,23 @@ func GetConnectionsWithRegion(integration []byte) (connections []integrationConn
 	return connections, err
 }
 
-// GetVersion
+// GetVersion resolves the user label or snapshot number of the integration to its version id
 func GetVersion(name string, userLabel string, snapshot string) (version string, err error) {
 	var integrationBody []byte
 
+	filter := "userLabel=" + userLabel
+	if userLabel == "" {
+		filter = "snapshotNumber=" + snapshot
+	}
+	if version, ok := getCachedVersion(name, filter); ok {
+		return version, nil
+	}
+	defer func() {
+		if err == nil {
+			cacheVersion(name, filter, version)
+		}
+	}()
+
 	apiclient.DisableCmdPrintHttpResponse()
 	defer apiclient.EnableCmdPrintHttpResponse()
 
@@ -996,6 +1326,9 @@ func changeState(name string, version string, filter string, configVars []byte,
 			return nil, err
 		}
 	}
+	if action != ":download" {
+		invalidateVersions(name)
+	}
 	u, _ := url.Parse(apiclient.GetBaseIntegrationURL())
 	u.Path = path.Join(u.Path, "integrations", name, "versions", version+action)
 	// download is a get, the rest are post
@@ -1018,8 +1351,21 @@ func changeState(name string, version string, filter string, configVars []byte,
 	return respBody, err
 }
 
-// getVersionId
+// getVersionId returns the id of the first version matching the filter, from the version
+// cache when it is enabled
 func getVersionId(name string, filter string) (version string, err error) {
+	if version, ok := getCachedVersion(name, filter); ok {
+		return version, nil
+	}
+	if version, err = listVersionId(name, filter); err != nil {
+		return "", err
+	}
+	cacheVersion(name, filter, version)
+	return version, nil
+}
+
+// listVersionId looks up the id of the first version matching the filter
+var listVersionId = func(name string, filter string) (version string, err error) {
 	u, _ := url.Parse(apiclient.GetBaseIntegrationURL())
 	q := u.Query()
 	q.Set("filter", filter)
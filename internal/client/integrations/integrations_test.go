@@ -15,10 +15,17 @@
 package integrations
 
 import (
+	"encoding/json"
+	"errors"
+	"internal/apiclient"
 	"internal/client/clienttest"
 	"internal/cmd/utils"
+	"net/url"
 	"os"
 	"path"
+	"reflect"
+	"strconv"
+	"strings"
 	"testing"
 )
 
@@ -64,3 +71,590 @@ func TestDelete(t *testing.T) {
 		t.Fatalf("Delete failed: %v", err)
 	}
 }
+
+func TestPublishWithRetryConflict(t *testing.T) {
+	apiclient.NewIntegrationClient(apiclient.IntegrationClientOptions{
+		SkipCache: true,
+		NoOutput:  true,
+	})
+	defer func() {
+		publishVersion = Publish
+		findVersion = getVersionId
+	}()
+
+	conflict := []byte(`{"error": {"code": 409, "message": "version was modified", "status": "ABORTED"}}`)
+	tests := []struct {
+		name      string
+		responses [][]byte
+		errs      []error
+		published []string
+		wantErr   bool
+	}{
+		{"no conflict", [][]byte{[]byte(`{}`)}, []error{nil}, []string{"v1"}, false},
+		{"conflict response", [][]byte{conflict, []byte(`{}`)}, []error{nil, nil}, []string{"v1", "v2"}, false},
+		{
+			"conflict error", [][]byte{nil, []byte(`{}`)},
+			[]error{errors.New("Conflict - request conflicts with the current state of the server: {}"), nil},
+			[]string{"v1", "v2"}, false,
+		},
+		{"conflict twice", [][]byte{conflict, conflict}, []error{nil, nil}, []string{"v1", "v2"}, true},
+		{"other error", [][]byte{nil}, []error{errors.New("Bad Request")}, []string{"v1"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var published []string
+			publishVersion = func(name string, version string, configVariables []byte) ([]byte, error) {
+				i := len(published)
+				published = append(published, version)
+				return tt.responses[i], tt.errs[i]
+			}
+			findVersion = func(name string, filter string) (string, error) {
+				if filter != "userLabel=label" {
+					t.Errorf("findVersion() filter = %s, want userLabel=label", filter)
+				}
+				return "v2", nil
+			}
+
+			_, err := PublishWithRetry("name", "v1", "label", "", nil)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("PublishWithRetry() error = %v, wantErr %t", err, tt.wantErr)
+			}
+			if strings.Join(published, ",") != strings.Join(tt.published, ",") {
+				t.Errorf("published versions = %v, want %v", published, tt.published)
+			}
+		})
+	}
+
+	var published []string
+	publishVersion = func(name string, version string, configVariables []byte) ([]byte, error) {
+		published = append(published, version)
+		return conflict, nil
+	}
+	findVersion = func(name string, filter string) (string, error) {
+		t.Errorf("findVersion() called with %s, want no lookup without a user label or snapshot", filter)
+		return "v2", nil
+	}
+	if _, err := PublishWithRetry("name", "v1", "", "", nil); err == nil {
+		t.Errorf("PublishWithRetry() succeeded, expected the conflict without a user label or snapshot")
+	}
+	if strings.Join(published, ",") != "v1" {
+		t.Errorf("published versions = %v, want [v1]", published)
+	}
+}
+
+func TestIsAlreadyPublished(t *testing.T) {
+	tests := map[string]bool{
+		`Bad Request - malformed request syntax: {"error": {"message": "Integration version is already published."}}`: true,
+		`Bad Request - malformed request syntax: {"error": {"message": "Invalid config parameter"}}`:                  false,
+		"Forbidden - the client does not have access rights":                                                          false,
+	}
+	for message, want := range tests {
+		if got := isAlreadyPublished(errors.New(message)); got != want {
+			t.Errorf("isAlreadyPublished(%q) = %t, want %t", message, got, want)
+		}
+	}
+}
+
+func TestGetCode(t *testing.T) {
+	content := []byte(`{"taskConfigs":[
+{"task":"JavaScriptTask","taskId":"1","parameters":{"script":{"key":"script","value":{"stringValue":"var s = \"a\\nb\";\nreturn s;"}}}},
+{"task":"JavaScriptTask","taskId":"2","parameters":{}},
+{"task":"JsonnetMapperTask","taskId":"3","parameters":{"template":{"key":"template"}}}]}`)
+
+	codeMap, err := GetCode(content)
+	if err != nil {
+		t.Fatalf("GetCode failed: %v", err)
+	}
+	if want := "var s = \"a\\nb\";\nreturn s;"; codeMap["JavaScriptTask"]["1"] != want {
+		t.Errorf("GetCode script = %q, want %q", codeMap["JavaScriptTask"]["1"], want)
+	}
+	if len(codeMap["JavaScriptTask"]) != 1 || len(codeMap["JsonnetMapperTask"]) != 0 {
+		t.Errorf("GetCode returned code for tasks without code: %v", codeMap)
+	}
+}
+
+func TestDiffVersions(t *testing.T) {
+	a := []byte(`{"name":"projects/p/locations/l/integrations/i/versions/1","snapshotNumber":"1",
+"triggerConfigs":[{"triggerNumber":"1","label":"API Trigger"}],
+"taskConfigs":[{"task":"FieldMappingTask","taskId":"1","displayName":"Map"},{"task":"EmailTask","taskId":"2","displayName":"Email"}]}`)
+	b := []byte(`{"name":"projects/p/locations/l/integrations/i/versions/2","snapshotNumber":"2",
+"triggerConfigs":[{"triggerNumber":"1","label":"API Trigger"}],
+"taskConfigs":[{"task":"FieldMappingTask","taskId":"1","displayName":"Map v2"},{"task":"JavaScriptTask","taskId":"3","displayName":"Script"}]}`)
+
+	changes, err := DiffVersions(a, b)
+	if err != nil {
+		t.Fatalf("DiffVersions failed: %v", err)
+	}
+	want := []string{"changed task 1 [displayName]", "removed task 2 []", "added task 3 []"}
+	if len(changes) != len(want) {
+		t.Fatalf("expected %d changes, got %v", len(want), changes)
+	}
+	for i, c := range changes {
+		got := c.Kind + " " + c.Type + " " + c.Id + " [" + strings.Join(c.Fields, ",") + "]"
+		if got != want[i] {
+			t.Errorf("change %d: expected %q, got %q", i, want[i], got)
+		}
+	}
+
+	if changes, _ = DiffVersions(a, a); len(changes) != 0 {
+		t.Errorf("expected no changes, got %v", changes)
+	}
+}
+
+func TestReferencesAuthConfig(t *testing.T) {
+	contents := []byte(`{"taskConfigs":[{"task":"GenericRestV2Task","taskId":"1","parameters":{
+"authConfig":{"key":"authConfig","value":{"jsonValue":"{\"@type\":\"type.googleapis.com/enterprise.crm.eventbus.authconfig.AuthConfigTaskParam\",\"authConfigId\":\"1234\"}"}},
+"authConfigName":{"key":"authConfigName","value":{"stringValue":"sample"}}}}]}`)
+	iversion := integrationVersion{}
+	if err := json.Unmarshal(contents, &iversion); err != nil {
+		t.Fatalf("unable to parse version: %v", err)
+	}
+	if !referencesAuthConfig(iversion, "1234", "") {
+		t.Errorf("expected a reference by id")
+	}
+	if !referencesAuthConfig(iversion, "5678", "sample") {
+		t.Errorf("expected a reference by display name")
+	}
+	if referencesAuthConfig(iversion, "5678", "other") {
+		t.Errorf("expected no reference")
+	}
+}
+
+func TestGetExecutionSummary(t *testing.T) {
+	respBody := []byte(`{"name":"projects/p/locations/l/integrations/i/executions/abc","state":"FAILED",
+"executionDetails":{"state":"FAILED","executionSnapshots":[
+{"executionSnapshotMetadata":{"task":"FieldMappingTask","taskLabel":"Map","taskNumber":"2"},
+ "taskExecutionDetails":[{"taskNumber":"2","taskExecutionState":"SUCCEED"},{"taskNumber":"10","taskExecutionState":"PENDING_EXECUTION"}]},
+{"executionSnapshotMetadata":{"task":"GenericRestV2Task","taskLabel":"Call","taskNumber":"10"},
+ "params":{"ErrorInfo":{"key":"ErrorInfo","value":{"jsonValue":"{\"message\":\"500\"}"}}},
+ "taskExecutionDetails":[{"taskNumber":"2","taskExecutionState":"SUCCEED"},{"taskNumber":"10","taskExecutionState":"FATAL"}]}]}}`)
+
+	summary, err := GetExecutionSummary(respBody)
+	if err != nil {
+		t.Fatalf("GetExecutionSummary failed: %v", err)
+	}
+	if summary.ExecutionId != "abc" || summary.State != "FAILED" || len(summary.Tasks) != 2 {
+		t.Fatalf("unexpected summary %+v", summary)
+	}
+	if summary.Tasks[0].TaskNumber != "2" || summary.Tasks[0].State != "SUCCEED" {
+		t.Errorf("unexpected first task %+v", summary.Tasks[0])
+	}
+	if summary.Tasks[1].Label != "Call" || summary.Tasks[1].State != "FATAL" || summary.Tasks[1].Error != `{"message":"500"}` {
+		t.Errorf("unexpected second task %+v", summary.Tasks[1])
+	}
+}
+
+func TestLintVersion(t *testing.T) {
+	contents := []byte(`{"taskConfigs":[
+{"task":"JavaScriptTask","taskId":"1","parameters":{"script":{"key":"script","value":{"stringValue":"$` + "`CONFIG_missing`" + `$"}}}},
+{"task":"JavaScriptTask","taskId":"2"},
+{"task":"GenericRestV2Task","taskId":"2","parameters":{"url":{"key":"url","value":{"stringValue":"$` + "`CONFIG_url`" + `$"}},
+"authConfigName":{"key":"authConfigName","value":{"stringValue":"unknown"}}}}],
+"integrationConfigParameters":[{"parameter":{"key":"` + "`CONFIG_url`" + `","dataType":"STRING_VALUE"}}]}`)
+
+	javascriptFolder := t.TempDir()
+	if err := os.WriteFile(path.Join(javascriptFolder, "javascript_1.js"), []byte("return;"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	findings, err := lintVersion(contents, javascriptFolder, map[string]bool{"sample": true})
+	if err != nil {
+		t.Fatalf("lintVersion() error = %v", err)
+	}
+	want := []string{
+		"task id 2 is used by 2 tasks",
+		"config variable CONFIG_missing is referenced but not declared",
+		"task 2 has no code file javascript_2.js",
+		"task 2 references authconfig unknown, which is not in the region",
+	}
+	if strings.Join(findings, "\n") != strings.Join(want, "\n") {
+		t.Errorf("lintVersion() = %q, want %q", findings, want)
+	}
+
+	if findings, err = lintVersion(contents, "", nil); err != nil || len(findings) != 2 {
+		t.Errorf("lintVersion() without folder and authconfigs = %q, %v, want 2 findings", findings, err)
+	}
+}
+
+func TestLintCodeFiles(t *testing.T) {
+	contents := []byte(`{"taskConfigs":[
+{"task":"JavaScriptTask","taskId":"1"},
+{"task":"JsonnetMapperTask","taskId":"2"},
+{"task":"JsonnetMapperTask","taskId":"3"}]}`)
+
+	javascriptFolder := t.TempDir()
+	jsonnetFolder := t.TempDir()
+	for file, folder := range map[string]string{
+		"javascript_1.js":             javascriptFolder,
+		"javascript_4.js":             javascriptFolder,
+		"datatransformer_2.jsonnet":   jsonnetFolder,
+		"datatransformer_2.libsonnet": jsonnetFolder,
+	} {
+		if err := os.WriteFile(path.Join(folder, file), []byte("{}"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	findings, err := LintCodeFiles(contents, javascriptFolder, jsonnetFolder)
+	if err != nil {
+		t.Fatalf("LintCodeFiles() error = %v", err)
+	}
+	want := []string{
+		"code file javascript_4.js does not belong to a JavaScriptTask",
+		"task 3 has no code file datatransformer_3.jsonnet",
+	}
+	if strings.Join(findings, "\n") != strings.Join(want, "\n") {
+		t.Errorf("LintCodeFiles() = %q, want %q", findings, want)
+	}
+
+	if findings, err = LintCodeFiles(contents, path.Join(javascriptFolder, "missing"), ""); err != nil || len(findings) != 0 {
+		t.Errorf("LintCodeFiles() without folders = %q, %v, want no findings", findings, err)
+	}
+}
+
+func TestGetUndeclaredConfigVariables(t *testing.T) {
+	contents := []byte(`{"integrationConfigParameters":[{"parameter":{"key":"` + "`CONFIG_url`" + `","dataType":"STRING_VALUE"}}]}`)
+	overrides := []byte(`{"trigger_overrides":[{"triggerNumber":"1","topicName":"$` + "`CONFIG_topic`" + `$"}],` +
+		`"task_overrides":[{"taskId":"1","parameters":{"url":{"value":{"stringValue":"$` + "`CONFIG_url`" + `$"}}}}]}`)
+
+	undeclared, err := GetUndeclaredConfigVariables(contents, overrides)
+	if err != nil {
+		t.Fatalf("GetUndeclaredConfigVariables() error = %v", err)
+	}
+	if !reflect.DeepEqual(undeclared, []string{"CONFIG_topic"}) {
+		t.Errorf("GetUndeclaredConfigVariables() = %q, want [CONFIG_topic]", undeclared)
+	}
+}
+
+func TestGenerateOverrides(t *testing.T) {
+	apiclient.NewIntegrationClient(apiclient.IntegrationClientOptions{
+		SkipCache: true,
+		NoOutput:  true,
+	})
+	_ = apiclient.SetProjectID("target-project")
+	_ = apiclient.SetRegion("europe-west1")
+	contents := []byte(`{"triggerConfigs":[{"triggerNumber":"1","triggerType":"CLOUD_SCHEDULER",
+"cloudSchedulerConfig":{"cronTab":"0 * * * *","location":"us-central1"}}],
+"integrationConfigParameters":[{"parameter":{"key":"` + "`CONFIG_topic`" + `","dataType":"STRING_VALUE"},
+"value":{"stringValue":"projects/p/locations/us-central1/topics/t"}},
+{"parameter":{"key":"` + "`CONFIG_url`" + `","dataType":"STRING_VALUE"},"value":{"stringValue":"https://example.com"}}]}`)
+
+	respBody, err := GenerateOverrides(contents)
+	if err != nil {
+		t.Fatalf("GenerateOverrides() error = %v", err)
+	}
+	o := overrides{}
+	if err = json.Unmarshal(respBody, &o); err != nil {
+		t.Fatalf("GenerateOverrides() = %s, not valid overrides: %v", respBody, err)
+	}
+	if len(o.TriggerOverrides) != 1 || o.TriggerOverrides[0].TriggerNumber != "1" ||
+		*o.TriggerOverrides[0].CloudSchedulerLocation != "europe-west1" {
+		t.Errorf("GenerateOverrides() trigger overrides = %s, want the scheduler moved to europe-west1", respBody)
+	}
+
+	iversion := integrationVersion{}
+	_ = json.Unmarshal(contents, &iversion)
+	if keys := getConfigVariableRegions(iversion, "europe-west1"); !reflect.DeepEqual(keys, []string{"`CONFIG_topic`"}) {
+		t.Errorf("getConfigVariableRegions() = %q, want [`CONFIG_topic`]", keys)
+	}
+}
+
+func TestGraph(t *testing.T) {
+	contents := []byte(`{"triggerConfigs":[{"label":"API Trigger","triggerType":"API","startTasks":[{"taskId":"1"}]}],
+"taskConfigs":[{"task":"FieldMappingTask","taskId":"1","displayName":"Map \"input\"",
+"nextTasks":[{"taskId":"2","condition":"$status$ = \"ok\""},{"taskId":"3"}]},
+{"task":"GenericRestV2Task","taskId":"2","displayName":"Call API"},{"task":"EmailTask","taskId":"3"}]}`)
+
+	dot, err := Graph(contents, GraphFormatDot)
+	if err != nil {
+		t.Fatalf("Graph() error = %v", err)
+	}
+	for _, want := range []string{
+		`trigger_1 [label="API Trigger\nAPI", shape=ellipse];`,
+		`task_1 [label="Map \"input\"\nFieldMappingTask"];`,
+		`task_3 [label="3\nEmailTask"];`,
+		`trigger_1 -> task_1;`,
+		`task_1 -> task_2 [label="$status$ = \"ok\""];`,
+		`task_1 -> task_3;`,
+	} {
+		if !strings.Contains(dot, want) {
+			t.Errorf("Graph() dot does not contain %s:\n%s", want, dot)
+		}
+	}
+
+	mermaid, err := Graph(contents, GraphFormatMermaid)
+	if err != nil {
+		t.Fatalf("Graph() error = %v", err)
+	}
+	for _, want := range []string{
+		`trigger_1(["API Trigger<br/>API"])`,
+		`task_1["Map #quot;input#quot;<br/>FieldMappingTask"]`,
+		`task_1 -->|"$status$ = #quot;ok#quot;"| task_2`,
+	} {
+		if !strings.Contains(mermaid, want) {
+			t.Errorf("Graph() mermaid does not contain %s:\n%s", want, mermaid)
+		}
+	}
+
+	if _, err = Graph(contents, "svg"); err == nil {
+		t.Errorf("Graph() succeeded with an unsupported format")
+	}
+}
+
+func TestSchema(t *testing.T) {
+	content := []byte(`{"description":"sample","integrationParameters":[
+		{"key":"name","dataType":"STRING_VALUE","inputOutputType":"IN","defaultValue":{"stringValue":"x"}},
+		{"key":"count","dataType":"INT_VALUE","inputOutputType":"IN_OUT","defaultValue":{"intValue":"3"}},
+		{"key":"ratio","dataType":"DOUBLE_VALUE","inputOutputType":"OUT","defaultValue":{}},
+		{"key":"ok","dataType":"BOOLEAN_VALUE","inputOutputType":"OUT"},
+		{"key":"ids","dataType":"INT_ARRAY","inputOutputType":"IN","defaultValue":{"intArray":{"intValues":["1","2"]}}},
+		{"key":"order","dataType":"JSON_VALUE","inputOutputType":"IN",
+		 "jsonSchema":"{\"$schema\":\"http://json-schema.org/draft-07/schema#\",\"type\":\"object\",\"required\":[\"id\"]}"},
+		{"key":"payload","dataType":"JSON_VALUE","inputOutputType":"OUT"},
+		{"key":"local","dataType":"STRING_VALUE"}]}`)
+
+	schema, err := Schema(content, "sample")
+	if err != nil {
+		t.Fatalf("Schema() error = %v", err)
+	}
+	want := `{"$schema":"https://json-schema.org/draft/2020-12/schema","title":"sample","description":"sample","type":"object","properties":{` +
+		`"input":{"additionalProperties":false,"properties":{"count":{"default":3,"type":"integer"},` +
+		`"ids":{"default":[1,2],"items":{"type":"integer"},"type":"array"},"name":{"default":"x","type":"string"},` +
+		`"order":{"required":["id"],"type":"object"}},"type":"object"},` +
+		`"output":{"properties":{"count":{"default":3,"type":"integer"},"ok":{"type":"boolean"},"payload":{},"ratio":{"type":"number"}},"type":"object"}}}`
+	if string(schema) != want {
+		t.Errorf("Schema() = %s, want %s", schema, want)
+	}
+
+	if _, err = Schema([]byte(`{"integrationParameters":[{"key":"order","dataType":"JSON_VALUE","inputOutputType":"IN","jsonSchema":"{"}]}`), ""); err == nil {
+		t.Errorf("Schema() succeeded, expected an error for an invalid parameter json schema")
+	}
+}
+
+func TestCheckConfigVariables(t *testing.T) {
+	contents := []byte(`{"taskConfigs":[{"task":"GenericRestV2Task","taskId":"1","parameters":{
+"url":{"key":"url","value":{"stringValue":"$` + "`CONFIG_url`" + `$/$` + "`CONFIG_path`" + `$"}}}}],
+"integrationConfigParameters":[{"parameter":{"key":"` + "`CONFIG_url`" + `","dataType":"STRING_VALUE"}},
+{"parameter":{"key":"` + "`CONFIG_timeout`" + `","dataType":"INT_VALUE","defaultValue":{"intValue":"30"}}},
+{"parameter":{"key":"` + "`CONFIG_token`" + `","dataType":"STRING_VALUE"}}]}`)
+
+	unused, missing, err := CheckConfigVariables(contents, []byte(`{"`+"`CONFIG_url`"+`":"https://example.com","CONFIG_old":"x"}`))
+	if err != nil {
+		t.Fatalf("CheckConfigVariables() error = %v", err)
+	}
+	if want := []string{"CONFIG_old"}; !reflect.DeepEqual(unused, want) {
+		t.Errorf("CheckConfigVariables() unused = %v, want %v", unused, want)
+	}
+	if want := []string{"CONFIG_path", "CONFIG_token"}; !reflect.DeepEqual(missing, want) {
+		t.Errorf("CheckConfigVariables() missing = %v, want %v", missing, want)
+	}
+}
+
+func TestVersionCache(t *testing.T) {
+	apiclient.NewIntegrationClient(apiclient.IntegrationClientOptions{
+		SkipCache: true,
+		NoOutput:  true,
+	})
+	lookupVersion := listVersionId
+	defer func() {
+		listVersionId = lookupVersion
+		DisableVersionCache()
+	}()
+	lookups := 0
+	listVersionId = func(name string, filter string) (string, error) {
+		lookups++
+		return "v" + strconv.Itoa(lookups), nil
+	}
+
+	// without the cache, each resolution is looked up
+	_, _ = getVersionId("name", "userLabel=label")
+	_, _ = getVersionId("name", "userLabel=label")
+	if lookups != 2 {
+		t.Fatalf("getVersionId() looked up %d times without the cache, want 2", lookups)
+	}
+
+	EnableVersionCache()
+	lookups = 0
+	for i := 0; i < 3; i++ {
+		if version, err := getVersionId("name", "userLabel=label"); err != nil || version != "v1" {
+			t.Fatalf("getVersionId() = %s, %v, want v1", version, err)
+		}
+	}
+	_, _ = getVersionId("other", "userLabel=label")
+	if lookups != 2 {
+		t.Errorf("getVersionId() looked up %d times with the cache, want 2", lookups)
+	}
+
+	// a changed integration is looked up again
+	invalidateVersions("name")
+	if version, _ := getVersionId("name", "userLabel=label"); version != "v3" {
+		t.Errorf("getVersionId() = %s after invalidating, want v3", version)
+	}
+	if version, _ := getVersionId("other", "userLabel=label"); version != "v2" {
+		t.Errorf("getVersionId() = %s for another integration, want the cached v2", version)
+	}
+
+	// the active version changes when a version is published, so it is always looked up
+	lookups = 0
+	_, _ = getVersionId("name", "state=ACTIVE")
+	_, _ = getVersionId("name", "state=ACTIVE")
+	if lookups != 2 {
+		t.Errorf("getVersionId() looked up the active version %d times, want 2", lookups)
+	}
+}
+
+func TestResolve(t *testing.T) {
+	apiclient.NewIntegrationClient(apiclient.IntegrationClientOptions{
+		SkipCache: true,
+		NoOutput:  true,
+	})
+	_ = apiclient.SetProjectID("my-project")
+	_ = apiclient.SetRegion("us-west1")
+	defer func() { sendRequest = apiclient.HttpClient }()
+
+	tests := []struct {
+		action  string
+		state   string
+		wantErr bool
+	}{
+		{"LIFT", "LIFTED", false},
+		{"raise", "REJECTED", false},
+		{"CANCEL", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.action, func(t *testing.T) {
+			var requests []string
+			sendRequest = func(params ...string) ([]byte, error) {
+				requests = append(requests, strings.Join(params, " "))
+				return []byte(`{}`), nil
+			}
+			_, err := Resolve("name", "exec-1", "susp-1", tt.action)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Resolve() error = %v, wantErr %t", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if len(requests) != 0 {
+					t.Errorf("Resolve() sent %v, want no request", requests)
+				}
+				return
+			}
+			name := "projects/my-project/locations/us-west1/integrations/name/executions/exec-1/suspensions/susp-1"
+			want := "https://us-west1-integrations.googleapis.com/v1/" + name + `:resolve ` +
+				`{"suspension":{"name":"` + name + `","state":"` + tt.state + `"}}`
+			if len(requests) != 1 || requests[0] != want {
+				t.Errorf("Resolve() sent %v, want %s", requests, want)
+			}
+		})
+	}
+}
+
+func TestListPendingSuspensions(t *testing.T) {
+	apiclient.NewIntegrationClient(apiclient.IntegrationClientOptions{
+		SkipCache: true,
+		NoOutput:  true,
+	})
+	_ = apiclient.SetProjectID("my-project")
+	_ = apiclient.SetRegion("us-west1")
+	defer func() { sendRequest = apiclient.HttpClient }()
+
+	pages := map[string]string{
+		"": `{"suspensions":[{"name":"s/susp-1","state":"PENDING"},{"name":"s/susp-2","state":"LIFTED"}],` +
+			`"nextPageToken":"page2"}`,
+		"page2": `{"suspensions":[{"name":"s/susp-3","state":"REJECTED"},{"name":"s/susp-4","state":"PENDING"}]}`,
+	}
+	var pageTokens []string
+	sendRequest = func(params ...string) ([]byte, error) {
+		u, err := url.Parse(params[0])
+		if err != nil {
+			return nil, err
+		}
+		if !strings.HasSuffix(u.Path, "/integrations/name/executions/exec-1/suspensions") {
+			t.Errorf("ListPendingSuspensions() requested %s", u.Path)
+		}
+		pageToken := u.Query().Get("pageToken")
+		pageTokens = append(pageTokens, pageToken)
+		return []byte(pages[pageToken]), nil
+	}
+
+	ids, err := ListPendingSuspensions("name", "exec-1")
+	if err != nil {
+		t.Fatalf("ListPendingSuspensions() error = %v", err)
+	}
+	if strings.Join(ids, ",") != "susp-1,susp-4" {
+		t.Errorf("ListPendingSuspensions() = %v, want [susp-1 susp-4]", ids)
+	}
+	if strings.Join(pageTokens, ",") != ",page2" {
+		t.Errorf("ListPendingSuspensions() requested pages %q, want the first page then page2", pageTokens)
+	}
+
+	sendRequest = func(params ...string) ([]byte, error) { return nil, errors.New("Not found") }
+	if _, err = ListPendingSuspensions("name", "exec-1"); err == nil {
+		t.Errorf("ListPendingSuspensions() succeeded, expected the API error")
+	}
+}
+
+func TestDeleteWithVersions(t *testing.T) {
+	apiclient.NewIntegrationClient(apiclient.IntegrationClientOptions{
+		SkipCache: true,
+		NoOutput:  true,
+	})
+	defer func() {
+		listVersionsPage, unpublishVersion, deleteVersion, deleteIntegration = ListVersions, Unpublish, DeleteVersion, Delete
+	}()
+
+	pages := map[string]string{
+		"": `{"integrationVersions":[{"name":"integrations/name/versions/v1","state":"ACTIVE"},` +
+			`{"name":"integrations/name/versions/v2","state":"DRAFT"}],"nextPageToken":"page2"}`,
+		"page2": `{"integrationVersions":[{"name":"integrations/name/versions/v3","state":"SNAPSHOT"}]}`,
+	}
+	listVersionsPage = func(name string, pageSize int, pageToken string, filter string, orderBy string,
+		allVersions bool, download bool, basicInfo bool,
+	) ([]byte, error) {
+		return []byte(pages[pageToken]), nil
+	}
+	var requests []string
+	unpublishVersion = func(name string, version string) ([]byte, error) {
+		requests = append(requests, "unpublish "+version)
+		return nil, nil
+	}
+	deleteVersion = func(name string, version string) ([]byte, error) {
+		requests = append(requests, "delete "+version)
+		return nil, nil
+	}
+	deleteIntegration = func(name string) ([]byte, error) {
+		requests = append(requests, "delete "+name)
+		return nil, nil
+	}
+
+	respBody, err := ListAllVersions("name", -1, "", "", "", 0)
+	if err != nil {
+		t.Fatalf("ListAllVersions() error = %v", err)
+	}
+	summaries, err := GetVersionSummaries(respBody)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = DeleteWithVersions("name", summaries); err != nil {
+		t.Fatalf("DeleteWithVersions() error = %v", err)
+	}
+	want := "unpublish v1,delete v1,delete v2,delete v3,delete name"
+	if strings.Join(requests, ",") != want {
+		t.Errorf("DeleteWithVersions() sent %v, want %s", requests, want)
+	}
+
+	requests = nil
+	deleteVersion = func(name string, version string) ([]byte, error) {
+		requests = append(requests, "delete "+version)
+		if version == "v2" {
+			return nil, errors.New("Forbidden")
+		}
+		return nil, nil
+	}
+	if err = DeleteWithVersions("name", summaries); err == nil || !strings.Contains(err.Error(), "version v2: Forbidden") {
+		t.Errorf("DeleteWithVersions() error = %v, want the version v2 error", err)
+	}
+	want = "unpublish v1,delete v1,delete v2,delete v3"
+	if strings.Join(requests, ",") != want {
+		t.Errorf("DeleteWithVersions() sent %v, want %s without deleting the integration", requests, want)
+	}
+}
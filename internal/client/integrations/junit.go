@@ -0,0 +1,120 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integrations
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"internal/apiclient"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// JUnitTestSuite collects test case results in the JUnit XML format
+type JUnitTestSuite struct {
+	XMLName   xml.Name        `xml:"testsuite"`
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Errors    int             `xml:"errors,attr"`
+	Time      string          `xml:"time,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+	elapsed   time.Duration
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Error     *junitMessage `xml:"error,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr,omitempty"`
+	Text    string `xml:",chardata"`
+}
+
+// NewJUnitTestSuite returns an empty test suite for the test cases of the integration version,
+// named <integration>/<version>
+func NewJUnitTestSuite(name string, version string) *JUnitTestSuite {
+	return &JUnitTestSuite{
+		Name: name + "/" + version,
+	}
+}
+
+// AddTestCase records the result of a test case execution. testBody is the response
+// of ExecuteTestCase and execErr is any error raised before an execution result was available
+func (s *JUnitTestSuite) AddTestCase(displayName string, elapsed time.Duration, testBody []byte, execErr error) {
+	tc := junitTestCase{
+		Name:      displayName,
+		ClassName: s.Name,
+		Time:      formatSeconds(elapsed),
+	}
+
+	s.Tests++
+	s.elapsed += elapsed
+	s.Time = formatSeconds(s.elapsed)
+
+	if execErr != nil {
+		s.Errors++
+		tc.Error = &junitMessage{Message: execErr.Error()}
+		s.TestCases = append(s.TestCases, tc)
+		return
+	}
+
+	tr := testCaseResponse{}
+	if err := json.Unmarshal(testBody, &tr); err != nil {
+		s.Errors++
+		tc.Error = &junitMessage{Message: err.Error()}
+		s.TestCases = append(s.TestCases, tc)
+		return
+	}
+
+	if tr.TestExecutionState != "PASSED" {
+		s.Failures++
+		var failures []string
+		for _, ar := range tr.AssertionResults {
+			if ar.Status == "SUCCEEDED" {
+				continue
+			}
+			failures = append(failures, fmt.Sprintf("task %s (%s): %s %s",
+				ar.TaskName, ar.TaskNumber, ar.Status, ar.FailureMessage))
+		}
+		tc.Failure = &junitMessage{
+			Message: fmt.Sprintf("test failed with %d assertions", len(tr.AssertionResults)),
+			Type:    tr.TestExecutionState,
+			Text:    strings.Join(failures, "\n"),
+		}
+	}
+	s.TestCases = append(s.TestCases, tc)
+}
+
+// Write marshals the test suite and writes it to filePath
+func (s *JUnitTestSuite) Write(filePath string) (err error) {
+	payload, err := xml.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	payload = append([]byte(xml.Header), payload...)
+	return apiclient.WriteByteArrayToFile(filePath, false, payload)
+}
+
+func formatSeconds(d time.Duration) string {
+	return strconv.FormatFloat(d.Seconds(), 'f', 3, 64)
+}
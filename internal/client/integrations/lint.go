@@ -0,0 +1,240 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integrations
+
+import (
+	"encoding/json"
+	"fmt"
+	"internal/apiclient"
+	"internal/client/authconfigs"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// config variables are referenced as $`CONFIG_name`$ in the task parameters
+var rConfigVarReference = regexp.MustCompile("\\$`(CONFIG_[^`]+)`\\$")
+
+// Lint checks an integration version for duplicate task ids, undeclared config variables,
+// JavaScript tasks without a code file and authconfigs that are not in the region.
+// The code files are checked only when the javascript folder exists and the authconfigs
+// only when checkAuthConfigs is set
+func Lint(content []byte, javascriptFolder string, checkAuthConfigs bool) (findings []string, err error) {
+	var authConfigNames map[string]bool
+
+	if checkAuthConfigs {
+		apiclient.ClientPrintHttpResponse.Set(false)
+		defer apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting())
+
+		respBody, err := authconfigs.ListAll("")
+		if err != nil {
+			return nil, err
+		}
+		summaries, err := authconfigs.GetSummaries(respBody)
+		if err != nil {
+			return nil, err
+		}
+		authConfigNames = map[string]bool{}
+		for _, s := range summaries {
+			authConfigNames[s.Id] = true
+			authConfigNames[s.DisplayName] = true
+		}
+	}
+
+	if stat, err := os.Stat(javascriptFolder); err != nil || !stat.IsDir() {
+		javascriptFolder = ""
+	}
+	return lintVersion(content, javascriptFolder, authConfigNames)
+}
+
+// lintVersion returns the findings for the integration version. An empty javascript folder
+// or nil authconfig names skip the corresponding checks
+func lintVersion(content []byte, javascriptFolder string, authConfigNames map[string]bool) (findings []string, err error) {
+	iversion := integrationVersion{}
+	if err = json.Unmarshal(content, &iversion); err != nil {
+		return nil, err
+	}
+
+	taskIds := map[string]int{}
+	for _, taskConfig := range iversion.TaskConfigs {
+		taskIds[taskConfig.TaskId]++
+	}
+	for _, taskId := range sortedIds(taskIds, nil) {
+		if taskIds[taskId] > 1 {
+			findings = append(findings, fmt.Sprintf("task id %s is used by %d tasks", taskId, taskIds[taskId]))
+		}
+	}
+
+	declared := map[string]bool{}
+	for _, c := range iversion.IntegrationConfigParameters {
+		declared[strings.Trim(c.Parameter.Key, "`")] = true
+	}
+	reported := map[string]bool{}
+	for _, match := range rConfigVarReference.FindAllStringSubmatch(string(content), -1) {
+		if !declared[match[1]] && !reported[match[1]] {
+			reported[match[1]] = true
+			findings = append(findings, fmt.Sprintf("config variable %s is referenced but not declared", match[1]))
+		}
+	}
+
+	for _, taskConfig := range iversion.TaskConfigs {
+		if javascriptFolder != "" && taskConfig.Task == "JavaScriptTask" {
+			codeFile := "javascript_" + taskConfig.TaskId + ".js"
+			if _, err := os.Stat(path.Join(javascriptFolder, codeFile)); err != nil {
+				findings = append(findings, fmt.Sprintf("task %s has no code file %s", taskConfig.TaskId, codeFile))
+			}
+		}
+		if authConfigNames == nil {
+			continue
+		}
+		if p, ok := taskConfig.Parameters["authConfig"]; ok && p.Value.JsonValue != nil {
+			if id := getAuthConfigUuid(*p.Value.JsonValue); id != "" && !authConfigNames[id] {
+				findings = append(findings, fmt.Sprintf("task %s references authconfig %s, which is not in the region",
+					taskConfig.TaskId, id))
+			}
+		}
+		if p, ok := taskConfig.Parameters["authConfigName"]; ok && p.Value.StringValue != nil {
+			if name := *p.Value.StringValue; name != "" && !authConfigNames[name] {
+				findings = append(findings, fmt.Sprintf("task %s references authconfig %s, which is not in the region",
+					taskConfig.TaskId, name))
+			}
+		}
+	}
+	return findings, nil
+}
+
+// CheckConfigVariables compares the config variables file with the integration version. unused are
+// the variables of the file that the integration neither declares nor references, missing are the
+// variables the integration declares or references that have no value in the file, in the version
+// or as a default value
+func CheckConfigVariables(content []byte, configVars []byte) (unused []string, missing []string, err error) {
+	iversion := integrationVersion{}
+	if err = json.Unmarshal(content, &iversion); err != nil {
+		return nil, nil, err
+	}
+	values := map[string]interface{}{}
+	if len(configVars) > 0 {
+		if err = json.Unmarshal(configVars, &values); err != nil {
+			return nil, nil, fmt.Errorf("invalid config variables: %w", err)
+		}
+	}
+	provided := map[string]bool{}
+	for key := range values {
+		provided[strings.Trim(key, "`")] = true
+	}
+
+	// the value is true when the integration has a value for the variable
+	known := map[string]bool{}
+	for _, c := range iversion.IntegrationConfigParameters {
+		known[strings.Trim(c.Parameter.Key, "`")] = c.Value != nil || c.Parameter.DefaultValue != nil
+	}
+	for _, match := range rConfigVarReference.FindAllStringSubmatch(string(content), -1) {
+		if _, ok := known[match[1]]; !ok {
+			known[match[1]] = false
+		}
+	}
+
+	for _, key := range sortedIds(provided, nil) {
+		if _, ok := known[key]; !ok {
+			unused = append(unused, key)
+		}
+	}
+	for _, key := range sortedIds(known, nil) {
+		if !known[key] && !provided[key] {
+			missing = append(missing, key)
+		}
+	}
+	return unused, missing, nil
+}
+
+// codeFiles describes the code files of the JavaScript and Jsonnet tasks, see GetCode
+var codeFiles = []struct {
+	taskType, prefix, ext string
+}{
+	{"JavaScriptTask", "javascript_", ".js"},
+	{"JsonnetMapperTask", "datatransformer_", ".jsonnet"},
+}
+
+// LintCodeFiles checks the code files of the integration version match its JavaScript and Jsonnet
+// tasks: each task has a code file and each code file has a task. A folder that does not exist is
+// not checked, the code is then in the integration version
+func LintCodeFiles(content []byte, javascriptFolder string, jsonnetFolder string) (findings []string, err error) {
+	iversion := integrationVersion{}
+	if err = json.Unmarshal(content, &iversion); err != nil {
+		return nil, err
+	}
+
+	for i, folder := range []string{javascriptFolder, jsonnetFolder} {
+		c := codeFiles[i]
+		entries, err := os.ReadDir(folder)
+		if err != nil {
+			continue
+		}
+		taskIds := map[string]bool{}
+		for _, taskConfig := range iversion.TaskConfigs {
+			if taskConfig.Task != c.taskType {
+				continue
+			}
+			taskIds[taskConfig.TaskId] = true
+			codeFile := c.prefix + taskConfig.TaskId + c.ext
+			if _, err := os.Stat(path.Join(folder, codeFile)); err != nil {
+				findings = append(findings, fmt.Sprintf("task %s has no code file %s", taskConfig.TaskId, codeFile))
+			}
+		}
+		for _, entry := range entries {
+			taskId, ok := strings.CutPrefix(strings.TrimSuffix(entry.Name(), c.ext), c.prefix)
+			if entry.IsDir() || !ok || !strings.HasSuffix(entry.Name(), c.ext) {
+				continue
+			}
+			if !taskIds[taskId] {
+				findings = append(findings, fmt.Sprintf("code file %s does not belong to a %s", entry.Name(), c.taskType))
+			}
+		}
+	}
+	return findings, nil
+}
+
+// GetUndeclaredConfigVariables returns the config variables referenced by the overrides that the
+// integration version does not declare
+func GetUndeclaredConfigVariables(content []byte, overrides []byte) (undeclared []string, err error) {
+	iversion := integrationVersion{}
+	if err = json.Unmarshal(content, &iversion); err != nil {
+		return nil, err
+	}
+	declared := map[string]bool{}
+	for _, c := range iversion.IntegrationConfigParameters {
+		declared[strings.Trim(c.Parameter.Key, "`")] = true
+	}
+	referenced := map[string]bool{}
+	for _, match := range rConfigVarReference.FindAllStringSubmatch(string(overrides), -1) {
+		if !declared[match[1]] {
+			referenced[match[1]] = true
+		}
+	}
+	return sortedIds(referenced, nil), nil
+}
+
+// CheckJSON returns an error naming the first field of the integration version that is not
+// part of the known schema. Such fields are dropped when the version is created
+func CheckJSON(content []byte) error {
+	return apiclient.UnmarshalStrict(content, &integrationVersion{})
+}
+
+// CheckOverridesJSON returns an error naming the first field of the overrides that is not
+// part of the known schema. Such fields are ignored when the overrides are applied
+func CheckOverridesJSON(content []byte) error {
+	return apiclient.UnmarshalStrict(content, &overrides{})
+}
@@ -87,6 +87,70 @@ const authConfigValue = "{  \"@type\": \"type.googleapis.com/enterprise.crm.even
 
 const configVarPrefix = "$`CONFIG_"
 
+// GetIAMChecks returns the IAM permissions the caller needs to grant trigger
+// service accounts access when the overrides are applied
+func GetIAMChecks(overridesContent []byte) (checks []apiclient.IAMCheck, err error) {
+	o := overrides{}
+	if err = json.Unmarshal(overridesContent, &o); err != nil {
+		return nil, err
+	}
+
+	for _, triggerOverride := range o.TriggerOverrides {
+		if triggerOverride.ProjectId == nil {
+			continue
+		}
+		if triggerOverride.ServiceAccount != nil && strings.HasPrefix(*triggerOverride.ServiceAccount, configVarPrefix) {
+			continue
+		}
+		if triggerOverride.TopicName != nil {
+			checks = append(checks, apiclient.ProjectIAMCheck(*triggerOverride.ProjectId,
+				"resourcemanager.projects.setIamPolicy"))
+		}
+	}
+	return checks, nil
+}
+
+// ApplyOverrides merges the overrides into the integration the same way CreateVersion does
+// and returns the resulting integration. It makes API calls: connection overrides and the
+// default service account of pubsub triggers are looked up in the project
+func ApplyOverrides(integration []byte, overridesContent []byte) (integrationBytes []byte, err error) {
+	iversion := integrationVersion{}
+	if err = json.Unmarshal(integration, &iversion); err != nil {
+		return nil, err
+	}
+
+	eversion := convertInternalToExternal(iversion)
+	if eversion, err = applyOverrides(eversion, overridesContent, false); err != nil {
+		return nil, err
+	}
+	return json.Marshal(eversion)
+}
+
+func applyOverrides(eversion integrationVersionExternal, overridesContent []byte,
+	grantPermission bool,
+) (integrationVersionExternal, error) {
+	if len(overridesContent) == 0 {
+		return eversion, nil
+	}
+
+	o := overrides{
+		IntegrationOverrides: integrationoverrides{
+			RunAsServiceAccount:       nil,
+			DatabasePersistencePolicy: "DATABASE_PERSISTENCE_POLICY_UNSPECIFIED",
+			EnableVariableMasking:     false,
+			CloudLoggingDetails: cloudLoggingDetails{
+				EnableCloudLogging:   false,
+				CloudLoggingSeverity: "CLOUD_LOGGING_SEVERITY_UNSPECIFIED",
+			},
+		},
+	}
+
+	if err := json.Unmarshal(overridesContent, &o); err != nil {
+		return eversion, err
+	}
+	return mergeOverrides(eversion, o, grantPermission)
+}
+
 // mergeOverrides
 func mergeOverrides(eversion integrationVersionExternal, o overrides, grantPermission bool) (integrationVersionExternal, error) {
 	var err error
@@ -360,6 +424,7 @@ func extractOverrides(iversion integrationVersion) (overrides, error) {
 			*triggerOverride.CloudSchedulerServiceAccount = triggerConfig.CloudSchedulerConfig.ServiceAccountEmail
 			*triggerOverride.CloudSchedulerLocation = triggerConfig.CloudSchedulerConfig.Location
 			*triggerOverride.CloudSchedulerCronTab = triggerConfig.CloudSchedulerConfig.CronTab
+			triggerOverride.TriggerNumber = triggerConfig.TriggerNumber
 			taskOverrides.TriggerOverrides = append(taskOverrides.TriggerOverrides, triggerOverride)
 		case "INTEGRATION_CONNECTOR_TRIGGER":
 			triggerOverride := triggeroverrides{}
@@ -464,38 +529,46 @@ func handleGenericConnectorTask(taskConfig taskconfig, taskOverrides *overrides,
 	co.TaskId = taskConfig.TaskId
 	co.Task = taskConfig.Task
 
-	cparams, ok := taskConfig.Parameters["config"]
-	connectionNameparams, okConnectionName := taskConfig.Parameters["connectionName"]
+	_, ok := taskConfig.Parameters["config"]
+	_, okConnectionName := taskConfig.Parameters["connectionName"]
 
 	if !ok && !okConnectionName {
 		return nil
 	}
+	connectionName, err := getTaskConnectionName(taskConfig, iconfigParam)
+	if err != nil {
+		return err
+	}
+	if connectionName != "" {
+		parts := strings.Split(connectionName, "/")
+		co.Parameters.ConnectionName = parts[len(parts)-1]
+	}
+	taskOverrides.ConnectionOverrides = append(taskOverrides.ConnectionOverrides, co)
+
+	return nil
+}
+
+// getTaskConnectionName returns the connection name of a connector task, as set on custom
+// connector tasks or in the config of Google built connector tasks. It is empty when the task
+// has no connection
+func getTaskConnectionName(taskConfig taskconfig, iconfigParam []parameterConfig) (string, error) {
+	cparams, ok := taskConfig.Parameters["config"]
+	connectionNameparams := taskConfig.Parameters["connectionName"]
+
 	if connectionNameparams.Key == "connectionName" {
 		if connectionNameparams.Value.StringValue != nil {
-			connectionName, err := getConnectionStringFromConnectionName(*connectionNameparams.Value.StringValue, iconfigParam)
-			if err != nil {
-				return err
-			}
-			parts := strings.Split(connectionName, "/")
-			connName := parts[len(parts)-1]
-			co.Parameters.ConnectionName = connName
-
+			return getConnectionStringFromConnectionName(*connectionNameparams.Value.StringValue, iconfigParam)
 		}
 	} else if (eventparameter{}) != cparams && ok {
 		if cparams.Value.JsonValue != nil {
 			cd, err := getConnectionDetails(*cparams.Value.JsonValue)
 			if err != nil {
-				return err
+				return "", err
 			}
-
-			parts := strings.Split(cd.Connection.ConnectionName, "/")
-			connName := parts[len(parts)-1]
-			co.Parameters.ConnectionName = connName
+			return cd.Connection.ConnectionName, nil
 		}
 	}
-	taskOverrides.ConnectionOverrides = append(taskOverrides.ConnectionOverrides, co)
-
-	return nil
+	return "", nil
 }
 
 // overrideParameters
@@ -527,25 +600,20 @@ func overrideParameters(overrideParameters map[string]eventparameter,
 func getNewConnectionParams(connectionName string, connectionLocation string) (cp connectionparams, err error) {
 	cp = connectionparams{}
 	var connectionVersionResponse map[string]interface{}
-	var integrationRegion string
 
 	apiclient.ClientPrintHttpResponse.Set(false)
 	defer apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting())
 
+	// the connection is in the connectors project and region, unless the location is overridden
+	restore := apiclient.UseConnectorContext()
 	if connectionLocation != "" {
-		integrationRegion = apiclient.GetRegion()     // store the integration location
-		err = apiclient.SetRegion(connectionLocation) // set the connector region
-		if err != nil {
+		if err = apiclient.SetRegion(connectionLocation); err != nil {
+			restore()
 			return cp, err
 		}
 	}
 	connResp, err := connections.Get(connectionName, "BASIC", false, false) // get connector details
-	if connectionLocation != "" {
-		err = apiclient.SetRegion(integrationRegion) // set the integration region back
-		if err != nil {
-			return cp, err
-		}
-	}
+	restore()
 	if err != nil {
 		return cp, err
 	}
@@ -602,7 +670,10 @@ func getConnectionStringFromConnectionName(connectionName string, iconfigParam [
 	return name, nil
 }
 
-func ExtractCode(content []byte) (codeMap map[string]map[string]string, err error) {
+// GetCode returns the JavaScript and Jsonnet code of the integration keyed by task type and task id.
+// It is the inverse of SetCode. The code is returned as decoded from the JSON document, so escape
+// sequences inside the code, such as \n in a string literal, are kept as written
+func GetCode(content []byte) (codeMap map[string]map[string]string, err error) {
 	codeMap = make(map[string]map[string]string)
 	codeMap["JavaScriptTask"] = make(map[string]string)
 	codeMap["JsonnetMapperTask"] = make(map[string]string)
@@ -611,31 +682,72 @@ func ExtractCode(content []byte) (codeMap map[string]map[string]string, err erro
 		return nil, err
 	}
 	for _, task := range iversion.TaskConfigs {
+		var param eventparameter
+		var found bool
 		if task.Task == "JavaScriptTask" {
-			codeMap[task.Task][task.TaskId] = *task.Parameters["script"].Value.StringValue
+			param, found = task.Parameters["script"]
 		} else if task.Task == "JsonnetMapperTask" {
-			codeMap[task.Task][task.TaskId] = *task.Parameters["template"].Value.StringValue
+			param, found = task.Parameters["template"]
+		}
+		if found && param.Value.StringValue != nil {
+			codeMap[task.Task][task.TaskId] = *param.Value.StringValue
 		}
 	}
 	return codeMap, nil
 }
 
+// RemoveCode clears the JavaScript and Jsonnet code of the integration. Unlike SetCode, it works
+// on the raw API document so any fields not modeled by the toolkit are preserved
+func RemoveCode(content []byte) (integrationBytes []byte, err error) {
+	var iversion map[string]interface{}
+	if err = json.Unmarshal(content, &iversion); err != nil {
+		return nil, err
+	}
+	taskConfigs, _ := iversion["taskConfigs"].([]interface{})
+	for _, t := range taskConfigs {
+		task, _ := t.(map[string]interface{})
+		var key string
+		switch task["task"] {
+		case "JavaScriptTask":
+			key = "script"
+		case "JsonnetMapperTask":
+			key = "template"
+		default:
+			continue
+		}
+		parameters, _ := task["parameters"].(map[string]interface{})
+		parameter, _ := parameters[key].(map[string]interface{})
+		if value, ok := parameter["value"].(map[string]interface{}); ok {
+			value["stringValue"] = ""
+		}
+	}
+	return json.Marshal(iversion)
+}
+
 func SetCode(content []byte, codeMap map[string]map[string]string) (integrationBytes []byte, err error) {
 	iversion := integrationVersion{}
 	if err = json.Unmarshal(content, &iversion); err != nil {
 		return nil, err
 	}
-	for _, task := range iversion.TaskConfigs {
+	for i, task := range iversion.TaskConfigs {
 		content := codeMap[task.Task][task.TaskId]
+		var key string
 		if task.Task == "JavaScriptTask" {
-			if content != "" {
-				*task.Parameters["script"].Value.StringValue = strings.ReplaceAll(content, "\\n", "\n")
-			}
+			key = "script"
 		} else if task.Task == "JsonnetMapperTask" {
-			if content != "" {
-				*task.Parameters["template"].Value.StringValue = strings.ReplaceAll(content, "\\n", "\n")
-			}
+			key = "template"
+		}
+		if key == "" || content == "" {
+			continue
+		}
+		// the code is stored as written, the JSON encoding escapes it
+		param := task.Parameters[key]
+		param.Key = key
+		param.Value.StringValue = &content
+		if task.Parameters == nil {
+			iversion.TaskConfigs[i].Parameters = map[string]eventparameter{}
 		}
+		iversion.TaskConfigs[i].Parameters[key] = param
 	}
 	return json.Marshal(iversion)
 }
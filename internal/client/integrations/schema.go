@@ -0,0 +1,180 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integrations
+
+import (
+	"encoding/json"
+	"fmt"
+	"internal/clilog"
+	"strconv"
+)
+
+const jsonSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+type parametersSchema struct {
+	Schema      string                 `json:"$schema"`
+	Title       string                 `json:"title,omitempty"`
+	Description string                 `json:"description,omitempty"`
+	Type        string                 `json:"type"`
+	Properties  map[string]interface{} `json:"properties"`
+}
+
+// parameterTypes maps integration parameter data types to JSON Schema types
+var parameterTypes = map[string]string{
+	"STRING_VALUE":  "string",
+	"INT_VALUE":     "integer",
+	"DOUBLE_VALUE":  "number",
+	"BOOLEAN_VALUE": "boolean",
+	"STRING_ARRAY":  "string",
+	"INT_ARRAY":     "integer",
+	"DOUBLE_ARRAY":  "number",
+	"BOOLEAN_ARRAY": "boolean",
+}
+
+// Schema returns a JSON Schema document describing the input and output parameters of the
+// integration version. The input and output properties hold the parameters by key, with the
+// schema of the plain parameter value. JSON parameters use the JSON schema set on the
+// parameter, if any
+func Schema(content []byte, title string) (schema []byte, err error) {
+	iversion := integrationVersion{}
+	if err = json.Unmarshal(content, &iversion); err != nil {
+		return nil, err
+	}
+
+	inputs := map[string]interface{}{}
+	outputs := map[string]interface{}{}
+	for _, p := range iversion.IntegrationParameters {
+		if p.InputOutputType != "IN" && p.InputOutputType != "OUT" && p.InputOutputType != "IN_OUT" {
+			continue
+		}
+		parameterSchema, err := getParameterSchema(p)
+		if err != nil {
+			return nil, err
+		}
+		if p.InputOutputType != "OUT" {
+			inputs[p.Key] = parameterSchema
+		}
+		if p.InputOutputType != "IN" {
+			outputs[p.Key] = parameterSchema
+		}
+	}
+
+	additionalProperties := false
+	return json.Marshal(parametersSchema{
+		Schema:      jsonSchemaDraft,
+		Title:       title,
+		Description: iversion.Description,
+		Type:        "object",
+		Properties: map[string]interface{}{
+			"input": map[string]interface{}{
+				"type":                 "object",
+				"properties":           inputs,
+				"additionalProperties": additionalProperties,
+			},
+			"output": map[string]interface{}{
+				"type":       "object",
+				"properties": outputs,
+			},
+		},
+	})
+}
+
+func getParameterSchema(p parameterExternal) (map[string]interface{}, error) {
+	parameterSchema := map[string]interface{}{}
+
+	switch p.DataType {
+	case "STRING_VALUE", "INT_VALUE", "DOUBLE_VALUE", "BOOLEAN_VALUE":
+		parameterSchema["type"] = parameterTypes[p.DataType]
+	case "STRING_ARRAY", "INT_ARRAY", "DOUBLE_ARRAY", "BOOLEAN_ARRAY":
+		parameterSchema["type"] = "array"
+		parameterSchema["items"] = map[string]interface{}{"type": parameterTypes[p.DataType]}
+	case "JSON_VALUE":
+		if p.JsonSchema != "" {
+			if err := json.Unmarshal([]byte(p.JsonSchema), &parameterSchema); err != nil {
+				return nil, fmt.Errorf("invalid json schema for parameter %s: %w", p.Key, err)
+			}
+			// the draft is only declared by the document
+			delete(parameterSchema, "$schema")
+		}
+	default:
+		clilog.Warning.Printf("Parameter %s has the unsupported data type %s, any value is allowed\n", p.Key, p.DataType)
+	}
+
+	if p.DefaultValue != nil {
+		defaultValue, err := getParameterDefault(p.DataType, *p.DefaultValue)
+		if err != nil {
+			return nil, fmt.Errorf("invalid default value for parameter %s: %w", p.Key, err)
+		}
+		if defaultValue != nil {
+			parameterSchema["default"] = defaultValue
+		}
+	}
+	return parameterSchema, nil
+}
+
+// getParameterDefault returns the plain default value of the parameter, or nil when there is none
+func getParameterDefault(dataType string, v valueType) (interface{}, error) {
+	switch dataType {
+	case "STRING_VALUE":
+		if v.StringValue != nil {
+			return *v.StringValue, nil
+		}
+	case "INT_VALUE":
+		if v.IntValue != nil {
+			return strconv.ParseInt(*v.IntValue, 10, 64)
+		}
+	case "DOUBLE_VALUE":
+		if v.DoubleValue != nil {
+			return *v.DoubleValue, nil
+		}
+	case "BOOLEAN_VALUE":
+		if v.BooleanValue != nil {
+			return *v.BooleanValue, nil
+		}
+	case "JSON_VALUE":
+		if v.JsonValue != nil {
+			var jsonValue interface{}
+			if err := json.Unmarshal([]byte(*v.JsonValue), &jsonValue); err != nil {
+				return nil, err
+			}
+			return jsonValue, nil
+		}
+	case "STRING_ARRAY":
+		if v.StringArray != nil {
+			return v.StringArray.StringValues, nil
+		}
+	case "INT_ARRAY":
+		if v.IntArray != nil {
+			intValues := make([]int64, 0, len(v.IntArray.IntValues))
+			for _, s := range v.IntArray.IntValues {
+				i, err := strconv.ParseInt(s, 10, 64)
+				if err != nil {
+					return nil, err
+				}
+				intValues = append(intValues, i)
+			}
+			return intValues, nil
+		}
+	case "DOUBLE_ARRAY":
+		if v.DoubleArray != nil {
+			return v.DoubleArray.DoubleValues, nil
+		}
+	case "BOOLEAN_ARRAY":
+		if v.BooleanArray != nil {
+			return v.BooleanArray.BooleanValues, nil
+		}
+	}
+	return nil, nil
+}
@@ -15,12 +15,34 @@
 package integrations
 
 import (
+	"encoding/json"
+	"fmt"
 	"internal/apiclient"
 	"net/url"
 	"path"
 	"strconv"
+	"strings"
 )
 
+type suspension struct {
+	Name  string `json:"name,omitempty"`
+	State string `json:"state,omitempty"`
+}
+
+type listSuspensions struct {
+	Suspensions   []suspension `json:"suspensions,omitempty"`
+	NextPageToken string       `json:"nextPageToken,omitempty"`
+}
+
+// suspensionStates maps the resolve actions to the state of the suspension
+var suspensionStates = map[string]string{
+	"LIFT":  "LIFTED",
+	"RAISE": "REJECTED",
+}
+
+// sendRequest sends the suspension requests; it is a variable to replace the API in tests
+var sendRequest = apiclient.HttpClient
+
 // List all suspensions
 func ListSuspensions(name string, execution string, pageSize int, pageToken string, filter string, orderBy string) (respBody []byte, err error) {
 	u, _ := url.Parse(apiclient.GetBaseIntegrationURL())
@@ -40,7 +62,7 @@ func ListSuspensions(name string, execution string, pageSize int, pageToken stri
 
 	u.RawQuery = q.Encode()
 	u.Path = path.Join(u.Path, "integrations", name, "executions", execution, "suspensions")
-	respBody, err = apiclient.HttpClient(u.String())
+	respBody, err = sendRequest(u.String())
 	return respBody, err
 }
 
@@ -49,11 +71,58 @@ func Lift(name string, execution string, suspension string, result string) (resp
 	u, _ := url.Parse(apiclient.GetBaseIntegrationURL())
 	u.Path = path.Join(u.Path, "integrations", name, "executions", execution, "suspensions", suspension, ":lift")
 	payload := "{ \"suspension_result\":\"" + result + "\"}"
-	respBody, err = apiclient.HttpClient(u.String(), payload)
+	respBody, err = sendRequest(u.String(), payload)
+	return respBody, err
+}
+
+// Resolve a suspension. LIFT resumes the execution, RAISE rejects the suspension
+// and fails the suspended task
+func Resolve(name string, execution string, suspensionId string, action string) (respBody []byte, err error) {
+	state, ok := suspensionStates[strings.ToUpper(action)]
+	if !ok {
+		return nil, fmt.Errorf("invalid action %s, must be one of LIFT or RAISE", action)
+	}
+	u, _ := url.Parse(apiclient.GetBaseIntegrationURL())
+	u.Path = path.Join(u.Path, "integrations", name, "executions", execution, "suspensions", suspensionId)
+	payload, err := json.Marshal(map[string]suspension{
+		"suspension": {
+			Name:  strings.TrimPrefix(u.Path, "/v1/"),
+			State: state,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	u.Path += ":resolve"
+	respBody, err = sendRequest(u.String(), string(payload))
 	return respBody, err
 }
 
-// Resolve one or more suspensions
-func Resolve(name string, suspensions string) (respBody []byte, err error) {
-	return nil, nil
+// ListPendingSuspensions returns the ids of the suspensions of an execution that are
+// waiting to be resolved
+func ListPendingSuspensions(name string, execution string) (ids []string, err error) {
+	var respBody []byte
+	pageToken := ""
+
+	apiclient.ClientPrintHttpResponse.Set(false)
+	defer apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting())
+
+	for {
+		if respBody, err = ListSuspensions(name, execution, -1, pageToken, "", ""); err != nil {
+			return nil, err
+		}
+		l := listSuspensions{}
+		if err = json.Unmarshal(respBody, &l); err != nil {
+			return nil, err
+		}
+		for _, s := range l.Suspensions {
+			if s.State == "PENDING" {
+				ids = append(ids, path.Base(s.Name))
+			}
+		}
+		if l.NextPageToken == "" {
+			return ids, nil
+		}
+		pageToken = l.NextPageToken
+	}
 }
@@ -19,9 +19,11 @@ import (
 	"fmt"
 	"internal/apiclient"
 	"net/url"
+	"os"
 	"path"
 	"path/filepath"
 	"strconv"
+	"strings"
 )
 
 type testCase struct {
@@ -40,6 +42,11 @@ type listTestCases struct {
 	NextPageToken string     `json:"nextPageToken,omitempty"`
 }
 
+type testCaseSummary struct {
+	TestCaseId  string `json:"testCaseId,omitempty"`
+	DisplayName string `json:"displayName,omitempty"`
+}
+
 type testTaskConfig struct {
 	TaskNumber string      `json:"taskNumber,omitempty"`
 	Task       string      `json:"task,omitempty"`
@@ -62,10 +69,10 @@ type mockConfig struct {
 }
 
 type testCaseResponse struct {
-	ExecutionId        string            `json:"executionId,omitempty`
-	OutputParameters   interface{}       `json:"outputParameters,omitempty`
-	AssertionResults   []assertionResult `json:"assertionResults,omitempty`
-	TestExecutionState string            `json:"testExecutionState,omitempty`
+	ExecutionId        string            `json:"executionId,omitempty"`
+	OutputParameters   interface{}       `json:"outputParameters,omitempty"`
+	AssertionResults   []assertionResult `json:"assertionResults,omitempty"`
+	TestExecutionState string            `json:"testExecutionState,omitempty"`
 }
 
 type assertionResult struct {
@@ -213,10 +220,16 @@ func FindTestCase(name string, integrationVersion string, displayName string, pa
 }
 
 func ListAllTestCases(name string, version string) (respBody []byte, err error) {
+	return ListAllTestCasesByFilter(name, version, "", "")
+}
+
+// ListAllTestCasesByFilter returns every page of the test cases matching the filter, in the order
+// of orderBy, as a single list response
+func ListAllTestCasesByFilter(name string, version string, filter string, orderBy string) (respBody []byte, err error) {
 	l := listTestCases{}
 	for {
 		newltc := listTestCases{}
-		respBody, err = ListTestCases(name, version, true, "", -1, "", "")
+		respBody, err = ListTestCases(name, version, true, filter, -1, l.NextPageToken, orderBy)
 		if err != nil {
 			return nil, err
 		}
@@ -230,8 +243,10 @@ func ListAllTestCases(name string, version string) (respBody []byte, err error)
 		if newltc.NextPageToken == "" {
 			break
 		}
+		l.NextPageToken = newltc.NextPageToken
 	}
 
+	l.NextPageToken = ""
 	respBody, err = json.Marshal(l)
 
 	return respBody, err
@@ -259,6 +274,198 @@ func DeleteAllTestCases(name string, version string) (err error) {
 	return nil
 }
 
+// GetTestCaseSummary returns the test case ids and display names from a list response
+func GetTestCaseSummary(respBody []byte) (rb []byte, err error) {
+	l := listTestCases{}
+	if err = json.Unmarshal(respBody, &l); err != nil {
+		return nil, err
+	}
+	summary := []testCaseSummary{}
+	for _, tc := range l.TestCases {
+		summary = append(summary, testCaseSummary{
+			TestCaseId:  filepath.Base(tc.Name),
+			DisplayName: tc.DisplayName,
+		})
+	}
+	return json.Marshal(summary)
+}
+
+// GetTestCaseIDs returns the test case ids of a list response keyed by display name.
+// When display names repeat, the first test case is kept
+func GetTestCaseIDs(respBody []byte) (ids map[string]string, err error) {
+	l := listTestCases{}
+	if err = json.Unmarshal(respBody, &l); err != nil {
+		return nil, err
+	}
+	ids = make(map[string]string, len(l.TestCases))
+	for _, tc := range l.TestCases {
+		if _, ok := ids[tc.DisplayName]; !ok {
+			ids[tc.DisplayName] = filepath.Base(tc.Name)
+		}
+	}
+	return ids, nil
+}
+
+// ExportTestCases writes the execute input of each test case of the integration version to folder.
+// The files are named after the test case display name and hold the test input parameters, so the
+// folder can be used with execute --input-folder
+func ExportTestCases(name string, version string, folder string) (err error) {
+	apiclient.ClientPrintHttpResponse.Set(false)
+	defer apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting())
+
+	respBody, err := ListAllTestCases(name, version)
+	if err != nil {
+		return err
+	}
+	files, err := GetTestCaseFiles(respBody)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(folder, 0o755); err != nil {
+		return err
+	}
+	for fileName, content := range files {
+		if err = apiclient.WriteByteArrayToFile(path.Join(folder, fileName), false, content); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetTestCaseFiles returns the execute input of each test case of a list response keyed by
+// file name. Test input parameters without a default value are left out
+func GetTestCaseFiles(respBody []byte) (files map[string][]byte, err error) {
+	l := listTestCases{}
+	if err = json.Unmarshal(respBody, &l); err != nil {
+		return nil, err
+	}
+	files = make(map[string][]byte, len(l.TestCases))
+	for _, tc := range l.TestCases {
+		if tc.DisplayName == "" {
+			return nil, fmt.Errorf("test case %s has no display name", filepath.Base(tc.Name))
+		}
+		if strings.ContainsAny(tc.DisplayName, `/\`) {
+			return nil, fmt.Errorf("test case display name %q cannot be used as a file name", tc.DisplayName)
+		}
+		fileName := tc.DisplayName + ".json"
+		if _, ok := files[fileName]; ok {
+			return nil, fmt.Errorf("two or more test cases have the display name %q", tc.DisplayName)
+		}
+		inputParameters := map[string]json.RawMessage{}
+		for _, p := range tc.TestInputParameters {
+			if p.DefaultValue == nil {
+				continue
+			}
+			value, err := json.Marshal(p.DefaultValue)
+			if err != nil {
+				return nil, err
+			}
+			if string(value) != "{}" {
+				inputParameters[p.Key] = value
+			}
+		}
+		content, err := json.MarshalIndent(map[string]interface{}{"inputParameters": inputParameters}, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		files[fileName] = content
+	}
+	return files, nil
+}
+
+// valueFields maps integration parameter data types to the field holding the value
+var valueFields = map[string]string{
+	"STRING_VALUE":  "stringValue",
+	"INT_VALUE":     "intValue",
+	"DOUBLE_VALUE":  "doubleValue",
+	"BOOLEAN_VALUE": "booleanValue",
+	"JSON_VALUE":    "jsonValue",
+	"STRING_ARRAY":  "stringArray",
+	"INT_ARRAY":     "intArray",
+	"DOUBLE_ARRAY":  "doubleArray",
+	"BOOLEAN_ARRAY": "booleanArray",
+}
+
+// ValidateTestInput checks content is a well formed test case execution request.
+// When integrationBody is set, each input parameter must also be an input of the
+// integration version with a value of the parameter data type
+func ValidateTestInput(content []byte, integrationBody []byte) error {
+	input := map[string]json.RawMessage{}
+	if err := json.Unmarshal(content, &input); err != nil {
+		return fmt.Errorf("input is not a valid json object: %w", err)
+	}
+
+	inputParameters := map[string]map[string]json.RawMessage{}
+	if raw, ok := input["inputParameters"]; ok {
+		if err := json.Unmarshal(raw, &inputParameters); err != nil {
+			return fmt.Errorf("inputParameters must be an object of parameter values: %w", err)
+		}
+	}
+
+	for key, value := range inputParameters {
+		if len(value) != 1 {
+			return fmt.Errorf("input parameter %s must have exactly one value field", key)
+		}
+		for field := range value {
+			if !isValueField(field) {
+				return fmt.Errorf("input parameter %s has an unknown value field %s", key, field)
+			}
+		}
+	}
+
+	if len(integrationBody) == 0 {
+		return nil
+	}
+
+	iversion := integrationVersionExternal{}
+	if err := json.Unmarshal(integrationBody, &iversion); err != nil {
+		return err
+	}
+	params := map[string]parameterExternal{}
+	for _, p := range iversion.IntegrationParameters {
+		params[p.Key] = p
+	}
+
+	for key, value := range inputParameters {
+		p, ok := params[key]
+		if !ok {
+			return fmt.Errorf("input parameter %s is not defined in the integration", key)
+		}
+		if p.InputOutputType != "IN" && p.InputOutputType != "IN_OUT" {
+			return fmt.Errorf("input parameter %s is not an input of the integration", key)
+		}
+		field, ok := valueFields[p.DataType]
+		if !ok {
+			continue
+		}
+		if _, ok = value[field]; !ok {
+			return fmt.Errorf("input parameter %s must set %s for data type %s", key, field, p.DataType)
+		}
+	}
+	return nil
+}
+
+func isValueField(field string) bool {
+	for _, f := range valueFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// GetTestCaseID returns the test case id and display name from a test case response
+func GetTestCaseID(respBody []byte) (testCaseID string, displayName string, err error) {
+	tc := testCase{}
+	if err = json.Unmarshal(respBody, &tc); err != nil {
+		return "", "", err
+	}
+	if tc.Name == "" {
+		return "", "", fmt.Errorf("test case name not found in response")
+	}
+	return filepath.Base(tc.Name), tc.DisplayName, nil
+}
+
 func getTestCaseIntegrationVersion(name string, snapshot string, userLabel string) (version string, err error) {
 
 	var iversionBytes []byte
@@ -0,0 +1,143 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integrations
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testInputIntegration = `{
+	"integrationParameters": [
+		{"key": "name", "dataType": "STRING_VALUE", "inputOutputType": "IN"},
+		{"key": "count", "dataType": "INT_VALUE", "inputOutputType": "IN_OUT"},
+		{"key": "result", "dataType": "JSON_VALUE", "inputOutputType": "OUT"}
+	]
+}`
+
+func TestValidateTestInput(t *testing.T) {
+	tests := []struct {
+		name            string
+		content         string
+		integrationBody string
+		errText         string
+	}{
+		{"empty parameters", `{"inputParameters": {}}`, testInputIntegration, ""},
+		{"valid", `{"inputParameters": {"name": {"stringValue": "a"}, "count": {"intValue": "1"}}}`, testInputIntegration, ""},
+		{"no schema", `{"inputParameters": {"other": {"stringValue": "a"}}}`, "", ""},
+		{"malformed", `{"inputParameters": {`, testInputIntegration, "not a valid json"},
+		{"not an object", `{"inputParameters": []}`, testInputIntegration, "inputParameters must be an object"},
+		{"two values", `{"inputParameters": {"name": {"stringValue": "a", "intValue": "1"}}}`, "", "name must have exactly one"},
+		{"unknown field", `{"inputParameters": {"name": {"textValue": "a"}}}`, "", "unknown value field textValue"},
+		{"undefined", `{"inputParameters": {"other": {"stringValue": "a"}}}`, testInputIntegration, "other is not defined"},
+		{"output", `{"inputParameters": {"result": {"jsonValue": "{}"}}}`, testInputIntegration, "result is not an input"},
+		{"wrong type", `{"inputParameters": {"count": {"stringValue": "1"}}}`, testInputIntegration, "count must set intValue"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateTestInput([]byte(tt.content), []byte(tt.integrationBody))
+			if tt.errText == "" {
+				if err != nil {
+					t.Fatalf("ValidateTestInput failed: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errText) {
+				t.Errorf("ValidateTestInput error = %v, want it to contain %q", err, tt.errText)
+			}
+		})
+	}
+}
+
+func TestGetTestCaseFiles(t *testing.T) {
+	respBody := []byte(`{"testCases": [
+		{"name": "projects/p/locations/l/integrations/i/versions/v/testCases/1", "displayName": "happy path", "triggerId": "api_trigger/test",
+			"testInputParameters": [
+				{"key": "orderId", "dataType": "STRING_VALUE", "defaultValue": {"stringValue": "42"}},
+				{"key": "retry", "dataType": "BOOLEAN_VALUE"}
+			]},
+		{"name": "projects/p/locations/l/integrations/i/versions/v/testCases/2", "displayName": "error", "databasePersistencePolicy": ""}
+	]}`)
+
+	files, err := GetTestCaseFiles(respBody)
+	if err != nil {
+		t.Fatalf("GetTestCaseFiles() error = %v", err)
+	}
+	if len(files) != 2 || files["happy path.json"] == nil || files["error.json"] == nil {
+		t.Fatalf("GetTestCaseFiles() = %v, want happy path.json and error.json", files)
+	}
+	input := struct {
+		InputParameters map[string]map[string]string `json:"inputParameters"`
+	}{}
+	if err = json.Unmarshal(files["happy path.json"], &input); err != nil {
+		t.Fatal(err)
+	}
+	if len(input.InputParameters) != 1 || input.InputParameters["orderId"]["stringValue"] != "42" {
+		t.Errorf("GetTestCaseFiles() = %s, want the orderId input parameter", files["happy path.json"])
+	}
+	// the files are execute --input-folder inputs
+	for fileName, content := range files {
+		if err = ValidateTestInput(content, nil); err != nil {
+			t.Errorf("ValidateTestInput(%s) error = %v", fileName, err)
+		}
+	}
+
+	for _, body := range []string{
+		`{"testCases": [{"name": "testCases/1"}]}`,
+		`{"testCases": [{"name": "testCases/1", "displayName": "a/b"}]}`,
+		`{"testCases": [{"name": "testCases/1", "displayName": "a"}, {"name": "testCases/2", "displayName": "a"}]}`,
+	} {
+		if _, err = GetTestCaseFiles([]byte(body)); err == nil {
+			t.Errorf("GetTestCaseFiles(%s) succeeded, expected an error", body)
+		}
+	}
+}
+
+func TestJUnitTestSuite(t *testing.T) {
+	report := NewJUnitTestSuite("name", "v1")
+	report.AddTestCase("passes", 1500*time.Millisecond, []byte(`{"testExecutionState":"PASSED"}`), nil)
+	report.AddTestCase("fails", 500*time.Millisecond, []byte(`{"testExecutionState":"FAILED","assertionResults":[`+
+		`{"taskNumber":"1","taskName":"Map","status":"SUCCEEDED"},`+
+		`{"taskNumber":"2","taskName":"Call","status":"FAILED","failureMessage":"status is 500"}]}`), nil)
+	report.AddTestCase("errors", 0, nil, errors.New("Not found"))
+
+	reportFile := path.Join(t.TempDir(), "report.xml")
+	if err := report.Write(reportFile); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	got, err := os.ReadFile(reportFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `<?xml version="1.0" encoding="UTF-8"?>
+<testsuite name="name/v1" tests="3" failures="1" errors="1" time="2.000">
+  <testcase name="passes" classname="name/v1" time="1.500"></testcase>
+  <testcase name="fails" classname="name/v1" time="0.500">
+    <failure message="test failed with 2 assertions" type="FAILED">task Call (2): FAILED status is 500</failure>
+  </testcase>
+  <testcase name="errors" classname="name/v1" time="0.000">
+    <error message="Not found"></error>
+  </testcase>
+</testsuite>`
+	if string(got) != want {
+		t.Errorf("Write() = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integrations
+
+import (
+	"internal/apiclient"
+	"path"
+	"strings"
+	"sync"
+)
+
+// versionCache memoizes the version ids resolved from a user label or snapshot number
+// filter while it is enabled. The versions of an integration are dropped from the cache
+// when a version of the integration is created, changed or deleted
+var versionCache struct {
+	sync.Mutex
+	versions map[string]string
+}
+
+// EnableVersionCache memoizes version resolution until DisableVersionCache is called, so a
+// command like apply does not look up the same user label or snapshot more than once
+func EnableVersionCache() {
+	versionCache.Lock()
+	defer versionCache.Unlock()
+	versionCache.versions = map[string]string{}
+}
+
+// DisableVersionCache stops memoizing version resolution and clears the cache
+func DisableVersionCache() {
+	versionCache.Lock()
+	defer versionCache.Unlock()
+	versionCache.versions = nil
+}
+
+// versionCacheKey returns the cache key of the filter, the integration is prefixed so all
+// its versions can be invalidated together
+func versionCacheKey(name string, filter string) string {
+	return path.Join(apiclient.GetProjectID(), apiclient.GetRegion(), name) + "?" + filter
+}
+
+func getCachedVersion(name string, filter string) (version string, ok bool) {
+	versionCache.Lock()
+	defer versionCache.Unlock()
+	version, ok = versionCache.versions[versionCacheKey(name, filter)]
+	return version, ok
+}
+
+// cacheVersion memoizes the version of a user label or snapshot number filter. Other
+// filters, such as state=ACTIVE, change when a version is published and are not cached
+func cacheVersion(name string, filter string, version string) {
+	if !strings.HasPrefix(filter, "userLabel=") && !strings.HasPrefix(filter, "snapshotNumber=") {
+		return
+	}
+	versionCache.Lock()
+	defer versionCache.Unlock()
+	if versionCache.versions != nil {
+		versionCache.versions[versionCacheKey(name, filter)] = version
+	}
+}
+
+// invalidateVersions drops the cached versions of the integration
+func invalidateVersions(name string) {
+	versionCache.Lock()
+	defer versionCache.Unlock()
+	prefix := versionCacheKey(name, "")
+	for key := range versionCache.versions {
+		if strings.HasPrefix(key, prefix) {
+			delete(versionCache.versions, key)
+		}
+	}
+}
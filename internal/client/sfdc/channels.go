@@ -45,6 +45,12 @@ type channelExternal struct {
 	ChannelTopic string `json:"channelTopic,omitempty"`
 }
 
+// CheckChannelJSON returns an error naming the first field of the sfdc channel that is not
+// part of the known schema
+func CheckChannelJSON(content []byte) error {
+	return apiclient.UnmarshalStrict(content, &channel{})
+}
+
 // CreateChannelFromContent
 func CreateChannelFromContent(instanceVersion string, content []byte) (respBody []byte, err error) {
 	c := channel{}
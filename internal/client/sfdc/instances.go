@@ -48,6 +48,12 @@ type instanceExternal struct {
 	ServiceAuthority string   `json:"serviceAuthority,omitempty"`
 }
 
+// CheckInstanceJSON returns an error naming the first field of the sfdc instance that is not
+// part of the known schema
+func CheckInstanceJSON(content []byte) error {
+	return apiclient.UnmarshalStrict(content, &instance{})
+}
+
 // CreateInstanceFromContent
 func CreateInstanceFromContent(content []byte) (respBody []byte, err error) {
 	i := instance{}
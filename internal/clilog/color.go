@@ -0,0 +1,63 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clilog
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"os"
+)
+
+// Color is an ANSI escape sequence that sets the color of the text that follows
+type Color string
+
+const (
+	Bold   Color = "\033[1m"
+	Red    Color = "\033[31m"
+	Green  Color = "\033[32m"
+	Yellow Color = "\033[33m"
+	reset        = "\033[0m"
+)
+
+// IsTerminal returns true if the file is a terminal and not a pipe or a file
+func IsTerminal(f *os.File) bool {
+	stat, err := f.Stat()
+	return err == nil && stat.Mode()&os.ModeCharDevice != 0
+}
+
+// Colored returns a logger that writes to the same output as l in color. The output stays
+// plain when it is not a terminal or the NO_COLOR environment variable is set
+func Colored(l *log.Logger, c Color) *log.Logger {
+	f, ok := l.Writer().(*os.File)
+	if !ok || !IsTerminal(f) || os.Getenv("NO_COLOR") != "" {
+		return l
+	}
+	return log.New(colorWriter{f, c}, l.Prefix(), l.Flags())
+}
+
+// colorWriter writes each log line in color
+type colorWriter struct {
+	w io.Writer
+	c Color
+}
+
+func (cw colorWriter) Write(p []byte) (int, error) {
+	line := bytes.TrimSuffix(p, []byte("\n"))
+	if _, err := io.WriteString(cw.w, string(cw.c)+string(line)+reset+string(p[len(line):])); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
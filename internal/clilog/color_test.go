@@ -0,0 +1,34 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clilog
+
+import (
+	"bytes"
+	"log"
+	"testing"
+)
+
+func TestColored(t *testing.T) {
+	var b bytes.Buffer
+	l := log.New(&b, "", 0)
+	if Colored(l, Green) != l {
+		t.Errorf("Colored() returned a new logger for output that is not a terminal")
+	}
+
+	log.New(colorWriter{&b, Green}, "", 0).Println("created")
+	if want := string(Green) + "created" + reset + "\n"; b.String() != want {
+		t.Errorf("colorWriter wrote %q, want %q", b.String(), want)
+	}
+}
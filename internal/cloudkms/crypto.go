@@ -19,6 +19,7 @@ import (
 	"context"
 	"encoding/base64"
 	"fmt"
+	"internal/apiclient"
 
 	kms "cloud.google.com/go/kms/apiv1"
 	kmspb "google.golang.org/genproto/googleapis/cloud/kms/v1"
@@ -30,7 +31,7 @@ func EncryptSymmetric(name string, plaintext []byte) (b64CipherText string, err
 	var kmsClient *kms.KeyManagementClient
 
 	ctx := context.Background()
-	kmsClient, err = kms.NewKeyManagementClient(ctx)
+	kmsClient, err = newKMSClient(ctx)
 	if err != nil {
 		return "", err
 	}
@@ -62,7 +63,7 @@ func DecryptSymmetric(name string, b64CipherText []byte) ([]byte, error) {
 	var err error
 
 	ctx := context.Background()
-	kmsClient, err = kms.NewKeyManagementClient(ctx)
+	kmsClient, err = newKMSClient(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -88,3 +89,12 @@ func DecryptSymmetric(name string, b64CipherText []byte) ([]byte, error) {
 
 	return bytes.TrimSpace(resp.Plaintext), nil
 }
+
+// newKMSClient returns a Cloud KMS client that trusts the proxy CA certificate
+func newKMSClient(ctx context.Context) (*kms.KeyManagementClient, error) {
+	opts, err := apiclient.GRPCClientOptions()
+	if err != nil {
+		return nil, err
+	}
+	return kms.NewKeyManagementClient(ctx, opts...)
+}
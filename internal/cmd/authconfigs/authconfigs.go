@@ -30,6 +30,11 @@ var examples = []string{
 	`integrationcli authconfigs create -f samples/ac_oidc.json`,
 	`integrationcli authconfigs create -f samples/ac_authtoken.json`,
 	`integrationcli authconfigs create -e samples/b64encoded_ac.txt -k locations/$region/keyRings/$key/cryptoKeys/$cryptokey`,
+	`integrationcli authconfigs list --default-token`,
+	`integrationcli authconfigs list --filter=state=VALID --json --default-token`,
+	`integrationcli authconfigs get -n $displayName --default-token`,
+	`integrationcli authconfigs get -n $displayName --json --default-token`,
+	`integrationcli authconfigs delete -n $id --default-token`,
 }
 
 func init() {
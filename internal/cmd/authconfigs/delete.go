@@ -15,20 +15,25 @@
 package authconfigs
 
 import (
+	"fmt"
 	"internal/apiclient"
 	"internal/client/authconfigs"
+	"internal/client/integrations"
 	"internal/clilog"
 	"internal/cmd/utils"
+	"strconv"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 )
 
-// DelCmd to get integration flow
+// DelCmd to delete an authconfig
 var DelCmd = &cobra.Command{
 	Use:   "delete",
 	Short: "Delete an authconfig from a region",
-	Long:  "Delete an authconfig from a region",
+	Long: "Delete an authconfig from a region. The delete is refused if a published integration " +
+		"version references the authconfig, unless --force is set",
 	Args: func(cmd *cobra.Command, args []string) (err error) {
 		project := utils.GetStringParam(cmd.Flag("proj"))
 		region := utils.GetStringParam(cmd.Flag("reg"))
@@ -45,16 +50,43 @@ var DelCmd = &cobra.Command{
 		cmd.SilenceUsage = true
 
 		name := utils.GetStringParam(cmd.Flag("name"))
+		force, _ := strconv.ParseBool(utils.GetStringParam(cmd.Flag("force")))
+
+		if !force {
+			apiclient.DisableCmdPrintHttpResponse()
+			displayName, err := authconfigs.GetDisplayName(name)
+			if err != nil {
+				return err
+			}
+			users, err := integrations.FindAuthConfigUsers(name, displayName)
+			if err != nil {
+				return err
+			}
+			if len(users) > 0 {
+				return fmt.Errorf("authconfig %s is used by the following integrations; use --force to delete it anyway:\n%s",
+					displayName, strings.Join(users, "\n"))
+			}
+			apiclient.EnableCmdPrintHttpResponse()
+
+			if err = utils.ConfirmDelete("authconfig", displayName); err != nil {
+				return err
+			}
+		}
+
 		_, err = authconfigs.Delete(name)
 		return
 	},
+	Example: `Delete an authconfig that is not used by any integration: ` + GetExample(8),
 }
 
 func init() {
 	var name string
+	var force bool
 
 	DelCmd.Flags().StringVarP(&name, "name", "n",
-		"", "AuthConfig name")
+		"", "AuthConfig name (uuid)")
+	DelCmd.Flags().BoolVarP(&force, "force", "",
+		false, "Delete the authconfig without prompting for confirmation, even if integrations reference it; default is false")
 
 	_ = DelCmd.MarkFlagRequired("name")
 }
@@ -21,6 +21,7 @@ import (
 	"internal/clilog"
 	"internal/cmd/utils"
 	"path"
+	"strconv"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
@@ -54,9 +55,15 @@ var GetCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
 		cmd.SilenceUsage = true
 
+		var respBody []byte
+
 		name := utils.GetStringParam(cmd.Flag("name"))
 		id := utils.GetStringParam(cmd.Flag("id"))
 		minimal := utils.GetBasicInfo(cmd, "minimal")
+		jsonOutput, _ := strconv.ParseBool(utils.GetStringParam(cmd.Flag("json")))
+
+		// minimal returns a subset of the raw response
+		jsonOutput = jsonOutput || cmd.Flag("minimal").Changed
 
 		if name != "" {
 			apiclient.DisableCmdPrintHttpResponse()
@@ -65,23 +72,40 @@ var GetCmd = &cobra.Command{
 				return err
 			}
 			apiclient.EnableCmdPrintHttpResponse()
-			_, err = authconfigs.Get(path.Base(version), minimal)
+			id = path.Base(version)
+		}
+
+		if jsonOutput {
+			_, err = authconfigs.Get(id, minimal)
 			return err
 		}
 
-		_, err = authconfigs.Get(id, minimal)
+		apiclient.DisableCmdPrintHttpResponse()
+		if respBody, err = authconfigs.Get(id, false); err != nil {
+			return err
+		}
+		apiclient.EnableCmdPrintHttpResponse()
 
-		return err
+		summary, err := authconfigs.GetSummary(respBody)
+		if err != nil {
+			return err
+		}
+		return printSummaries([]authconfigs.Summary{summary})
 	},
+	Example: `Get an authconfig by display name: ` + GetExample(6) + `
+Get the raw authconfig: ` + GetExample(7),
 }
 
 func init() {
 	var name, id, minimal string
+	var jsonOutput bool
 
 	GetCmd.Flags().StringVarP(&id, "id", "i",
 		"", "Authconfig name (uuid)")
 	GetCmd.Flags().StringVarP(&name, "name", "n",
 		"", "Authconfig display name")
 	GetCmd.Flags().StringVarP(&minimal, "minimal", "",
-		"", "Minimal number of fields returned; default is false")
+		"", "Minimal number of fields returned; implies --json; default is false")
+	GetCmd.Flags().BoolVarP(&jsonOutput, "json", "",
+		false, "Print the raw response; default is false")
 }
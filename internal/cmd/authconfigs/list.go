@@ -15,20 +15,24 @@
 package authconfigs
 
 import (
+	"fmt"
 	"internal/apiclient"
 	"internal/client/authconfigs"
 	"internal/clilog"
 	"internal/cmd/utils"
+	"strconv"
+	"text/tabwriter"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 )
 
-// ListCmd to list Integrations
+// ListCmd to list authconfigs
 var ListCmd = &cobra.Command{
 	Use:   "list",
-	Short: "List all integration flows in the region",
-	Long:  "List all integration flows in the region",
+	Short: "List all authconfigs in the region",
+	Long: "List all authconfigs in the region with their display name, type and state. " +
+		"All pages are returned unless a page size or page token is set",
 	Args: func(cmd *cobra.Command, args []string) (err error) {
 		project := utils.GetStringParam(cmd.Flag("proj"))
 		region := utils.GetStringParam(cmd.Flag("reg"))
@@ -44,18 +48,44 @@ var ListCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
 		cmd.SilenceUsage = true
 
+		var respBody []byte
+
 		pageToken := utils.GetStringParam(cmd.Flag("pageToken"))
 		filter := utils.GetStringParam(cmd.Flag("filter"))
+		jsonOutput, _ := strconv.ParseBool(utils.GetStringParam(cmd.Flag("json")))
+
+		apiclient.DisableCmdPrintHttpResponse()
+
+		if pageSize != -1 || pageToken != "" {
+			respBody, err = authconfigs.List(pageSize, pageToken, filter)
+		} else {
+			respBody, err = authconfigs.ListAll(filter)
+		}
+		if err != nil {
+			return err
+		}
 
-		_, err = authconfigs.List(pageSize, pageToken, filter)
-		return err
+		apiclient.EnableCmdPrintHttpResponse()
+		apiclient.ClientPrintHttpResponse.Set(true)
+
+		if jsonOutput {
+			return apiclient.PrettyPrint(respBody)
+		}
+		summaries, err := authconfigs.GetSummaries(respBody)
+		if err != nil {
+			return err
+		}
+		return printSummaries(summaries)
 	},
+	Example: `List all authconfigs: ` + GetExample(4) + `
+List all authconfigs as JSON: ` + GetExample(5),
 }
 
 var pageSize int
 
 func init() {
 	var pageToken, filter string
+	var jsonOutput bool
 
 	ListCmd.Flags().IntVarP(&pageSize, "pageSize", "",
 		-1, "The maximum number of versions to return")
@@ -63,4 +93,18 @@ func init() {
 		"", "A page token, received from a previous call")
 	ListCmd.Flags().StringVarP(&filter, "filter", "",
 		"", "Filter results")
+	ListCmd.Flags().BoolVarP(&jsonOutput, "json", "",
+		false, "Print the raw response; default is false")
+}
+
+func printSummaries(summaries []authconfigs.Summary) error {
+	if !apiclient.GetCmdPrintHttpResponseSetting() {
+		return nil
+	}
+	w := tabwriter.NewWriter(clilog.HTTPResponse.Writer(), 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tDISPLAY NAME\tTYPE\tSTATE")
+	for _, s := range summaries {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", s.Id, s.DisplayName, s.Type, s.State)
+	}
+	return w.Flush()
 }
@@ -19,6 +19,7 @@ import (
 	"internal/client/certificates"
 	"internal/clilog"
 	"internal/cmd/utils"
+	"strconv"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
@@ -45,6 +46,14 @@ var DelCmd = &cobra.Command{
 		cmd.SilenceUsage = true
 
 		name := utils.GetStringParam(cmd.Flag("name"))
+		force, _ := strconv.ParseBool(utils.GetStringParam(cmd.Flag("force")))
+
+		if !force {
+			if err = utils.ConfirmDelete("certificate", name); err != nil {
+				return err
+			}
+		}
+
 		_, err = certificates.Delete(name)
 		return
 	},
@@ -52,9 +61,12 @@ var DelCmd = &cobra.Command{
 
 func init() {
 	var name string
+	var force bool
 
 	DelCmd.Flags().StringVarP(&name, "name", "n",
 		"", "Integration flow name")
+	DelCmd.Flags().BoolVarP(&force, "force", "",
+		false, "Delete the certificate without prompting for confirmation; default is false")
 
 	_ = DelCmd.MarkFlagRequired("name")
 }
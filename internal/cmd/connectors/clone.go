@@ -0,0 +1,98 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connectors
+
+import (
+	"internal/apiclient"
+	"internal/client/connections"
+	"internal/clilog"
+	"internal/cmd/utils"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// CloneCmd to create a connection with the configuration of another connection
+var CloneCmd = &cobra.Command{
+	Use:   "clone",
+	Short: "Create a connection with the configuration of another connection",
+	Long: "Create a connection with the configuration of another connection in the region. The fields " +
+		"set by the server are removed and the overrides file is merged into the configuration " +
+		"as a JSON merge patch, where a null value removes a field",
+	Args: func(cmd *cobra.Command, args []string) (err error) {
+		cmdProject := cmd.Flag("proj")
+		cmdRegion := cmd.Flag("reg")
+
+		if err = apiclient.SetRegion(utils.GetStringParam(cmdRegion)); err != nil {
+			return err
+		}
+		cmd.Flags().VisitAll(func(f *pflag.Flag) {
+			clilog.Debug.Printf("%s: %s\n", f.Name, f.Value)
+		})
+		return apiclient.SetProjectID(utils.GetStringParam(cmdProject))
+	},
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		cmd.SilenceUsage = true
+
+		var overrides []byte
+
+		source := utils.GetStringParam(cmd.Flag("source"))
+		target := utils.GetStringParam(cmd.Flag("target"))
+		overridesFile := utils.GetStringParam(cmd.Flag("override"))
+		grantPermission, _ := strconv.ParseBool(utils.GetStringParam(cmd.Flag("grant-permission")))
+		wait, _ := strconv.ParseBool(utils.GetStringParam(cmd.Flag("wait")))
+		waitTimeout, _ := time.ParseDuration(utils.GetStringParam(cmd.Flag("wait-timeout")))
+
+		if overridesFile != "" {
+			if overrides, err = utils.ReadFile(overridesFile); err != nil {
+				return err
+			}
+		}
+
+		content, err := connections.GetCloneConfig(source, overrides)
+		if err != nil {
+			return err
+		}
+
+		clilog.Info.Printf("Creating connection %s from %s\n", target, source)
+		_, err = connections.Create(target, content, "", "", "", grantPermission, false, wait, waitTimeout)
+		return err
+	},
+	Example: `Create a connection with the configuration of another connection and a different host: ` + GetExample(10),
+}
+
+func init() {
+	var source, target, overridesFile string
+	var grantPermission, wait bool
+	var waitTimeout time.Duration
+
+	CloneCmd.Flags().StringVarP(&source, "source", "s",
+		"", "Name of the connection to copy")
+	CloneCmd.Flags().StringVarP(&target, "target", "",
+		"", "Name of the connection to create")
+	CloneCmd.Flags().StringVarP(&overridesFile, "override", "o",
+		"", "JSON file merged into the connection configuration, for ex: to set a different host or secret")
+	CloneCmd.Flags().BoolVarP(&grantPermission, "grant-permission", "g",
+		false, "Grant the service account permission to the GCP resource; default is false")
+	CloneCmd.Flags().BoolVarP(&wait, "wait", "",
+		false, "Waits for the connector to finish, with success or error; default is false")
+	CloneCmd.Flags().DurationVarP(&waitTimeout, "wait-timeout", "",
+		0, "Maximum time to wait for the connector, for ex: 15m; default is no limit")
+
+	_ = CloneCmd.MarkFlagRequired("source")
+	_ = CloneCmd.MarkFlagRequired("target")
+}
@@ -32,6 +32,14 @@ var examples = []string{
 	`integrationcli connectors create -n $name -f samples/gcs_connection.json -sa=connectors --wait=true --default-token`,
 	`integrationcli connectors custom versions create --id $version -n $name -f samples/custom-connection.json --sa=connectors --default-token`,
 	`integrationcli connectors custom create -n $name -d $dispName --type OPEN_API --default-token`,
+	`while integrationcli connectors state -n $name --default-token; [ $? -eq 2 ]; do sleep 10; done`,
+	`gcloud secrets versions access latest --secret=$source | integrationcli connectors rotate-secret -n $name --field password --default-token`,
+	`integrationcli connectors custom list --versions --default-token`,
+	`integrationcli connectors custom delete -n $name --all --force --default-token`,
+	`integrationcli connectors test-connection -n $name --default-token`,
+	`integrationcli connectors managedzones list --default-token`,
+	`integrationcli connectors clone -s $source --target $name -o overrides.json --wait=true --default-token`,
+	`integrationcli connectors managedzones delete -n $name --dry-run --default-token`,
 }
 
 type ConnectorType string
@@ -64,6 +72,10 @@ func init() {
 	Cmd.AddCommand(CustomCmd)
 	Cmd.AddCommand(EventSubCmd)
 	Cmd.AddCommand(RepairCmd)
+	Cmd.AddCommand(StateCmd)
+	Cmd.AddCommand(RotateSecretCmd)
+	Cmd.AddCommand(TestConnectionCmd)
+	Cmd.AddCommand(CloneCmd)
 }
 
 func GetExample(i int) string {
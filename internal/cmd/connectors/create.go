@@ -23,6 +23,7 @@ import (
 	"os"
 	"regexp"
 	"strconv"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
@@ -32,7 +33,8 @@ import (
 var CreateCmd = &cobra.Command{
 	Use:   "create",
 	Short: "Create a new connection",
-	Long:  "Create a new connection in a region",
+	Long: "Create a new connection in a region from a connection file. The file, service account, " +
+		"secret and permission handling are the same as for connectors in integrations apply",
 	Args: func(cmd *cobra.Command, args []string) (err error) {
 		cmdProject := cmd.Flag("proj")
 		cmdRegion := cmd.Flag("reg")
@@ -40,6 +42,13 @@ var CreateCmd = &cobra.Command{
 		if err = apiclient.SetRegion(utils.GetStringParam(cmdRegion)); err != nil {
 			return err
 		}
+		if encryptionKey != "" {
+			re := regexp.MustCompile(`locations\/([a-zA-Z0-9_-]+)\/keyRings\/([a-zA-Z0-9_-]+)\/cryptoKeys\/([a-zA-Z0-9_-]+)`)
+			if ok := re.Match([]byte(encryptionKey)); !ok {
+				return fmt.Errorf("encryption key must be of the format " +
+					"locations/{location}/keyRings/{test}/cryptoKeys/{cryptoKey}")
+			}
+		}
 		cmd.Flags().VisitAll(func(f *pflag.Flag) {
 			clilog.Debug.Printf("%s: %s\n", f.Name, f.Value)
 		})
@@ -51,6 +60,7 @@ var CreateCmd = &cobra.Command{
 		createSecret, _ := strconv.ParseBool(utils.GetStringParam(cmd.Flag("create-secret")))
 		grantPermission, _ := strconv.ParseBool(utils.GetStringParam(cmd.Flag("grant-permission")))
 		wait, _ := strconv.ParseBool(utils.GetStringParam(cmd.Flag("wait")))
+		waitTimeout, _ := time.ParseDuration(utils.GetStringParam(cmd.Flag("wait-timeout")))
 		name := utils.GetStringParam(cmd.Flag("name"))
 
 		if _, err = os.Stat(connectionFile); err != nil {
@@ -62,17 +72,8 @@ var CreateCmd = &cobra.Command{
 			return fmt.Errorf("unable to open file %w", err)
 		}
 
-		if encryptionKey != "" {
-			re := regexp.MustCompile(`locations\/([a-zA-Z0-9_-]+)\/keyRings\/([a-zA-Z0-9_-]+)\/cryptoKeys\/([a-zA-Z0-9_-]+)`)
-			ok := re.Match([]byte(encryptionKey))
-			if !ok {
-				return fmt.Errorf("encryption key must be of the format " +
-					"locations/{location}/keyRings/{test}/cryptoKeys/{cryptoKey}")
-			}
-		}
-
 		_, err = connections.Create(name, content, serviceAccountName,
-			serviceAccountProject, encryptionKey, grantPermission, createSecret, wait)
+			serviceAccountProject, encryptionKey, grantPermission, createSecret, wait, waitTimeout)
 
 		return err
 	},
@@ -85,6 +86,7 @@ var connectionFile, serviceAccountName, serviceAccountProject, encryptionKey str
 func init() {
 	var name string
 	grantPermission, wait, createSecret := false, false, false
+	var waitTimeout time.Duration
 
 	CreateCmd.Flags().StringVarP(&name, "name", "n",
 		"", "Connection name")
@@ -100,6 +102,8 @@ func init() {
 		"", "Cloud KMS key for decrypting Auth Config; Format = locations/*/keyRings/*/cryptoKeys/*")
 	CreateCmd.Flags().BoolVarP(&wait, "wait", "",
 		false, "Waits for the connector to finish, with success or error; default is false")
+	CreateCmd.Flags().DurationVarP(&waitTimeout, "wait-timeout", "",
+		0, "Maximum time to wait for the connector, for ex: 15m; default is no limit")
 	CreateCmd.Flags().BoolVarP(&createSecret, "create-secret", "",
 		false, "Create Secret Manager secrets when creating the connection; default is false")
 
@@ -15,24 +15,34 @@
 package connectors
 
 import (
+	"errors"
+	"fmt"
 	"internal/apiclient"
 	"internal/client/connections"
 	"internal/clilog"
 	"internal/cmd/utils"
+	"strconv"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 )
 
-// DelCustomCmd to get connection
+// DelCustomCmd to delete a custom connection or its versions
 var DelCustomCmd = &cobra.Command{
 	Use:   "delete",
 	Short: "Delete a custom connection",
-	Long:  "Delete a custom connection",
+	Long: "Delete a custom connection, one of its versions with --version, or all its versions " +
+		"and the custom connection with --all",
 	Args: func(cmd *cobra.Command, args []string) (err error) {
 		cmdProject := cmd.Flag("proj")
 		cmdRegion := cmd.Flag("reg")
 
+		all, _ := strconv.ParseBool(utils.GetStringParam(cmd.Flag("all")))
+		if all && utils.GetStringParam(cmd.Flag("version")) != "" {
+			return errors.New("only one of version or all can be passed")
+		}
+
 		if err = apiclient.SetRegion(utils.GetStringParam(cmdRegion)); err != nil {
 			return err
 		}
@@ -45,21 +55,78 @@ var DelCustomCmd = &cobra.Command{
 		cmd.SilenceUsage = true
 
 		name := utils.GetStringParam(cmd.Flag("name"))
+		version := utils.GetStringParam(cmd.Flag("version"))
+		all, _ := strconv.ParseBool(utils.GetStringParam(cmd.Flag("all")))
+
+		if version != "" {
+			if !force {
+				if err = utils.ConfirmDelete("version of custom connection "+name, version); err != nil {
+					return err
+				}
+			}
+			_, err = connections.DeleteCustomVersion(name, version)
+			return err
+		}
+		if all {
+			return deleteAllCustomVersions(name)
+		}
+
+		if !force {
+			if err = utils.ConfirmDelete("custom connection", name); err != nil {
+				return err
+			}
+		}
 		_, err = connections.DeleteCustom(name, force)
 		return err
 	},
+	Example: `Delete all the versions and the custom connection without prompting: ` + GetExample(7),
 }
 
 var force bool
 
 func init() {
-	var name string
+	var name, version string
+	var all bool
 
 	DelCustomCmd.Flags().StringVarP(&name, "name", "n",
 		"", "The name of the custom connection")
+	DelCustomCmd.Flags().StringVarP(&version, "version", "",
+		"", "Delete only this version of the custom connection")
+	DelCustomCmd.Flags().BoolVarP(&all, "all", "",
+		false, "Delete all the versions and then the custom connection; default is false")
 
 	DelCustomCmd.Flags().BoolVarP(&force, "force", "",
-		false, "Force delete the custom connection")
+		false, "Force delete the custom connection and its versions without asking for confirmation")
 
 	_ = DelCustomCmd.MarkFlagRequired("name")
 }
+
+func deleteAllCustomVersions(name string) (err error) {
+	apiclient.DisableCmdPrintHttpResponse()
+	defer apiclient.EnableCmdPrintHttpResponse()
+
+	versions, err := connections.ListCustomVersionNames(name)
+	if err != nil {
+		return err
+	}
+	if !force {
+		if err = utils.Confirm(fmt.Sprintf("Delete %d versions and the custom connection %s?", len(versions), name)); err != nil {
+			return err
+		}
+	}
+
+	errs := []string{}
+	for _, version := range versions {
+		clilog.Info.Printf("Deleting version %s of custom connection %s\n", version, name)
+		if _, err = connections.DeleteCustomVersion(name, version); err != nil {
+			errs = append(errs, fmt.Sprintf("version %s: %v", version, err))
+		}
+	}
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "\n"))
+	}
+
+	clilog.Info.Printf("Deleting custom connection %s\n", name)
+	_, err = connections.DeleteCustom(name, force)
+	return err
+}
@@ -20,6 +20,7 @@ import (
 	"internal/client/connections"
 	"internal/clilog"
 	"internal/cmd/utils"
+	"strconv"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
@@ -49,6 +50,14 @@ var DelCmd = &cobra.Command{
 		cmd.SilenceUsage = true
 
 		name := utils.GetStringParam(cmd.Flag("name"))
+		force, _ := strconv.ParseBool(utils.GetStringParam(cmd.Flag("force")))
+
+		if !force {
+			if err = utils.ConfirmDelete("connection", name); err != nil {
+				return err
+			}
+		}
+
 		_, err = connections.Delete(name)
 		return
 	},
@@ -56,9 +65,12 @@ var DelCmd = &cobra.Command{
 
 func init() {
 	var name string
+	var force bool
 
 	DelCmd.Flags().StringVarP(&name, "name", "n",
 		"", "The name of the connection")
+	DelCmd.Flags().BoolVarP(&force, "force", "",
+		false, "Delete the connection without prompting for confirmation; default is false")
 
 	_ = DelCmd.MarkFlagRequired("name")
 }
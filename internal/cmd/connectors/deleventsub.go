@@ -19,6 +19,7 @@ import (
 	"internal/client/connections"
 	"internal/clilog"
 	"internal/cmd/utils"
+	"strconv"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
@@ -46,6 +47,14 @@ var DelEventSubCmd = &cobra.Command{
 
 		name := utils.GetStringParam(cmd.Flag("name"))
 		conn := utils.GetStringParam(cmd.Flag("conn"))
+		force, _ := strconv.ParseBool(utils.GetStringParam(cmd.Flag("force")))
+
+		if !force {
+			if err = utils.ConfirmDelete("event subscription", name); err != nil {
+				return err
+			}
+		}
+
 		_, err = connections.DeleteEventSubscription(name, conn)
 		return err
 	},
@@ -53,11 +62,14 @@ var DelEventSubCmd = &cobra.Command{
 
 func init() {
 	var name, conn string
+	var force bool
 
 	DelEventSubCmd.Flags().StringVarP(&name, "name", "n",
 		"", "The name of the event subscription")
 	DelEventSubCmd.Flags().StringVarP(&conn, "conn", "c",
 		"", "The name of the connection")
+	DelEventSubCmd.Flags().BoolVarP(&force, "force", "",
+		false, "Delete the event subscription without prompting for confirmation; default is false")
 
 	_ = DelEventSubCmd.MarkFlagRequired("name")
 	_ = DelEventSubCmd.MarkFlagRequired("conn")
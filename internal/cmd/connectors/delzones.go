@@ -19,6 +19,8 @@ import (
 	"internal/client/connections"
 	"internal/clilog"
 	"internal/cmd/utils"
+	"strconv"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
@@ -45,17 +47,49 @@ var DelManagedZonesCmd = &cobra.Command{
 		cmd.SilenceUsage = true
 
 		name := utils.GetStringParam(cmd.Flag("name"))
+		force, _ := strconv.ParseBool(utils.GetStringParam(cmd.Flag("force")))
+		wait, _ := strconv.ParseBool(utils.GetStringParam(cmd.Flag("wait")))
+		waitTimeout, _ := time.ParseDuration(utils.GetStringParam(cmd.Flag("wait-timeout")))
+		dryRun, _ := strconv.ParseBool(utils.GetStringParam(cmd.Flag("dry-run")))
 
-		_, err = connections.DeleteZone(name)
-		return
+		// print the managed zone that would be deleted; fails when it does not exist
+		if dryRun {
+			if _, err = connections.GetZone(name, false); err != nil {
+				return err
+			}
+			clilog.Info.Printf("Dry run, the managed zone %s would be deleted\n", name)
+			return nil
+		}
+
+		if !force {
+			if err = utils.ConfirmDelete("managed zone", name); err != nil {
+				return err
+			}
+		}
+
+		if _, err = connections.DeleteZone(name); err != nil || !wait {
+			return err
+		}
+		return connections.WaitForZoneDeletion(name, waitTimeout)
 	},
+	Example: `Print the managed zone that would be deleted: ` + GetExample(11),
 }
 
 func init() {
 	var name string
+	var force, wait, dryRun bool
+	var waitTimeout time.Duration
 
 	DelManagedZonesCmd.Flags().StringVarP(&name, "name", "n",
 		"", "The name of the managedzone")
+	DelManagedZonesCmd.Flags().BoolVarP(&force, "force", "",
+		false, "Delete the managed zone without prompting for confirmation; default is false")
+	DelManagedZonesCmd.Flags().BoolVarP(&dryRun, "dry-run", "",
+		false, "Print the managed zone that would be deleted without deleting it; default is false")
+	DelManagedZonesCmd.Flags().BoolVarP(&wait, "wait", "",
+		false, "Waits until the managed zone is deleted, so it can be created again; default is false")
+	DelManagedZonesCmd.Flags().DurationVarP(&waitTimeout, "wait-timeout", "",
+		10*time.Minute, "Maximum time to wait for the managed zone to be deleted, for ex: 15m; 0 waits without limit")
 
 	_ = DelManagedZonesCmd.MarkFlagRequired("name")
 }
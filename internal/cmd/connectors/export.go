@@ -28,7 +28,7 @@ import (
 var ExportCmd = &cobra.Command{
 	Use:   "export",
 	Short: "Export connections in a region to a folder",
-	Long:  "Export connections in a region to a folder",
+	Long:  "Export connections in a region to a folder in the format used by integrations apply",
 	Args: func(cmd *cobra.Command, args []string) (err error) {
 		cmdProject := cmd.Flag("proj")
 		cmdRegion := cmd.Flag("reg")
@@ -47,16 +47,24 @@ var ExportCmd = &cobra.Command{
 		if err = apiclient.FolderExists(folder); err != nil {
 			return err
 		}
+		if customFolder != "" {
+			if err = apiclient.FolderExists(customFolder); err != nil {
+				return err
+			}
+		}
 
-		return connections.Export(folder)
+		apiclient.DisableCmdPrintHttpResponse()
+		return connections.Export(folder, customFolder, utils.DefaultFileSplitter)
 	},
 }
 
-var folder string
+var folder, customFolder string
 
 func init() {
 	ExportCmd.Flags().StringVarP(&folder, "folder", "f",
 		"", "Folder to export connections")
+	ExportCmd.Flags().StringVarP(&customFolder, "custom-connectors-folder", "",
+		"", "Folder to export the custom connector versions used by the connections")
 
 	_ = ExportCmd.MarkFlagRequired("folder")
 }
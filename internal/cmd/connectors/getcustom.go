@@ -45,16 +45,23 @@ var GetCustomCmd = &cobra.Command{
 		cmd.SilenceUsage = true
 
 		name := utils.GetStringParam(cmd.Flag("name"))
+		version := utils.GetStringParam(cmd.Flag("version"))
+		if version != "" {
+			_, err = connections.GetCustomVersion(name, version, false)
+			return err
+		}
 		_, err = connections.GetCustom(name)
 		return err
 	},
 }
 
 func init() {
-	var name string
+	var name, version string
 
 	GetCustomCmd.Flags().StringVarP(&name, "name", "n",
 		"", "The name of the custom connection")
+	GetCustomCmd.Flags().StringVarP(&version, "version", "",
+		"", "The custom connection version; returns the version instead of the custom connection")
 
 	_ = GetCustomCmd.MarkFlagRequired("name")
 }
@@ -20,6 +20,7 @@ import (
 	"internal/clilog"
 	"internal/cmd/utils"
 	"strconv"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
@@ -47,17 +48,19 @@ var ImportCmd = &cobra.Command{
 
 		createSecret, _ := strconv.ParseBool(utils.GetStringParam(cmd.Flag("create-secret")))
 		wait, _ := strconv.ParseBool(utils.GetStringParam(cmd.Flag("wait")))
+		waitTimeout, _ := time.ParseDuration(utils.GetStringParam(cmd.Flag("wait-timeout")))
 
 		if err = apiclient.FolderExists(folder); err != nil {
 			return err
 		}
 
-		return connections.Import(folder, createSecret, wait)
+		return connections.Import(folder, createSecret, wait, waitTimeout)
 	},
 }
 
 func init() {
 	createSecret, wait := false, false
+	var waitTimeout time.Duration
 
 	ImportCmd.Flags().StringVarP(&folder, "folder", "f",
 		"", "Folder to import connections")
@@ -65,6 +68,8 @@ func init() {
 		false, "Create Secret Manager secrets when creating the connection")
 	ImportCmd.Flags().BoolVarP(&wait, "wait", "",
 		false, "Waits for the connector to finish, with success or error")
+	ImportCmd.Flags().DurationVarP(&waitTimeout, "wait-timeout", "",
+		0, "Maximum time to wait for each connector, for ex: 15m; default is no limit")
 
 	_ = ImportCmd.MarkFlagRequired("folder")
 }
@@ -15,10 +15,14 @@
 package connectors
 
 import (
+	"fmt"
 	"internal/apiclient"
 	"internal/client/connections"
 	"internal/clilog"
 	"internal/cmd/utils"
+	"strconv"
+	"strings"
+	"text/tabwriter"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
@@ -44,15 +48,22 @@ var ListCustomCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
 		cmd.SilenceUsage = true
 
+		versions, _ := strconv.ParseBool(utils.GetStringParam(cmd.Flag("versions")))
+		if versions {
+			return listCustomVersions()
+		}
+
 		_, err = connections.ListCustom(pageSize,
 			utils.GetStringParam(cmd.Flag("pageToken")),
 			utils.GetStringParam(cmd.Flag("filter")))
 		return err
 	},
+	Example: `List custom connections with their versions: ` + GetExample(6),
 }
 
 func init() {
 	var pageToken, filter string
+	var versions bool
 
 	ListCustomCmd.Flags().IntVarP(&pageSize, "pageSize", "",
 		-1, "The maximum number of versions to return")
@@ -60,4 +71,35 @@ func init() {
 		"", "A page token, received from a previous call")
 	ListCustomCmd.Flags().StringVarP(&filter, "filter", "",
 		"", "Filter results")
+	ListCustomCmd.Flags().BoolVarP(&versions, "versions", "",
+		false, "List every custom connection with its versions and the matching scaffold file name; default is false")
+}
+
+func listCustomVersions() (err error) {
+	apiclient.DisableCmdPrintHttpResponse()
+	names, err := connections.ListCustomNames()
+	if err != nil {
+		return err
+	}
+	rows := [][]string{}
+	for _, name := range names {
+		versions, err := connections.ListCustomVersionNames(name)
+		if err != nil {
+			return err
+		}
+		for _, version := range versions {
+			rows = append(rows, []string{name, version, name + utils.DefaultFileSplitter + version + ".json"})
+		}
+	}
+	apiclient.EnableCmdPrintHttpResponse()
+
+	if !apiclient.GetCmdPrintHttpResponseSetting() {
+		return nil
+	}
+	w := tabwriter.NewWriter(clilog.HTTPResponse.Writer(), 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tVERSION\tSCAFFOLD FILE")
+	for _, row := range rows {
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	return w.Flush()
 }
@@ -15,20 +15,24 @@
 package connectors
 
 import (
+	"fmt"
 	"internal/apiclient"
 	"internal/client/connections"
 	"internal/clilog"
 	"internal/cmd/utils"
+	"strconv"
+	"text/tabwriter"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 )
 
-// ListManagedZonesCmd to list Connections
+// ListManagedZonesCmd to list managed zones
 var ListManagedZonesCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List all managedzones configured",
-	Long:  "List all managedzones configured",
+	Long: "List all managedzones configured with their DNS suffix and target project and VPC. " +
+		"All pages are listed unless a page size or page token is set",
 	Args: func(cmd *cobra.Command, args []string) (err error) {
 		cmdProject := cmd.Flag("proj")
 		cmdRegion := cmd.Flag("reg")
@@ -44,16 +48,42 @@ var ListManagedZonesCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
 		cmd.SilenceUsage = true
 
-		_, err = connections.ListZones(pageSize,
-			utils.GetStringParam(cmd.Flag("pageToken")),
-			utils.GetStringParam(cmd.Flag("filter")),
-			utils.GetStringParam(cmd.Flag("orderBy")))
-		return err
+		var respBody []byte
+
+		pageToken := utils.GetStringParam(cmd.Flag("pageToken"))
+		filter := utils.GetStringParam(cmd.Flag("filter"))
+		orderBy := utils.GetStringParam(cmd.Flag("orderBy"))
+		jsonOutput, _ := strconv.ParseBool(utils.GetStringParam(cmd.Flag("json")))
+
+		apiclient.DisableCmdPrintHttpResponse()
+
+		if pageSize != -1 || pageToken != "" {
+			respBody, err = connections.ListZones(pageSize, pageToken, filter, orderBy)
+		} else {
+			respBody, err = connections.ListAllZones(filter, orderBy)
+		}
+		if err != nil {
+			return err
+		}
+
+		apiclient.EnableCmdPrintHttpResponse()
+		apiclient.ClientPrintHttpResponse.Set(true)
+
+		if jsonOutput {
+			return apiclient.PrettyPrint(respBody)
+		}
+		summaries, err := connections.GetZoneSummaries(respBody)
+		if err != nil {
+			return err
+		}
+		return printZoneSummaries(summaries)
 	},
+	Example: `List the managed zones of the region: ` + GetExample(9),
 }
 
 func init() {
 	var pageToken, filter, orderBy string
+	var jsonOutput bool
 
 	ListManagedZonesCmd.Flags().IntVarP(&pageSize, "pageSize", "",
 		-1, "The maximum number of versions to return")
@@ -63,4 +93,18 @@ func init() {
 		"", "Filter results")
 	ListManagedZonesCmd.Flags().StringVarP(&orderBy, "orderBy", "",
 		"", "The results would be returned in order")
+	ListManagedZonesCmd.Flags().BoolVarP(&jsonOutput, "json", "",
+		false, "Print the raw response; default is false")
+}
+
+func printZoneSummaries(summaries []connections.ZoneSummary) error {
+	if !apiclient.GetCmdPrintHttpResponseSetting() {
+		return nil
+	}
+	w := tabwriter.NewWriter(clilog.HTTPResponse.Writer(), 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tDNS\tTARGET PROJECT\tTARGET VPC")
+	for _, s := range summaries {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", s.Name, s.DNS, s.TargetProject, s.TargetVPC)
+	}
+	return w.Flush()
 }
@@ -0,0 +1,89 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connectors
+
+import (
+	"errors"
+	"internal/apiclient"
+	"internal/client/connections"
+	"internal/clilog"
+	"internal/cmd/utils"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// RotateSecretCmd to rotate the secret of a connection
+var RotateSecretCmd = &cobra.Command{
+	Use:   "rotate-secret",
+	Short: "Rotate a secret used by a connection",
+	Long: "Add a new Secret Manager version to the secret referenced by an auth config field " +
+		"of the connection and update the connection to use it. The value is read from stdin when --value is not set",
+	Args: func(cmd *cobra.Command, args []string) (err error) {
+		cmdProject := cmd.Flag("proj")
+		cmdRegion := cmd.Flag("reg")
+
+		if err = apiclient.SetRegion(utils.GetStringParam(cmdRegion)); err != nil {
+			return err
+		}
+		cmd.Flags().VisitAll(func(f *pflag.Flag) {
+			clilog.Debug.Printf("%s: %s\n", f.Name, f.Value)
+		})
+		return apiclient.SetProjectID(utils.GetStringParam(cmdProject))
+	},
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		cmd.SilenceUsage = true
+
+		var payload []byte
+
+		name := utils.GetStringParam(cmd.Flag("name"))
+		field := utils.GetStringParam(cmd.Flag("field"))
+		value := utils.GetStringParam(cmd.Flag("value"))
+
+		if value != "" {
+			payload = []byte(value)
+		} else {
+			if payload, err = io.ReadAll(os.Stdin); err != nil {
+				return err
+			}
+			// drop the newline added by echo or a terminal
+			payload = []byte(strings.TrimRight(string(payload), "\r\n"))
+		}
+		if len(payload) == 0 {
+			return errors.New("the secret value cannot be empty")
+		}
+
+		_, err = connections.RotateSecret(name, field, payload)
+		return err
+	},
+	Example: `Rotate the password of a connection from stdin: ` + GetExample(5),
+}
+
+func init() {
+	var name, field, value string
+
+	RotateSecretCmd.Flags().StringVarP(&name, "name", "n",
+		"", "Connection name")
+	RotateSecretCmd.Flags().StringVarP(&field, "field", "",
+		"password", "Auth config field that references the secret; one of password, clientKey, "+
+			"clientSecret, sshClientCert or sslClientCertPass")
+	RotateSecretCmd.Flags().StringVarP(&value, "value", "",
+		"", "New secret value; read from stdin if not set")
+
+	_ = RotateSecretCmd.MarkFlagRequired("name")
+}
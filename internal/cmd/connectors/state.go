@@ -0,0 +1,82 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connectors
+
+import (
+	"fmt"
+	"internal/apiclient"
+	"internal/client/connections"
+	"internal/clilog"
+	"internal/cmd/utils"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// exit codes returned by the state command
+const (
+	stateInProgressExitCode = 2
+	stateFailedExitCode     = 3
+)
+
+// StateCmd to get the lifecycle state of a connection
+var StateCmd = &cobra.Command{
+	Use:   "state",
+	Short: "Get the state of a connection",
+	Long: "Get the lifecycle state of a connection. The command exits with 0 when the connection is ACTIVE, " +
+		"2 when it is CREATING, UPDATING or DELETING, 3 for any other state and 1 if the state could not be read",
+	Args: func(cmd *cobra.Command, args []string) (err error) {
+		cmdProject := cmd.Flag("proj")
+		cmdRegion := cmd.Flag("reg")
+
+		if err = apiclient.SetRegion(utils.GetStringParam(cmdRegion)); err != nil {
+			return err
+		}
+		cmd.Flags().VisitAll(func(f *pflag.Flag) {
+			clilog.Debug.Printf("%s: %s\n", f.Name, f.Value)
+		})
+		return apiclient.SetProjectID(utils.GetStringParam(cmdProject))
+	},
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		cmd.SilenceUsage = true
+
+		name := utils.GetStringParam(cmd.Flag("name"))
+		state, err := connections.GetState(name)
+		if err != nil {
+			return err
+		}
+
+		switch state {
+		case "ACTIVE":
+			return nil
+		case "CREATING", "UPDATING", "DELETING":
+			return utils.NewExitCodeError(stateInProgressExitCode,
+				fmt.Errorf("connection %s is in state %s", name, state))
+		default:
+			return utils.NewExitCodeError(stateFailedExitCode,
+				fmt.Errorf("connection %s is in state %s", name, state))
+		}
+	},
+	Example: `Poll until a connection is no longer in progress: ` + GetExample(4),
+}
+
+func init() {
+	var name string
+
+	StateCmd.Flags().StringVarP(&name, "name", "n",
+		"", "The name of the connection")
+
+	_ = StateCmd.MarkFlagRequired("name")
+}
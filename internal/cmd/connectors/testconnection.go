@@ -0,0 +1,70 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connectors
+
+import (
+	"internal/apiclient"
+	"internal/client/connections"
+	"internal/clilog"
+	"internal/cmd/utils"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// TestConnectionCmd to check a connection can reach its backend
+var TestConnectionCmd = &cobra.Command{
+	Use:   "test-connection",
+	Short: "Check a connection can reach its backend",
+	Long: "Check a connection can reach its backend by refreshing the connection schema metadata " +
+		"and report the diagnostic message when it fails. Connectors without schema metadata do not support the test",
+	Args: func(cmd *cobra.Command, args []string) (err error) {
+		cmdProject := cmd.Flag("proj")
+		cmdRegion := cmd.Flag("reg")
+
+		if err = apiclient.SetRegion(utils.GetStringParam(cmdRegion)); err != nil {
+			return err
+		}
+		cmd.Flags().VisitAll(func(f *pflag.Flag) {
+			clilog.Debug.Printf("%s: %s\n", f.Name, f.Value)
+		})
+		return apiclient.SetProjectID(utils.GetStringParam(cmdProject))
+	},
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		cmd.SilenceUsage = true
+
+		name := utils.GetStringParam(cmd.Flag("name"))
+		waitTimeout, _ := time.ParseDuration(utils.GetStringParam(cmd.Flag("wait-timeout")))
+		if err = connections.TestConnection(name, waitTimeout); err != nil {
+			return err
+		}
+		clilog.Info.Printf("Connection %s reached its backend successfully\n", name)
+		return nil
+	},
+	Example: `Check a connection after applying it: ` + GetExample(8),
+}
+
+func init() {
+	var name string
+	var waitTimeout time.Duration
+
+	TestConnectionCmd.Flags().StringVarP(&name, "name", "n",
+		"", "The name of the connection")
+	TestConnectionCmd.Flags().DurationVarP(&waitTimeout, "wait-timeout", "",
+		5*time.Minute, "Maximum time to wait for the connection test to complete, for ex: 10m; 0 waits without limit")
+
+	_ = TestConnectionCmd.MarkFlagRequired("name")
+}
@@ -19,6 +19,7 @@ import (
 	"internal/client/connections"
 	"internal/clilog"
 	"internal/cmd/utils"
+	"strconv"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
@@ -45,6 +46,13 @@ var DelCmd = &cobra.Command{
 		cmd.SilenceUsage = true
 
 		name := utils.GetStringParam(cmd.Flag("name"))
+		force, _ := strconv.ParseBool(utils.GetStringParam(cmd.Flag("force")))
+
+		if !force {
+			if err = utils.ConfirmDelete("endpoint attachment", name); err != nil {
+				return err
+			}
+		}
 
 		_, err = connections.DeleteEndpoint(name)
 		return
@@ -53,9 +61,12 @@ var DelCmd = &cobra.Command{
 
 func init() {
 	var name string
+	var force bool
 
 	DelCmd.Flags().StringVarP(&name, "name", "n",
 		"", "Endpoint attachment name")
+	DelCmd.Flags().BoolVarP(&force, "force", "",
+		false, "Delete the endpoint attachment without prompting for confirmation; default is false")
 
 	_ = DelCmd.MarkFlagRequired("name")
 }
@@ -15,6 +15,7 @@
 package integrations
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -24,24 +25,32 @@ import (
 	"internal/client/integrations"
 	"internal/client/sfdc"
 	"internal/clilog"
+	"internal/cloudkms"
 	"internal/cmd/utils"
 	"io/fs"
 	"os"
 	"path"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
+	"text/tabwriter"
+	"text/template"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
+	"gopkg.in/yaml.v3"
 )
 
 // ApplyCmd a scaffold Integrations
 var ApplyCmd = &cobra.Command{
 	Use:   "apply",
 	Short: "Apply configuration generated by scaffold to a region",
-	Long:  "Apply configuration generated by scaffold to a region",
+	Long: "Apply configuration generated by scaffold to a region. Overrides and config variable files may " +
+		"reference environment variables as ${VAR} or ${VAR:-default}. Connector files named *.json.tmpl are go " +
+		"templates rendered with the --values file",
 	Args: func(cmd *cobra.Command, args []string) (err error) {
 		cmdProject := cmd.Flag("proj")
 		cmdRegion := cmd.Flag("reg")
@@ -51,15 +60,94 @@ var ApplyCmd = &cobra.Command{
 			clilog.Debug.Printf("%s: %s\n", f.Name, f.Value)
 		})
 
-		if !cloudDeploy && folder == "" {
-			return fmt.Errorf("either --folder or --cloud-deploy must be set")
+		fromGCS := utils.GetStringParam(cmd.Flag("from-gcs"))
+		gitURL := utils.GetStringParam(cmd.Flag("git"))
+		if !cloudDeploy && folder == "" && fromGCS == "" && gitURL == "" {
+			return fmt.Errorf("one of --folder, --cloud-deploy, --from-gcs or --git must be set")
+		}
+		if gitURL != "" {
+			if cloudDeploy || folder != "" || fromGCS != "" {
+				return fmt.Errorf("--git cannot be combined with --folder, --cloud-deploy or --from-gcs")
+			}
+			if gitPath := path.Clean(utils.GetStringParam(cmd.Flag("path"))); path.IsAbs(gitPath) ||
+				strings.HasPrefix(gitPath, "..") {
+				return fmt.Errorf("--path must be a relative path in the repository")
+			}
+		} else if utils.GetStringParam(cmd.Flag("ref")) != "" || utils.GetStringParam(cmd.Flag("path")) != "" {
+			return fmt.Errorf("--ref and --path can only be set with --git")
+		}
+		if fromGCS != "" {
+			if cloudDeploy || folder != "" {
+				return fmt.Errorf("--from-gcs cannot be combined with --folder or --cloud-deploy")
+			}
+			if !strings.HasPrefix(fromGCS, "gs://") {
+				return fmt.Errorf("--from-gcs must be a gs:// url")
+			}
+		}
+
+		if err = setFileSplitter(); err != nil {
+			return err
+		}
+
+		if (env != "" && (len(applyEnvs) > 0 || allEnvs)) || (len(applyEnvs) > 0 && allEnvs) {
+			return fmt.Errorf("only one of --env, --envs or --all-envs can be set")
+		}
+		if cloudDeploy && (len(applyEnvs) > 0 || allEnvs) {
+			return fmt.Errorf("--envs and --all-envs cannot be combined with --cloud-deploy")
+		}
+
+		if runTests, _ := strconv.ParseBool(utils.GetStringParam(cmd.Flag("run-tests"))); runTests && skipTestCases {
+			return fmt.Errorf("--run-tests cannot be combined with --skip-testcases")
+		}
+
+		if minNodes < 0 || maxNodes < 0 || (minNodes > 0 && maxNodes > 0 && minNodes > maxNodes) {
+			return fmt.Errorf("--min-nodes and --max-nodes must be positive and --min-nodes cannot be greater than --max-nodes")
+		}
+
+		if _, err = parseLabels(labelList); err != nil {
+			return err
+		}
+
+		if err = validateApplyOrder(applyOrder); err != nil {
+			return err
+		}
+
+		if encryptionKey != "" {
+			re := regexp.MustCompile(`locations\/([a-zA-Z0-9_-]+)\/keyRings\/([a-zA-Z0-9_-]+)\/cryptoKeys\/([a-zA-Z0-9_-]+)`)
+			if ok := re.Match([]byte(encryptionKey)); !ok {
+				return fmt.Errorf("encryption key must be of the format " +
+					"locations/{location}/keyRings/{test}/cryptoKeys/{cryptoKey}")
+			}
+		}
+
+		region := utils.GetStringParam(cmdRegion)
+		project := utils.GetStringParam(cmdProject)
+
+		// the command line flags take precedence over the target file
+		if targetFile := utils.GetStringParam(cmd.Flag("target-file")); targetFile != "" {
+			targetRegion, targetProject, err := readTargetFile(targetFile)
+			if err != nil {
+				return err
+			}
+			if region == "" {
+				region = targetRegion
+			}
+			if project == "" {
+				project = targetProject
+			}
 		}
 
-		if err = apiclient.SetRegion(utils.GetStringParam(cmdRegion)); err != nil {
+		if err = apiclient.SetRegion(region); err != nil {
+			return err
+		}
+		if err = apiclient.SetProjectID(project); err != nil {
 			return err
 		}
 
-		return apiclient.SetProjectID(utils.GetStringParam(cmdProject))
+		// connectors, endpoints and managed zones can live in a separate connectivity project
+		apiclient.SetConnectorRegion(utils.GetStringParam(cmd.Flag("connector-region")))
+		apiclient.SetConnectorProjectID(utils.GetStringParam(cmd.Flag("connector-project")))
+		return nil
 	},
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
 		cmd.SilenceUsage = true
@@ -69,12 +157,62 @@ var ApplyCmd = &cobra.Command{
 		cloudDeploy, _ := strconv.ParseBool(utils.GetStringParam(cmd.Flag("cloud-deploy")))
 		createSecret, _ := strconv.ParseBool(utils.GetStringParam(cmd.Flag("create-secret")))
 		grantPermission, _ := strconv.ParseBool(utils.GetStringParam(cmd.Flag("grant-permission")))
-		userLabel := utils.GetStringParam(cmd.Flag("user-label"))
+		userLabel := utils.GetStringParam(cmd.Flag("userlabel"))
 		wait, _ := strconv.ParseBool(utils.GetStringParam(cmd.Flag("wait")))
+		waitTimeout, _ := time.ParseDuration(utils.GetStringParam(cmd.Flag("wait-timeout")))
 		runTests, _ := strconv.ParseBool(utils.GetStringParam(cmd.Flag("run-tests")))
+		fromGCS := utils.GetStringParam(cmd.Flag("from-gcs"))
+		gitURL := utils.GetStringParam(cmd.Flag("git"))
+		since := utils.GetStringParam(cmd.Flag("since"))
 
 		apiclient.DisableCmdPrintHttpResponse()
 
+		// the versions resolved while applying are looked up once
+		integrations.EnableVersionCache()
+		defer integrations.DisableVersionCache()
+
+		// extracted folders are removed on exit; a user supplied folder never is
+		var tempFolder string
+		defer func() {
+			if tempFolder == "" {
+				return
+			}
+			if keepTemp {
+				clilog.Info.Printf("Keeping extracted scaffold configuration in %s\n", tempFolder)
+				return
+			}
+			if rerr := os.RemoveAll(tempFolder); rerr != nil {
+				clilog.Warning.Printf("Unable to remove %s: %v\n", tempFolder, rerr)
+			}
+		}()
+
+		// the folder can also be a tgz or zip archive of the scaffold
+		if stat, serr := os.Stat(folder); folder != "" && serr == nil && stat.Mode().IsRegular() {
+			clilog.Info.Printf("Extracting scaffold configuration from %s\n", folder)
+			if folder, err = apiclient.ExtractArchive(folder); err != nil {
+				return err
+			}
+			tempFolder = folder
+		}
+
+		if fromGCS != "" {
+			clilog.Info.Printf("Extracting scaffold configuration from %s\n", fromGCS)
+			if folder, err = apiclient.ExtractGCSArchive(fromGCS); err != nil {
+				return err
+			}
+			tempFolder = folder
+		}
+
+		if gitURL != "" {
+			ref := utils.GetStringParam(cmd.Flag("ref"))
+			clilog.Info.Printf("Downloading scaffold configuration from %s\n", gitURL)
+			var repoFolder string
+			if tempFolder, repoFolder, err = apiclient.DownloadGitArchive(gitURL, ref); err != nil {
+				return err
+			}
+			folder = path.Join(repoFolder, utils.GetStringParam(cmd.Flag("path")))
+		}
+
 		if cloudDeploy {
 			if err = storeCloudDeployVariables(); err != nil {
 				return err
@@ -84,119 +222,884 @@ var ApplyCmd = &cobra.Command{
 			if err != nil {
 				return err
 			}
-			folder, err = apiclient.ExtractTgz(skaffoldConfigUri)
+			folder, err = apiclient.ExtractGCSArchive(skaffoldConfigUri)
 			if err != nil {
 				return err
 			}
+			tempFolder = folder
 		}
 
 		srcFolder := folder
-		if env != "" {
-			folder = path.Join(folder, env)
+		if checkpointFile := utils.GetStringParam(cmd.Flag("checkpoint")); checkpointFile != "" {
+			if applyCheckpoint, err = readCheckpoint(checkpointFile, srcFolder); err != nil {
+				return err
+			}
+			defer func() { applyCheckpoint = nil }()
 		}
-		if stat, err := os.Stat(folder); err != nil || !stat.IsDir() {
-			return fmt.Errorf("problem with supplied path, %w", err)
+		if valuesFile := utils.GetStringParam(cmd.Flag("values")); valuesFile != "" {
+			if templateValues, err = readTemplateValues(valuesFile); err != nil {
+				return err
+			}
+			defer func() { templateValues = nil }()
 		}
+		applyEnv := func(env string) (err error) {
+			folder := srcFolder
+			if env != "" {
+				folder = path.Join(folder, env)
+			}
+			if stat, err := os.Stat(folder); err != nil || !stat.IsDir() {
+				return fmt.Errorf("problem with supplied path, %w", err)
+			}
+			// the offline checks run before anything is created
+			if validateBeforeApply || strictJSON {
+				if err = checkScaffold(srcFolder, env); err != nil {
+					return err
+				}
+			}
+
+			applyErrs = nil
+			applyStart := time.Now()
+			if since != "" {
+				if applySince, err = parseSince(since, path.Join(folder, applyStateFile), applyStart); err != nil {
+					return err
+				}
+				if !applySince.IsZero() {
+					clilog.Info.Printf("Applying resource files modified since %s\n", applySince.Format(time.RFC3339))
+				}
+			}
 
-		testsFolder := path.Join(folder, "tests")
-		testsConfigFolder := path.Join(folder, "test-configs")
-		authconfigFolder := path.Join(folder, "authconfigs")
-		connectorsFolder := path.Join(folder, "connectors")
-		customConnectorsFolder := path.Join(folder, "custom-connectors")
-		configVarsFolder := path.Join(folder, "config-variables")
-		overridesFile := path.Join(folder, "overrides/overrides.json")
-		sfdcinstancesFolder := path.Join(folder, "sfdcinstances")
-		sfdcchannelsFolder := path.Join(folder, "sfdcchannels")
-		endpointsFolder := path.Join(folder, "endpoints")
-		zonesFolder := path.Join(folder, "zones")
+			testsFolder := path.Join(folder, "tests")
+			testsConfigFolder := path.Join(folder, "test-configs")
+			authconfigFolder := path.Join(folder, "authconfigs")
+			connectorsFolder := path.Join(folder, "connectors")
+			customConnectorsFolder := path.Join(folder, "custom-connectors")
+			configVarsFolder := path.Join(folder, "config-variables")
+			overridesFiles := getOverridesFiles(srcFolder, env)
+			sfdcinstancesFolder := path.Join(folder, "sfdcinstances")
+			sfdcchannelsFolder := path.Join(folder, "sfdcchannels")
+			endpointsFolder := path.Join(folder, "endpoints")
+			zonesFolder := path.Join(folder, "zones")
+
+			integrationFolder := path.Join(srcFolder, "src")
+
+			if clilog.IsTerminal(os.Stdout) {
+				resourceFolders := []string{endpointsFolder, zonesFolder, sfdcinstancesFolder, sfdcchannelsFolder}
+				if !skipAuthconfigs {
+					resourceFolders = append(resourceFolders, authconfigFolder)
+				}
+				if !skipConnectors {
+					resourceFolders = append(resourceFolders, customConnectorsFolder, connectorsFolder)
+				}
+				applyProgress = newApplyProgress(integrationFolder, resourceFolders)
+				defer func() { applyProgress = nil }()
+			}
 
-		integrationFolder := path.Join(srcFolder, "src")
+			if grantPermission {
+				if err = preflightGrantPermissions(connectorsFolder, overridesFiles, createSecret); err != nil {
+					return err
+				}
+			}
 
-		if !skipAuthconfigs {
-			if err = processAuthConfigs(authconfigFolder); err != nil {
+			steps := map[string]func() error{
+				"authconfigs": func() error {
+					if skipAuthconfigs {
+						clilog.Colored(clilog.Info, clilog.Yellow).Printf("Skipping applying authconfigs configuration\n")
+						return nil
+					}
+					return processAuthConfigs(authconfigFolder)
+				},
+				"endpoints": func() error {
+					defer apiclient.UseConnectorContext()()
+					return processEndpoints(endpointsFolder, wait)
+				},
+				"zones": func() error {
+					defer apiclient.UseConnectorContext()()
+					return processManagedZones(zonesFolder)
+				},
+				"custom-connectors": func() error {
+					if skipConnectors {
+						return nil
+					}
+					defer apiclient.UseConnectorContext()()
+					return processCustomConnectors(customConnectorsFolder)
+				},
+				"connectors": func() error {
+					if skipConnectors {
+						clilog.Colored(clilog.Info, clilog.Yellow).Printf("Skipping applying connector configuration\n")
+						return nil
+					}
+					defer apiclient.UseConnectorContext()()
+					if err := processConnectors(connectorsFolder, grantPermission, createSecret, wait, waitTimeout); err != nil {
+						return err
+					}
+					return processEventSubscriptions(path.Join(connectorsFolder, subscriptionsFolderName))
+				},
+				"sfdcinstances": func() error {
+					return processSfdcInstances(sfdcinstancesFolder)
+				},
+				"sfdcchannels": func() error {
+					return processSfdcChannels(sfdcchannelsFolder)
+				},
+				"integration": func() error {
+					// the integration of each environment is a single checkpoint entry
+					integrationKey := path.Join(folder, "integration")
+					if applyCheckpoint.completed(integrationKey) {
+						clilog.Colored(clilog.Info, clilog.Yellow).Printf("Skipping the integration, applied before the checkpoint\n")
+						return nil
+					}
+					if err := processIntegration(overridesFiles, integrationFolder, testsFolder,
+						configVarsFolder, testsConfigFolder, pipeline, userLabel, grantPermission, runTests); err != nil {
+						return err
+					}
+					return applyCheckpoint.record(integrationKey)
+				},
+			}
+			// the output of each resource type is grouped under a header
+			stepFolders := map[string]string{
+				"authconfigs": authconfigFolder, "endpoints": endpointsFolder, "zones": zonesFolder,
+				"custom-connectors": customConnectorsFolder, "connectors": connectorsFolder,
+				"sfdcinstances": sfdcinstancesFolder, "sfdcchannels": sfdcchannelsFolder, "integration": integrationFolder,
+			}
+			for _, resourceType := range applyOrder {
+				if _, err := os.Stat(stepFolders[resourceType]); err == nil {
+					clilog.Colored(clilog.Info, clilog.Bold).Printf("== %s ==\n", applyHeaders[resourceType])
+				}
+				if err = checkApplyError(steps[resourceType]()); err != nil {
+					return err
+				}
+			}
+
+			if len(applyErrs) > 0 {
+				return fmt.Errorf("apply completed with %d errors:\n%s", len(applyErrs), strings.Join(applyErrs, "\n"))
+			}
+			if since != "" && tempFolder == "" {
+				return writeApplyState(path.Join(folder, applyStateFile), applyStart)
+			}
+			return nil
+		}
+
+		envs := applyEnvs
+		if allEnvs {
+			if envs, err = listEnvFolders(srcFolder); err != nil {
 				return err
 			}
+			if len(envs) == 0 {
+				return fmt.Errorf("no environment folders were found in %s", srcFolder)
+			}
+		}
+		if len(envs) == 0 {
+			err = applyEnv(env)
 		} else {
-			clilog.Info.Printf("Skipping applying authconfigs configuration\n")
+			results := make([]envResult, 0, len(envs))
+			for _, e := range envs {
+				clilog.Info.Printf("Applying environment %s\n", e)
+				err = applyEnv(e)
+				results = append(results, envResult{e, err})
+				if err != nil && !continueOnError {
+					break
+				}
+			}
+			err = printEnvResults(envs, results)
 		}
-
-		if err = processEndpoints(endpointsFolder); err != nil {
+		if err != nil {
 			return err
 		}
+		return applyCheckpoint.clear()
+	},
+	Example: `Apply scaffold configuration and wait for connectors: ` + GetExample(9) + `
+Apply scaffold configuration for a specific environment: ` + GetExample(10) + `
+Apply scaffold configuration and grant permissions to the service account: ` + GetExample(11) + `
+Apply scaffold configuration, but skip connectors: ` + GetExample(12) + `
+Apply scaffold configuration and run functional tests: ` + GetExample(18) + `
+Apply scaffold configuration to the region and project of a target file: ` + GetExample(34) + `
+Apply authconfigs after the connectors they depend on: ` + GetExample(38) + `
+Apply the dev, staging and prod environments in order: ` + GetExample(39) + `
+Apply scaffold configuration and create connectors with 2 to 5 nodes: ` + GetExample(42) + `
+Apply scaffold configuration from a folder of a git repository: ` + GetExample(45) + `
+Apply scaffold configuration and resume from the last failure when run again: ` + GetExample(51) + `
+Apply scaffold configuration and render the connector templates with a values file: ` + GetExample(54),
+}
 
-		if err = processManagedZones(zonesFolder); err != nil {
-			return err
-		}
+var serviceAccountName, serviceAccountProject, encryptionKey, pipeline string
+var release, outputGCSPath, cloudDeployProjectId, cloudDeployLocation string
+var continueOnError, keepTemp, noPublish, lintBeforeApply, validateBeforeApply, evaluateJsonnet, failIfExists, skipTestCases bool
+
+// strictJSON rejects the fields of the scaffold files that are not part of their schema, instead
+// of dropping them. See validateStrictJSON for the files that are checked
+var strictJSON bool
+
+// setConfigVarList holds the config variables set on the command line
+var setConfigVarList []string
+
+// applyHeaders are the headers the apply output of each resource type is grouped under
+var applyHeaders = map[string]string{
+	"authconfigs":       "AuthConfigs",
+	"endpoints":         "Endpoints",
+	"zones":             "Managed Zones",
+	"custom-connectors": "Custom Connectors",
+	"connectors":        "Connectors",
+	"sfdcinstances":     "SFDC Instances",
+	"sfdcchannels":      "SFDC Channels",
+	"integration":       "Integration",
+}
 
-		if !skipConnectors {
-			if err = processCustomConnectors(customConnectorsFolder); err != nil {
-				return err
-			}
+// defaultApplyOrder is the order resource types are applied in, so each type is applied
+// after the types it usually depends on
+var defaultApplyOrder = []string{
+	"authconfigs", "endpoints", "zones", "custom-connectors", "connectors",
+	"sfdcinstances", "sfdcchannels", "integration",
+}
 
-			if err = processConnectors(connectorsFolder, grantPermission, createSecret, wait); err != nil {
-				return err
+// applyOrder holds the order set with --order
+var applyOrder []string
+
+// applySince is the cutoff before which scaffold resource files are not applied
+var applySince time.Time
+
+// applyStateFile records the time of the last apply with --since in the scaffold folder
+const applyStateFile = ".integrationcli-apply.json"
+
+// applyEnvs holds the environments set with --envs, applied in order
+var applyEnvs []string
+
+// allEnvs applies every environment folder of the scaffold
+var allEnvs bool
+
+// scaffoldFolders are the scaffold folders that are not environments
+var scaffoldFolders = []string{
+	"src", "tests", "test-configs", "authconfigs", "connectors", "custom-connectors", "config-variables",
+	"overrides", "sfdcinstances", "sfdcchannels", "endpoints", "zones",
+}
+
+// minNodes and maxNodes override the node counts of the connectors created by apply
+var minNodes, maxNodes int
+
+// labelList holds the labels added to the connections and managed zones created by apply
+var labelList []string
+
+// applyErrs holds the errors skipped when continue-on-error is set
+var applyErrs []string
+
+func init() {
+	var userLabel, fromGCS, gitURL, gitRef, gitPath, targetFile, since, checkpointFile, valuesFile string
+	var connectorRegion, connectorProject string
+	grantPermission, createSecret, wait, runTests, cloudDeploy := false, false, false, false, false
+	var waitTimeout time.Duration
+
+	ApplyCmd.Flags().StringVarP(&folder, "folder", "f",
+		"", "Folder containing scaffolding configuration, or a tgz or zip archive of it")
+	ApplyCmd.Flags().BoolVarP(&cloudDeploy, "cloud-deploy", "",
+		false, "Deploy using Cloud Deploy; default is false")
+	ApplyCmd.Flags().StringVarP(&fromGCS, "from-gcs", "",
+		"", "Cloud Storage url of a scaffold tgz or zip archive to extract and apply, for ex: gs://bucket/path.tgz")
+	ApplyCmd.Flags().StringVarP(&gitURL, "git", "",
+		"", "GitHub or GitLab repository url to download and apply, without a git client; "+
+			"set GIT_TOKEN for private repositories")
+	ApplyCmd.Flags().StringVarP(&gitRef, "ref", "",
+		"", "Branch, tag or commit of the git repository; default is the default branch")
+	ApplyCmd.Flags().StringVarP(&gitPath, "path", "",
+		"", "Folder of the scaffold configuration in the git repository; default is the repository root")
+	ApplyCmd.Flags().BoolVarP(&grantPermission, "grant-permission", "g",
+		false, "Grant the service account permission to the GCP resource; default is false")
+	ApplyCmd.Flags().StringVarP(&userLabel, "userlabel", "u",
+		"", "Integration version userlabel")
+	ApplyCmd.Flags().StringVarP(&serviceAccountName, "sa", "",
+		"", "Service Account name for the connection or integration trigger")
+	ApplyCmd.Flags().StringVarP(&serviceAccountProject, "sp", "",
+		"", "Service Account Project for the connection or integraton trigger.")
+	ApplyCmd.Flags().StringVarP(&encryptionKey, "encryption-keyid", "k",
+		"", "Cloud KMS key for decrypting Auth Config files and connector secrets; Format = locations/*/keyRings/*/cryptoKeys/*")
+	ApplyCmd.Flags().StringVarP(&env, "env", "e",
+		"", "Environment name for the scaffolding; the environment overrides are merged over the base overrides")
+	ApplyCmd.Flags().StringSliceVarP(&applyEnvs, "envs", "",
+		nil, "Environments to apply in order, for ex: dev,staging,prod; stops at the first failure unless --continue-on-error is set")
+	ApplyCmd.Flags().BoolVarP(&allEnvs, "all-envs", "",
+		false, "Apply every environment folder of the scaffold, a folder with an overrides or config-variables folder, in name order; default is false")
+	ApplyCmd.Flags().BoolVarP(&createSecret, "create-secret", "",
+		false, "Create Secret Manager secrets when creating the connection; default is false")
+	ApplyCmd.Flags().BoolVarP(&wait, "wait", "",
+		false, "Waits for the connector to finish, with success or error; default is false")
+	ApplyCmd.Flags().DurationVarP(&waitTimeout, "wait-timeout", "",
+		0, "Maximum time to wait for each connector, for ex: 15m; default is no limit")
+	ApplyCmd.Flags().IntVarP(&minNodes, "min-nodes", "",
+		0, "Minimum node count of the connectors that are created, overrides the nodeConfig of the connector files")
+	ApplyCmd.Flags().IntVarP(&maxNodes, "max-nodes", "",
+		0, "Maximum node count of the connectors that are created, overrides the nodeConfig of the connector files")
+	ApplyCmd.Flags().BoolVarP(&skipConnectors, "skip-connectors", "",
+		false, "Skip applying connector configuration; default is false")
+	ApplyCmd.Flags().StringVarP(&connectorRegion, "connector-region", "",
+		"", "Region of the connectors, endpoint attachments and managed zones; default is the integration region")
+	ApplyCmd.Flags().StringVarP(&connectorProject, "connector-project", "",
+		"", "Project of the connectors, endpoint attachments and managed zones; default is the integration project")
+	ApplyCmd.Flags().BoolVarP(&skipAuthconfigs, "skip-authconfigs", "",
+		false, "Skip applying authconfigs configuration; default is false")
+	ApplyCmd.Flags().BoolVarP(&skipTestCases, "skip-testcases", "",
+		false, "Skip creating the test cases of the integration version; default is false")
+	ApplyCmd.Flags().BoolVarP(&useUnderscore, "use-underscore", "",
+		false, "Use underscore as a file splitter; default is __")
+	ApplyCmd.Flags().StringVarP(&fileSplitter, "file-splitter", "",
+		utils.DefaultFileSplitter, "Separator used in custom connector and sfdc channel file names")
+	ApplyCmd.Flags().BoolVarP(&runTests, "run-tests", "",
+		false, "Runs unit tests from config files in test-configs folder. See ./samples/test-config.json for an example config")
+	ApplyCmd.Flags().BoolVarP(&keepTemp, "keep-temp", "",
+		false, "Keep the folder extracted for --cloud-deploy, --from-gcs or an archive after apply; default is false")
+	ApplyCmd.Flags().BoolVarP(&noPublish, "no-publish", "",
+		false, "Create the integration version and test cases as a draft without publishing it; default is false")
+	ApplyCmd.Flags().BoolVarP(&evaluateJsonnet, "evaluate-jsonnet", "",
+		false, "Resolve the local imports of the data transformer files by inlining the imported files, "+
+			"so shared jsonnet libraries are sent with the integration; default is false")
+	ApplyCmd.Flags().BoolVarP(&validateBeforeApply, "validate-before-apply", "",
+		false, "Run the offline checks of scaffold validate before creating anything and stop when problems "+
+			"are found; default is false")
+	ApplyCmd.Flags().BoolVarP(&strictJSON, "strict-json", "",
+		false, "Fail when the integration, overrides, connector, custom connector, endpoint, zone or sfdc files have "+
+			"fields that are not part of their schema, for ex: a misspelled key, instead of ignoring them. authconfig "+
+			"files are not checked. Implies --validate-before-apply; default is false")
+	ApplyCmd.Flags().BoolVarP(&lintBeforeApply, "lint-before-apply", "",
+		false, "Lint the integration flow file, with the overrides merged, before creating the version and stop when "+
+			"problems are found. "+
+			"Authconfigs are checked after the scaffold authconfigs are applied; default is false")
+	ApplyCmd.Flags().StringArrayVarP(&setConfigVarList, "set-config-var", "",
+		nil, "Config variable in the form name=value set over the config variables file before publishing. "+
+			"The value is converted to the declared type of the config variable. Repeat the flag to set more than one")
+	ApplyCmd.Flags().StringArrayVarP(&labelList, "label", "",
+		nil, "Label in the form key=value added to the connections and managed zones created by apply, "+
+			"unless the file already sets it. Authconfigs and integration versions do not support labels. "+
+			"Repeat the flag to set more than one")
+	ApplyCmd.Flags().StringVarP(&targetFile, "target-file", "",
+		"", "JSON file with the region and project to apply to, for ex: {\"region\": \"us-west1\", \"project\": \"my-project\"}. "+
+			"The --reg and --proj flags take precedence")
+	ApplyCmd.Flags().StringVarP(&since, "since", "",
+		"", "Only apply the authconfig, endpoint, zone, connector and sfdc files modified since a timestamp, for ex: "+
+			"2025-01-02T15:04:05Z, or a duration, for ex: 2h or 1d. Use last for the time of the previous successful "+
+			"apply with --since, recorded in "+applyStateFile+" in the folder; all the files are applied when none was recorded. "+
+			"The integration is always applied")
+	ApplyCmd.Flags().StringSliceVarP(&applyOrder, "order", "",
+		defaultApplyOrder, "Order to apply the resource types in. Must list each of "+
+			strings.Join(defaultApplyOrder, ", ")+" once")
+	ApplyCmd.Flags().StringVarP(&checkpointFile, "checkpoint", "",
+		"", "File recording the resources that were applied. A later apply with the same file skips them "+
+			"and resumes after the last failure. The file is removed when the apply succeeds")
+	ApplyCmd.Flags().StringVarP(&valuesFile, "values", "",
+		"", "YAML file with the values of the connector templates, connectors/*"+connectorTemplateExt+" files "+
+			"rendered as go templates")
+	ApplyCmd.Flags().BoolVarP(&continueOnError, "continue-on-error", "",
+		false, "Continue applying the remaining resources when one fails and report all failures at the end; default is false")
+	ApplyCmd.Flags().BoolVarP(&failIfExists, "fail-if-exists", "",
+		false, "Fail when an authconfig, endpoint, zone, connector or sfdc resource already exists "+
+			"instead of skipping or updating it; default is false")
+}
+
+// envResult is the outcome of applying an environment folder
+type envResult struct {
+	env string
+	err error
+}
+
+// envMarkerFolders are the folders identifying an environment folder of the scaffold
+var envMarkerFolders = []string{"overrides", "config-variables"}
+
+// listEnvFolders returns the environment folders of the scaffold in name order. A folder that
+// is not hidden and is not a resource folder of the scaffold is an environment when it has an
+// overrides or config-variables folder
+func listEnvFolders(folder string) (envs []string, err error) {
+	entries, err := os.ReadDir(folder)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") || slices.Contains(scaffoldFolders, entry.Name()) {
+			continue
+		}
+		for _, marker := range envMarkerFolders {
+			if stat, err := os.Stat(path.Join(folder, entry.Name(), marker)); err == nil && stat.IsDir() {
+				envs = append(envs, entry.Name())
+				break
 			}
-		} else {
-			clilog.Info.Printf("Skipping applying connector configuration\n")
 		}
+	}
+	return envs, nil
+}
 
-		if err = processSfdcInstances(sfdcinstancesFolder); err != nil {
-			return err
+// printEnvResults prints the outcome of each environment, the environments after a failure are
+// reported as skipped, and returns the errors of the failed environments
+func printEnvResults(envs []string, results []envResult) error {
+	var errs []string
+
+	w := tabwriter.NewWriter(clilog.HTTPResponse.Writer(), 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ENV\tRESULT")
+	for _, r := range results {
+		if r.err != nil {
+			fmt.Fprintf(w, "%s\tfailed\n", r.env)
+			errs = append(errs, fmt.Sprintf("%s: %v", r.env, r.err))
+		} else {
+			fmt.Fprintf(w, "%s\tapplied\n", r.env)
 		}
+	}
+	for _, e := range envs[len(results):] {
+		fmt.Fprintf(w, "%s\tskipped\n", e)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%d of %d environments failed to apply:\n%s", len(errs), len(envs), strings.Join(errs, "\n"))
+	}
+	return nil
+}
 
-		if err = processSfdcChannels(sfdcchannelsFolder); err != nil {
-			return err
+// checkApplyError records the error and returns nil when continue-on-error is set
+func checkApplyError(err error) error {
+	if err == nil || !continueOnError {
+		return err
+	}
+	clilog.Colored(clilog.Warning, clilog.Red).Println(err)
+	applyErrs = append(applyErrs, err.Error())
+	return nil
+}
+
+// applyWalkFunc wraps fn so a failed resource does not stop the walk when continue-on-error is set.
+// Files not modified since the --since cutoff or recorded in the checkpoint are skipped
+func applyWalkFunc(fn filepath.WalkFunc) filepath.WalkFunc {
+	return func(path string, info os.FileInfo, err error) error {
+		isFile := err == nil && !info.IsDir()
+		if isFile && info.ModTime().Before(applySince) {
+			clilog.Debug.Printf("Skipping %s, not modified since %s\n", path, applySince.Format(time.RFC3339))
+			return nil
+		}
+		if isFile && applyCheckpoint.completed(path) {
+			clilog.Info.Printf("Skipping %s, applied before the checkpoint\n", path)
+			applyProgress.step(path)
+			return nil
+		}
+		err = fn(path, info, err)
+		if isFile {
+			applyProgress.step(path)
+		}
+		if isFile && err == nil {
+			err = applyCheckpoint.record(path)
 		}
+		if err != nil && err != filepath.SkipDir && continueOnError {
+			return checkApplyError(fmt.Errorf("%s: %w", path, err))
+		}
+		return err
+	}
+}
 
-		if err = processIntegration(overridesFile, integrationFolder, testsFolder,
-			configVarsFolder, testsConfigFolder, pipeline, userLabel, grantPermission, runTests); err != nil {
-			return err
+// parseSince returns the cutoff of a --since timestamp, duration or last, which reads the state file.
+// Without a state file, last is the zero time so every file is applied
+func parseSince(since string, stateFile string, now time.Time) (cutoff time.Time, err error) {
+	if since == "last" {
+		state := struct {
+			LastApply time.Time `json:"lastApply"`
+		}{}
+		if _, err := os.Stat(stateFile); errors.Is(err, fs.ErrNotExist) {
+			clilog.Info.Printf("No previous apply with --since was recorded in %s, applying all the files\n", stateFile)
+			return cutoff, nil
 		}
+		contents, err := utils.ReadFile(stateFile)
+		if err != nil {
+			return cutoff, fmt.Errorf("unable to read the last apply time: %w", err)
+		}
+		if err = json.Unmarshal(contents, &state); err != nil {
+			return cutoff, fmt.Errorf("unable to parse %s: %w", stateFile, err)
+		}
+		return state.LastApply, nil
+	}
+	if cutoff, err = time.Parse(time.RFC3339, since); err == nil {
+		return cutoff, nil
+	}
+	age, err := utils.ParseAge(since)
+	if err != nil {
+		return cutoff, fmt.Errorf("since must be a timestamp, a duration or last: %w", err)
+	}
+	return now.Add(-age), nil
+}
 
+// writeApplyState records the start of a successful apply for --since last
+func writeApplyState(stateFile string, applyStart time.Time) error {
+	state, err := json.Marshal(map[string]string{"lastApply": applyStart.UTC().Format(time.RFC3339)})
+	if err != nil {
 		return err
-	},
-	Example: `Apply scaffold configuration and wait for connectors: ` + GetExample(9) + `
-Apply scaffold configuration for a specific environment: ` + GetExample(10) + `
-Apply scaffold configuration and grant permissions to the service account: ` + GetExample(11) + `
-Apply scaffold configuration, but skip connectors: ` + GetExample(12) + `
-Apply scaffold configuration and run functional tests: ` + GetExample(18),
+	}
+	return apiclient.WriteByteArrayToFile(stateFile, false, state)
+}
+
+// checkpoint records the resources of an apply that were applied, relative to the scaffold folder
+type checkpoint struct {
+	file      string
+	folder    string
+	Completed []string `json:"completed"`
+}
+
+// applyCheckpoint is set with --checkpoint
+var applyCheckpoint *checkpoint
+
+// readCheckpoint reads the checkpoint file, a missing file is an empty checkpoint
+func readCheckpoint(file string, folder string) (*checkpoint, error) {
+	c := &checkpoint{file: file, folder: folder}
+	if _, err := os.Stat(file); err != nil {
+		return c, nil
+	}
+	contents, err := utils.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(contents, c); err != nil {
+		return nil, fmt.Errorf("unable to parse checkpoint file %s: %w", file, err)
+	}
+	if len(c.Completed) > 0 {
+		clilog.Info.Printf("Resuming apply, skipping the %d resources recorded in %s\n", len(c.Completed), file)
+	}
+	return c, nil
+}
+
+func (c *checkpoint) key(resource string) string {
+	if rel, err := filepath.Rel(c.folder, resource); err == nil {
+		return filepath.ToSlash(rel)
+	}
+	return resource
+}
+
+// completed returns true when the resource was recorded by an earlier apply
+func (c *checkpoint) completed(resource string) bool {
+	if c == nil {
+		return false
+	}
+	return slices.Contains(c.Completed, c.key(resource))
+}
+
+// record adds the resource to the checkpoint and writes the file, so the progress is kept when
+// apply fails
+func (c *checkpoint) record(resource string) error {
+	if c == nil {
+		return nil
+	}
+	c.Completed = append(c.Completed, c.key(resource))
+	contents, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+	return apiclient.WriteByteArrayToFile(c.file, false, contents)
+}
+
+// clear removes the checkpoint file after a successful apply
+func (c *checkpoint) clear() error {
+	if c == nil {
+		return nil
+	}
+	if err := os.Remove(c.file); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// validateApplyOrder checks the order lists each resource type once
+func validateApplyOrder(order []string) error {
+	seen := map[string]bool{}
+	for _, resourceType := range order {
+		if !slices.Contains(defaultApplyOrder, resourceType) {
+			return fmt.Errorf("unknown resource type %s in order, must be one of %s",
+				resourceType, strings.Join(defaultApplyOrder, ", "))
+		}
+		if seen[resourceType] {
+			return fmt.Errorf("resource type %s is listed more than once in order", resourceType)
+		}
+		seen[resourceType] = true
+	}
+	missing := []string{}
+	for _, resourceType := range defaultApplyOrder {
+		if !seen[resourceType] {
+			missing = append(missing, resourceType)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("order is missing the resource types %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// readTargetFile returns the region and project of a target file
+func readTargetFile(targetFile string) (region string, project string, err error) {
+	target := struct {
+		Region  string `json:"region,omitempty"`
+		Project string `json:"project,omitempty"`
+	}{}
+
+	contents, err := utils.ReadFile(targetFile)
+	if err != nil {
+		return "", "", err
+	}
+	if err = json.Unmarshal(contents, &target); err != nil {
+		return "", "", fmt.Errorf("invalid target file %s: %w", targetFile, err)
+	}
+	return target.Region, target.Project, nil
+}
+
+// parseLabels parses a list of key=value labels
+func parseLabels(labels []string) (map[string]string, error) {
+	parsed := map[string]string{}
+	for _, label := range labels {
+		key, value, found := strings.Cut(label, "=")
+		if !found || key == "" {
+			return nil, fmt.Errorf("label %q must be of the form key=value", label)
+		}
+		parsed[key] = value
+	}
+	return parsed, nil
+}
+
+// mergeLabels adds the labels to the resource, keeping the labels already set in the file
+func mergeLabels(contents []byte, labels []string) ([]byte, error) {
+	if len(labels) == 0 {
+		return contents, nil
+	}
+	parsed, err := parseLabels(labels)
+	if err != nil {
+		return nil, err
+	}
+
+	resource := map[string]interface{}{}
+	if err = json.Unmarshal(contents, &resource); err != nil {
+		return nil, err
+	}
+	resourceLabels, ok := resource["labels"].(map[string]interface{})
+	if !ok {
+		resourceLabels = map[string]interface{}{}
+	}
+	for key, value := range parsed {
+		if _, exists := resourceLabels[key]; !exists {
+			resourceLabels[key] = value
+		}
+	}
+	resource["labels"] = resourceLabels
+	return json.Marshal(resource)
+}
+
+// getOverridesFiles returns the overrides files in the order they are layered. With an
+// environment, the base overrides of the scaffold are followed by the overrides of the
+// environment folder and its overrides.<env>.json file. Each file can be written in YAML
+// instead, with the .yaml extension
+func getOverridesFiles(srcFolder string, env string) []string {
+	overridesFiles := []string{path.Join(srcFolder, "overrides", "overrides.json")}
+	if env != "" {
+		overridesFiles = append(overridesFiles,
+			path.Join(srcFolder, env, "overrides", "overrides.json"),
+			path.Join(srcFolder, env, "overrides", "overrides."+env+".json"))
+	}
+	for i, overridesFile := range overridesFiles {
+		if _, err := os.Stat(overridesFile); err == nil {
+			continue
+		}
+		yamlFile := strings.TrimSuffix(overridesFile, jsonExt) + yamlExt
+		if _, err := os.Stat(yamlFile); err == nil {
+			overridesFiles[i] = yamlFile
+		}
+	}
+	return overridesFiles
+}
+
+// yamlExt is the extension of the overrides files written in YAML
+const yamlExt = ".yaml"
+
+// overridesToJSON converts the contents of a YAML overrides file to JSON, the contents of
+// the other overrides files are returned as is
+func overridesToJSON(overridesFile string, contents []byte) ([]byte, error) {
+	if filepath.Ext(overridesFile) != yamlExt {
+		return contents, nil
+	}
+	var overrides interface{}
+	if err := yaml.Unmarshal(contents, &overrides); err != nil {
+		return nil, fmt.Errorf("invalid overrides file %s: %w", overridesFile, err)
+	}
+	if overrides == nil {
+		return nil, nil
+	}
+	return json.Marshal(overrides)
+}
+
+// readOverrides reads the overrides files that exist and deep merges them, the later
+// files taking precedence. It returns nil when there are no overrides files
+func readOverrides(overridesFiles []string) (overridesBytes []byte, err error) {
+	for _, overridesFile := range overridesFiles {
+		if _, err = os.Stat(overridesFile); err != nil {
+			continue
+		}
+		contents, err := utils.ReadFile(overridesFile)
+		if err != nil {
+			return nil, err
+		}
+		if contents, err = utils.InterpolateEnv(contents); err != nil {
+			return nil, fmt.Errorf("unable to interpolate overrides file %s: %w", overridesFile, err)
+		}
+		if contents, err = overridesToJSON(overridesFile, contents); err != nil {
+			return nil, err
+		}
+		if len(contents) == 0 {
+			continue
+		}
+		clilog.Info.Printf("Found overrides file %s\n", overridesFile)
+		if overridesBytes, err = mergeOverrides(overridesBytes, contents); err != nil {
+			return nil, fmt.Errorf("unable to merge overrides file %s: %w", overridesFile, err)
+		}
+	}
+	return overridesBytes, nil
+}
+
+// overridesArrayKeys are the fields identifying the elements of the overrides arrays
+var overridesArrayKeys = map[string]string{
+	"trigger_overrides":    "triggerNumber",
+	"task_overrides":       "taskId",
+	"connection_overrides": "taskId",
+	"param_overrides":      "key",
+}
+
+// mergeOverrides deep merges the layer into the base overrides. Objects are merged field by
+// field, the elements of the overrides arrays are merged by their identifying field and
+// any other value of the layer replaces the value of the base
+func mergeOverrides(base []byte, layer []byte) ([]byte, error) {
+	if len(base) == 0 {
+		return layer, nil
+	}
+	var baseOverrides, layerOverrides interface{}
+	if err := json.Unmarshal(base, &baseOverrides); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(layer, &layerOverrides); err != nil {
+		return nil, err
+	}
+	return json.Marshal(mergeOverridesValue("", baseOverrides, layerOverrides))
+}
+
+func mergeOverridesValue(name string, base interface{}, layer interface{}) interface{} {
+	switch layerValue := layer.(type) {
+	case map[string]interface{}:
+		baseValue, ok := base.(map[string]interface{})
+		if !ok {
+			return layerValue
+		}
+		for key, value := range layerValue {
+			baseValue[key] = mergeOverridesValue(key, baseValue[key], value)
+		}
+		return baseValue
+	case []interface{}:
+		baseValue, ok := base.([]interface{})
+		idKey, keyed := overridesArrayKeys[name]
+		if !ok || !keyed {
+			return layerValue
+		}
+		for _, element := range layerValue {
+			id, hasId := getOverridesElementId(element, idKey)
+			merged := false
+			for i, baseElement := range baseValue {
+				if baseId, ok := getOverridesElementId(baseElement, idKey); hasId && ok && baseId == id {
+					baseValue[i] = mergeOverridesValue("", baseElement, element)
+					merged = true
+					break
+				}
+			}
+			if !merged {
+				baseValue = append(baseValue, element)
+			}
+		}
+		return baseValue
+	default:
+		return layerValue
+	}
+}
+
+// getOverridesElementId returns the identifying field of the element of an overrides array
+func getOverridesElementId(element interface{}, idKey string) (string, bool) {
+	fields, ok := element.(map[string]interface{})
+	if !ok {
+		return "", false
+	}
+	id, ok := fields[idKey].(string)
+	return id, ok && id != ""
+}
+
+// mergeNodeConfig sets the node counts of the connection nodeConfig when they are greater than 0
+func mergeNodeConfig(contents []byte, minNodes int, maxNodes int) ([]byte, error) {
+	if minNodes <= 0 && maxNodes <= 0 {
+		return contents, nil
+	}
+
+	resource := map[string]interface{}{}
+	if err := json.Unmarshal(contents, &resource); err != nil {
+		return nil, err
+	}
+	nodeConfig, ok := resource["nodeConfig"].(map[string]interface{})
+	if !ok {
+		nodeConfig = map[string]interface{}{}
+	}
+	minCount, _ := nodeConfig["minNodeCount"].(float64)
+	maxCount, _ := nodeConfig["maxNodeCount"].(float64)
+	if minNodes > 0 {
+		minCount = float64(minNodes)
+		nodeConfig["minNodeCount"] = minNodes
+	}
+	if maxNodes > 0 {
+		maxCount = float64(maxNodes)
+		nodeConfig["maxNodeCount"] = maxNodes
+	}
+	if minCount > 0 && maxCount > 0 && minCount > maxCount {
+		return nil, fmt.Errorf("minNodeCount %v is greater than maxNodeCount %v", minCount, maxCount)
+	}
+	resource["nodeConfig"] = nodeConfig
+	return json.Marshal(resource)
+}
+
+// progress prints the number of resources applied out of the total found in the scaffold
+type progress struct {
+	total, done int
+}
+
+// applyProgress is set when stdout is a terminal
+var applyProgress *progress
+
+// newApplyProgress counts the resource files that apply will walk and the integration file
+func newApplyProgress(integrationFolder string, resourceFolders []string) *progress {
+	p := &progress{}
+	for _, folder := range resourceFolders {
+		_ = filepath.Walk(folder, func(path string, info os.FileInfo, err error) error {
+			if err == nil && !info.IsDir() && !info.ModTime().Before(applySince) {
+				p.total++
+			}
+			return nil
+		})
+	}
+	rJSONFiles := regexp.MustCompile(`(\S*)\.json$`)
+	hasIntegration := false
+	_ = filepath.Walk(integrationFolder, func(path string, info os.FileInfo, err error) error {
+		if err == nil && !info.IsDir() && rJSONFiles.MatchString(filepath.Base(path)) {
+			hasIntegration = true
+		}
+		return nil
+	})
+	if hasIntegration {
+		p.total++
+	}
+	return p
 }
 
-var serviceAccountName, serviceAccountProject, encryptionKey, pipeline string
-var release, outputGCSPath, cloudDeployProjectId, cloudDeployLocation string
-
-func init() {
-	var userLabel string
-	grantPermission, createSecret, wait, runTests, cloudDeploy := false, false, false, false, false
+func (p *progress) step(resource string) {
+	if p == nil {
+		return
+	}
+	p.done++
+	clilog.Info.Printf("applied %d/%d %s\n", p.done, p.total, resource)
+}
 
-	ApplyCmd.Flags().StringVarP(&folder, "folder", "f",
-		"", "Folder containing scaffolding configuration")
-	ApplyCmd.Flags().BoolVarP(&cloudDeploy, "cloud-deploy", "",
-		false, "Deploy using Cloud Deploy; default is false")
-	ApplyCmd.Flags().BoolVarP(&grantPermission, "grant-permission", "g",
-		false, "Grant the service account permission to the GCP resource; default is false")
-	ApplyCmd.Flags().StringVarP(&userLabel, "userlabel", "u",
-		"", "Integration version userlabel")
-	ApplyCmd.Flags().StringVarP(&serviceAccountName, "sa", "",
-		"", "Service Account name for the connection or integration trigger")
-	ApplyCmd.Flags().StringVarP(&serviceAccountProject, "sp", "",
-		"", "Service Account Project for the connection or integraton trigger.")
-	ApplyCmd.Flags().StringVarP(&encryptionKey, "encryption-keyid", "k",
-		"", "Cloud KMS key for decrypting Auth Config; Format = locations/*/keyRings/*/cryptoKeys/*")
-	ApplyCmd.Flags().StringVarP(&env, "env", "e",
-		"", "Environment name for the scaffolding")
-	ApplyCmd.Flags().BoolVarP(&createSecret, "create-secret", "",
-		false, "Create Secret Manager secrets when creating the connection; default is false")
-	ApplyCmd.Flags().BoolVarP(&wait, "wait", "",
-		false, "Waits for the connector to finish, with success or error; default is false")
-	ApplyCmd.Flags().BoolVarP(&skipConnectors, "skip-connectors", "",
-		false, "Skip applying connector configuration; default is false")
-	ApplyCmd.Flags().BoolVarP(&skipAuthconfigs, "skip-authconfigs", "",
-		false, "Skip applying authconfigs configuration; default is false")
-	ApplyCmd.Flags().BoolVarP(&useUnderscore, "use-underscore", "",
-		false, "Use underscore as a file splitter; default is __")
-	ApplyCmd.Flags().BoolVarP(&runTests, "run-tests", "",
-		false, "Runs unit tests from config files in test-configs folder. See ./samples/test-config.json for an example config")
+// setFileSplitter resolves the file splitter from the use-underscore and file-splitter flags
+func setFileSplitter() error {
+	if useUnderscore {
+		if fileSplitter != utils.DefaultFileSplitter {
+			return errors.New("use-underscore cannot be combined with file-splitter")
+		}
+		fileSplitter = utils.LegacyFileSplitter
+		return nil
+	}
+	return utils.ValidateFileSplitter(fileSplitter)
 }
 
 func getFilenameWithoutExtension(filname string) string {
@@ -220,25 +1123,35 @@ func getVersion(respBody []byte) (version string, err error) {
 	return version, nil
 }
 
-func getServiceAttachment(respBody []byte) (sa string, err error) {
-	var jsonMap map[string]string
+// getServiceAttachment returns the service attachment and the optional description of an endpoint attachment.
+// They are read from the top level of the file or from an endpointAttachment object
+func getServiceAttachment(respBody []byte) (sa string, description string, err error) {
+	var e map[string]interface{}
 
-	if err = json.Unmarshal(respBody, &jsonMap); err != nil {
-		return "", err
+	if err = json.Unmarshal(respBody, &e); err != nil {
+		return "", "", err
+	}
+	locations := []map[string]interface{}{e}
+	if nested, ok := e["endpointAttachment"].(map[string]interface{}); ok {
+		locations = append(locations, nested)
 	}
-	if jsonMap["serviceAttachment"] == "" {
-		return "", errors.New("serviceAttachment not found")
+	for _, l := range locations {
+		if sa, _ = l["serviceAttachment"].(string); sa != "" {
+			description, _ = l["description"].(string)
+			return sa, description, nil
+		}
 	}
-	return jsonMap["serviceAttachment"], nil
+	return "", "", errors.New("serviceAttachment not found, looked in serviceAttachment and endpointAttachment.serviceAttachment")
 }
 
 func processAuthConfigs(authconfigFolder string) (err error) {
 	var stat fs.FileInfo
 	rJSONFiles := regexp.MustCompile(`(\S*)\.json`)
+	fullEncryptionKey := path.Join("projects", apiclient.GetProjectID(), encryptionKey)
 
 	if stat, err = os.Stat(authconfigFolder); err == nil && stat.IsDir() {
 		// create any authconfigs
-		err = filepath.Walk(authconfigFolder, func(path string, info os.FileInfo, err error) error {
+		err = filepath.Walk(authconfigFolder, applyWalkFunc(func(path string, info os.FileInfo, err error) error {
 			if err != nil {
 				return err
 			}
@@ -253,17 +1166,26 @@ func processAuthConfigs(authconfigFolder string) (err error) {
 						if err != nil {
 							return err
 						}
-						clilog.Info.Printf("Creating authconfig: %s\n", authConfigFile)
+						if encryptionKey != "" {
+							// the authconfig file is base64 encoded and encrypted with Cloud KMS
+							if authConfigBytes, err = cloudkms.DecryptSymmetric(fullEncryptionKey, authConfigBytes); err != nil {
+								return fmt.Errorf("unable to decrypt authconfig %s: %w", authConfigFile, err)
+							}
+						}
+						if authConfigBytes, err = authconfigs.ResolveSecrets(authConfigBytes); err != nil {
+							return fmt.Errorf("unable to resolve the secrets of authconfig %s: %w", authConfigFile, err)
+						}
+						clilog.Colored(clilog.Info, clilog.Green).Printf("Creating authconfig: %s\n", authConfigFile)
 						if _, err = authconfigs.Create(authConfigBytes); err != nil {
 							return err
 						}
-					} else {
-						clilog.Info.Printf("Authconfig %s already exists\n", authConfigFile)
+					} else if err = resourceExists("Authconfig", authConfigFile); err != nil {
+						return err
 					}
 				}
 			}
 			return nil
-		})
+		}))
 		if err != nil {
 			return err
 		}
@@ -271,41 +1193,65 @@ func processAuthConfigs(authconfigFolder string) (err error) {
 	return nil
 }
 
-func processEndpoints(endpointsFolder string) (err error) {
+func processEndpoints(endpointsFolder string, wait bool) (err error) {
 	var stat fs.FileInfo
-	rJSONFiles := regexp.MustCompile(`(\S*)\.json`)
 
 	if stat, err = os.Stat(endpointsFolder); err == nil && stat.IsDir() {
-		// create any endpoint attachments
-		err = filepath.Walk(endpointsFolder, func(path string, info os.FileInfo, err error) error {
+		// create or update any endpoint attachments
+		err = filepath.Walk(endpointsFolder, applyWalkFunc(func(path string, info os.FileInfo, err error) error {
 			if err != nil {
 				return err
 			}
 			if !info.IsDir() {
 				endpointFile := filepath.Base(path)
-				if rJSONFiles.MatchString(endpointFile) {
-					clilog.Info.Printf("Found configuration for endpoint attachment: %s\n", endpointFile)
+				if filepath.Ext(endpointFile) != jsonExt {
+					return nil
+				}
+				clilog.Info.Printf("Found configuration for endpoint attachment: %s\n", endpointFile)
+				endpointName := getFilenameWithoutExtension(endpointFile)
+				endpointBytes, err := utils.ReadFile(path)
+				if err != nil {
+					return err
 				}
-				if !connections.FindEndpoint(getFilenameWithoutExtension(endpointFile)) {
+				serviceAttachment, description, err := getServiceAttachment(endpointBytes)
+				if err != nil {
+					return fmt.Errorf("invalid endpoint attachment %s: %w", endpointFile, err)
+				}
+				if !connections.FindEndpoint(endpointName) {
 					// the endpoint does not exist, try to create it
-					endpointBytes, err := utils.ReadFile(path)
-					if err != nil {
-						return err
-					}
-					serviceAccountName, err := getServiceAttachment(endpointBytes)
-					if err != nil {
-						return err
-					}
-					if _, err = connections.CreateEndpoint(getFilenameWithoutExtension(endpointFile),
-						serviceAccountName, "", false); err != nil {
+					clilog.Colored(clilog.Info, clilog.Green).Printf("Creating endpoint: %s\n", endpointFile)
+					if _, err = connections.CreateEndpoint(endpointName, serviceAttachment, description, wait); err != nil {
 						return err
 					}
-				} else {
-					clilog.Info.Printf("Endpoint %s already exists\n", endpointFile)
+					return nil
+				}
+				// the endpoint exists, update it if the configuration changed
+				if failIfExists {
+					return resourceExists("Endpoint", endpointFile)
+				}
+				respBody, err := connections.GetEndpoint(endpointName, true)
+				if err != nil {
+					return err
+				}
+				deployedAttachment, deployedDescription, err := getServiceAttachment(respBody)
+				if err != nil {
+					return err
+				}
+				// the service attachment of an endpoint attachment cannot be changed
+				if deployedAttachment != serviceAttachment {
+					return fmt.Errorf("endpoint %s is attached to %s, delete it to attach it to %s",
+						endpointName, deployedAttachment, serviceAttachment)
+				}
+				if deployedDescription == description {
+					return resourceExists("Endpoint", endpointFile)
+				}
+				clilog.Colored(clilog.Info, clilog.Green).Printf("Updating the description of endpoint %s\n", endpointName)
+				if _, err = connections.UpdateEndpointDescription(endpointName, description); err != nil {
+					return err
 				}
 			}
 			return nil
-		})
+		}))
 		if err != nil {
 			return err
 		}
@@ -317,10 +1263,9 @@ func processManagedZones(zonesFolder string) (err error) {
 	var stat fs.FileInfo
 	rJSONFiles := regexp.MustCompile(`(\S*)\.json`)
 
-	// create any managed zones
+	// create or update any managed zones
 	if stat, err = os.Stat(zonesFolder); err == nil && stat.IsDir() {
-		// create any managedzones
-		err = filepath.Walk(zonesFolder, func(path string, info os.FileInfo, err error) error {
+		err = filepath.Walk(zonesFolder, applyWalkFunc(func(path string, info os.FileInfo, err error) error {
 			if err != nil {
 				return err
 			}
@@ -329,22 +1274,42 @@ func processManagedZones(zonesFolder string) (err error) {
 				if rJSONFiles.MatchString(zoneFile) {
 					clilog.Info.Printf("Found configuration for managed zone: %s\n", zoneFile)
 				}
-				if _, err = connections.GetZone(getFilenameWithoutExtension(zoneFile), true); err != nil {
+				zoneName := getFilenameWithoutExtension(zoneFile)
+				zoneBytes, err := utils.ReadFile(path)
+				if err != nil {
+					return err
+				}
+				if zoneBytes, err = mergeLabels(zoneBytes, labelList); err != nil {
+					return fmt.Errorf("invalid managed zone %s: %w", zoneFile, err)
+				}
+				respBody, err := connections.GetZone(zoneName, true)
+				if err != nil {
 					// the managed zone does not exist, try to create it
-					zoneBytes, err := utils.ReadFile(path)
-					if err != nil {
-						return err
-					}
-					if _, err = connections.CreateZone(getFilenameWithoutExtension(zoneFile),
-						zoneBytes); err != nil {
+					clilog.Colored(clilog.Info, clilog.Green).Printf("Creating zone: %s\n", zoneFile)
+					if _, err = connections.CreateZone(zoneName, zoneBytes); err != nil {
 						return err
 					}
-				} else {
-					clilog.Info.Printf("Zone %s already exists\n", zoneFile)
+					return nil
+				}
+				// the managed zone exists, reconcile it with the local configuration
+				if failIfExists {
+					return resourceExists("Zone", zoneFile)
+				}
+				updateMask, diff, err := connections.DiffZone(respBody, zoneBytes)
+				if err != nil {
+					return err
+				}
+				if len(updateMask) == 0 {
+					return resourceExists("Zone", zoneFile)
+				}
+				clilog.Warning.Printf("Zone %s differs from the deployed configuration:\n%s\n",
+					zoneName, strings.Join(diff, "\n"))
+				if _, err = connections.UpdateZone(zoneName, zoneBytes, updateMask); err != nil {
+					return err
 				}
 			}
 			return nil
-		})
+		}))
 		if err != nil {
 			return err
 		}
@@ -352,28 +1317,47 @@ func processManagedZones(zonesFolder string) (err error) {
 	return nil
 }
 
-func processConnectors(connectorsFolder string, grantPermission bool, createSecret bool, wait bool) (err error) {
+func processConnectors(connectorsFolder string, grantPermission bool, createSecret bool, wait bool,
+	waitTimeout time.Duration,
+) (err error) {
 	var stat fs.FileInfo
 	rJSONFiles := regexp.MustCompile(`(\S*)\.json`)
 
 	if stat, err = os.Stat(connectorsFolder); err == nil && stat.IsDir() {
 		// create any connectors
-		err = filepath.Walk(connectorsFolder, func(path string, info os.FileInfo, err error) error {
+		err = filepath.Walk(connectorsFolder, applyWalkFunc(func(path string, info os.FileInfo, err error) error {
 			if err != nil {
 				return err
 			}
+			// the event subscriptions are created once the connections exist
+			if info.IsDir() && filepath.Base(path) == subscriptionsFolderName {
+				return filepath.SkipDir
+			}
 			if !info.IsDir() {
+				var connectionBytes []byte
 				connectionFile := filepath.Base(path)
+				if strings.HasSuffix(connectionFile, connectorTemplateExt) {
+					if connectionFile, connectionBytes, err = renderConnectorTemplate(path); err != nil {
+						return err
+					}
+				}
 				if rJSONFiles.MatchString(connectionFile) {
 					clilog.Info.Printf("Found configuration for connection: %s\n", connectionFile)
 					_, err = connections.Get(getFilenameWithoutExtension(connectionFile), "", true, false)
 					// create the connection only if the connection is not found
 					if err != nil {
-						connectionBytes, err := utils.ReadFile(path)
-						if err != nil {
-							return err
+						if connectionBytes == nil {
+							if connectionBytes, err = utils.ReadFile(path); err != nil {
+								return err
+							}
+						}
+						if connectionBytes, err = mergeLabels(connectionBytes, labelList); err != nil {
+							return fmt.Errorf("invalid connection %s: %w", connectionFile, err)
 						}
-						clilog.Info.Printf("Creating connector: %s\n", connectionFile)
+						if connectionBytes, err = mergeNodeConfig(connectionBytes, minNodes, maxNodes); err != nil {
+							return fmt.Errorf("invalid connection %s: %w", connectionFile, err)
+						}
+						clilog.Colored(clilog.Info, clilog.Green).Printf("Creating connector: %s\n", connectionFile)
 
 						if _, err = connections.Create(getFilenameWithoutExtension(connectionFile),
 							connectionBytes,
@@ -382,16 +1366,17 @@ func processConnectors(connectorsFolder string, grantPermission bool, createSecr
 							encryptionKey,
 							grantPermission,
 							createSecret,
-							wait); err != nil {
+							wait,
+							waitTimeout); err != nil {
 							return err
 						}
-					} else {
-						clilog.Info.Printf("Connector %s already exists\n", connectionFile)
+					} else if err = resourceExists("Connector", connectionFile); err != nil {
+						return err
 					}
 				}
 			}
 			return nil
-		})
+		}))
 		if err != nil {
 			return err
 		}
@@ -399,61 +1384,200 @@ func processConnectors(connectorsFolder string, grantPermission bool, createSecr
 	return nil
 }
 
-func processCustomConnectors(customConnectorsFolder string) (err error) {
+// connectorTemplateExt is the extension of the connector files that are go templates,
+// rendered with the values file before they are applied
+const connectorTemplateExt = ".json.tmpl"
+
+// templateValues holds the values of the connector templates
+var templateValues map[string]interface{}
+
+// readTemplateValues reads the YAML values file of the connector templates
+func readTemplateValues(valuesFile string) (values map[string]interface{}, err error) {
+	contents, err := utils.ReadFile(valuesFile)
+	if err != nil {
+		return nil, err
+	}
+	if err = yaml.Unmarshal(contents, &values); err != nil {
+		return nil, fmt.Errorf("invalid values file %s: %w", valuesFile, err)
+	}
+	return values, nil
+}
+
+// renderTemplate executes the go template with the template values. A value missing
+// from the values file is an error
+func renderTemplate(name string, text string) ([]byte, error) {
+	t, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	var b bytes.Buffer
+	if err = t.Execute(&b, templateValues); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// getConnectorTemplateName returns the connection name of a connector template, the file
+// name is a template too
+func getConnectorTemplateName(templateFile string) (string, error) {
+	name, err := renderTemplate(templateFile, strings.TrimSuffix(filepath.Base(templateFile), connectorTemplateExt))
+	if err != nil {
+		return "", fmt.Errorf("unable to render connector template name: %w", err)
+	}
+	return string(name), nil
+}
+
+// renderConnectorTemplate renders the connector template and returns the JSON file
+// name and contents it stands for
+func renderConnectorTemplate(templateFile string) (connectionFile string, connectionBytes []byte, err error) {
+	name, err := getConnectorTemplateName(templateFile)
+	if err != nil {
+		return "", nil, err
+	}
+	contents, err := utils.ReadFile(templateFile)
+	if err != nil {
+		return "", nil, err
+	}
+	if connectionBytes, err = renderTemplate(templateFile, string(contents)); err != nil {
+		return "", nil, fmt.Errorf("unable to render connector template: %w", err)
+	}
+	if !json.Valid(connectionBytes) {
+		return "", nil, fmt.Errorf("connector template %s did not render to valid JSON", templateFile)
+	}
+	return name + jsonExt, connectionBytes, nil
+}
+
+// subscriptionsFolderName is the folder of the connectors folder holding the event subscriptions
+const subscriptionsFolderName = "subscriptions"
+
+// processEventSubscriptions creates the event subscriptions of the connections, named
+// connectionName<file splitter>subscriptionId.json. The connections must be active
+func processEventSubscriptions(subscriptionsFolder string) (err error) {
 	var stat fs.FileInfo
-	var fileSplitter string
-	rJSONFiles := regexp.MustCompile(`(\S*)\.json`)
+	rJSONFiles := regexp.MustCompile(`(\S*)\.json$`)
 
-	if useUnderscore {
-		fileSplitter = utils.LegacyFileSplitter
-	} else {
-		fileSplitter = utils.DefaultFileSplitter
+	if stat, err = os.Stat(subscriptionsFolder); err != nil || !stat.IsDir() {
+		return nil
+	}
+	return filepath.Walk(subscriptionsFolder, applyWalkFunc(func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || !rJSONFiles.MatchString(filepath.Base(path)) {
+			return nil
+		}
+		subscriptionFile := filepath.Base(path)
+		clilog.Info.Printf("Found configuration for event subscription: %s\n", subscriptionFile)
+		connectionName, subscriptionId, err := getEventSubscriptionNames(subscriptionFile)
+		if err != nil {
+			return err
+		}
+		// create the event subscription only if it is not found
+		if _, err = connections.GetEventSubscription(subscriptionId, connectionName, false); err == nil {
+			return resourceExists("event subscription", subscriptionFile)
+		}
+		state, err := connections.GetState(connectionName)
+		if err != nil {
+			return err
+		}
+		if state != "ACTIVE" {
+			return fmt.Errorf("connection %s is %s, event subscription %s can only be created "+
+				"once it is ACTIVE; apply with --wait", connectionName, state, subscriptionFile)
+		}
+		subscriptionBytes, err := utils.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		clilog.Colored(clilog.Info, clilog.Green).Printf("Creating event subscription: %s\n", subscriptionFile)
+		_, err = connections.CreateEventSubscription(connectionName, subscriptionId, subscriptionBytes)
+		return err
+	}))
+}
+
+// getEventSubscriptionNames returns the connection name and subscription id of the event subscription
+// file, which is named connectionName<file splitter>subscriptionId.json
+func getEventSubscriptionNames(subscriptionFile string) (connectionName string, subscriptionId string, err error) {
+	names := strings.Split(getFilenameWithoutExtension(subscriptionFile), fileSplitter)
+	if len(names) != 2 || names[0] == "" || names[1] == "" {
+		return "", "", fmt.Errorf("event subscription file %s does not follow the naming "+
+			"convention connectionName%ssubscriptionId.json", subscriptionFile, fileSplitter)
 	}
+	return names[0], names[1], nil
+}
+
+func processCustomConnectors(customConnectorsFolder string) (err error) {
+	var stat fs.FileInfo
+	rJSONFiles := regexp.MustCompile(`(\S*)\.json`)
 
 	if stat, err = os.Stat(customConnectorsFolder); err == nil && stat.IsDir() {
 		// create any custom connectors
-		err = filepath.Walk(customConnectorsFolder, func(path string, info os.FileInfo, err error) error {
+		err = filepath.Walk(customConnectorsFolder, applyWalkFunc(func(path string, info os.FileInfo, err error) error {
 			if err != nil {
 				return err
 			}
 			if !info.IsDir() {
 				customConnectionFile := filepath.Base(path)
 				if rJSONFiles.MatchString(customConnectionFile) {
-					customConnectionDetails := strings.Split(strings.TrimSuffix(customConnectionFile, filepath.Ext(customConnectionFile)), fileSplitter)
-					// the file format is name-version.json
-					if len(customConnectionDetails) == 2 {
-						clilog.Info.Printf("Found configuration for custom connection: %v\n", customConnectionFile)
-						contents, err := utils.ReadFile(path)
-						if err != nil {
+					// the file format is name<splitter>version.json; the name may contain the splitter
+					connectionName, connectionVersion, found := splitOnLast(getFilenameWithoutExtension(customConnectionFile), fileSplitter)
+					if !found || connectionName == "" || connectionVersion == "" {
+						clilog.Warning.Printf("custom connector file %s does not follow the naming "+
+							"convention name%sversion.json\n", customConnectionFile, fileSplitter)
+						return nil
+					}
+					clilog.Info.Printf("Found configuration for custom connection: %v\n", customConnectionFile)
+					contents, err := utils.ReadFile(path)
+					if err != nil {
+						return err
+					}
+					if _, err := connections.GetCustomVersion(connectionName,
+						connectionVersion, false); err != nil {
+						// didn't find the custom connector, create it
+						clilog.Colored(clilog.Info, clilog.Green).Printf("Creating custom connector: %s\n", customConnectionFile)
+						if err = connections.CreateCustomWithVersion(connectionName,
+							connectionVersion, contents, serviceAccountName, serviceAccountProject); err != nil {
 							return err
 						}
-						clilog.Info.Printf("Creating custom connector: %s\n", customConnectionFile)
-						if _, err := connections.GetCustomVersion(customConnectionDetails[0],
-							customConnectionDetails[1], false); err != nil {
-							// didn't find the custom connector, create it
-							if err = connections.CreateCustomWithVersion(customConnectionDetails[0],
-								customConnectionDetails[1], contents, serviceAccountName, serviceAccountProject); err != nil {
-								return err
-							}
-						} else {
-							clilog.Info.Printf("Custom Connector %s already exists\n", customConnectionFile)
-						}
+					} else if err = resourceExists("Custom Connector", customConnectionFile); err != nil {
+						return err
 					}
 				}
 			}
 			return nil
-		})
+		}))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resourceExists logs that the resource in the file already exists, or returns an error
+// when fail-if-exists is set
+func resourceExists(kind string, file string) error {
+	if failIfExists {
+		return fmt.Errorf("%s %s already exists and --fail-if-exists is set", kind, file)
 	}
+	clilog.Colored(clilog.Info, clilog.Yellow).Printf("%s %s already exists\n", kind, file)
 	return nil
 }
 
+// splitOnLast splits s around the last occurrence of sep
+func splitOnLast(s string, sep string) (before string, after string, found bool) {
+	i := strings.LastIndex(s, sep)
+	if i < 0 {
+		return s, "", false
+	}
+	return s[:i], s[i+len(sep):], true
+}
+
 func processSfdcInstances(sfdcinstancesFolder string) (err error) {
 	var stat fs.FileInfo
 	rJSONFiles := regexp.MustCompile(`(\S*)\.json`)
 
 	if stat, err = os.Stat(sfdcinstancesFolder); err == nil && stat.IsDir() {
 		// create any sfdc instances
-		err = filepath.Walk(sfdcinstancesFolder, func(path string, info os.FileInfo, err error) error {
+		err = filepath.Walk(sfdcinstancesFolder, applyWalkFunc(func(path string, info os.FileInfo, err error) error {
 			if err != nil {
 				return err
 			}
@@ -468,18 +1592,18 @@ func processSfdcInstances(sfdcinstancesFolder string) (err error) {
 						if err != nil {
 							return err
 						}
-						clilog.Info.Printf("Creating sfdc instance: %s\n", instanceFile)
+						clilog.Colored(clilog.Info, clilog.Green).Printf("Creating sfdc instance: %s\n", instanceFile)
 						_, err = sfdc.CreateInstanceFromContent(instanceBytes)
 						if err != nil {
-							return nil
+							return err
 						}
-					} else {
-						clilog.Info.Printf("sfdc instance %s already exists\n", instanceFile)
+					} else if err = resourceExists("sfdc instance", instanceFile); err != nil {
+						return err
 					}
 				}
 			}
 			return nil
-		})
+		}))
 		if err != nil {
 			return err
 		}
@@ -489,19 +1613,11 @@ func processSfdcInstances(sfdcinstancesFolder string) (err error) {
 
 func processSfdcChannels(sfdcchannelsFolder string) (err error) {
 	var stat fs.FileInfo
-	var fileSplitter string
 	rJSONFiles := regexp.MustCompile(`(\S*)\.json`)
-	const sfdcNamingConvention = 2 // when file is split with _, the result must be 2
-
-	if useUnderscore {
-		fileSplitter = utils.LegacyFileSplitter
-	} else {
-		fileSplitter = utils.DefaultFileSplitter
-	}
 
 	if stat, err = os.Stat(sfdcchannelsFolder); err == nil && stat.IsDir() {
 		// create any sfdc channels
-		err = filepath.Walk(sfdcchannelsFolder, func(path string, info os.FileInfo, err error) error {
+		err = filepath.Walk(sfdcchannelsFolder, applyWalkFunc(func(path string, info os.FileInfo, err error) error {
 			if err != nil {
 				return err
 			}
@@ -509,11 +1625,9 @@ func processSfdcChannels(sfdcchannelsFolder string) (err error) {
 				channelFile := filepath.Base(path)
 				if rJSONFiles.MatchString(channelFile) {
 					clilog.Info.Printf("Found configuration for sfdc channel: %s\n", channelFile)
-					sfdcNames := strings.Split(getFilenameWithoutExtension(channelFile), fileSplitter)
-					if len(sfdcNames) != sfdcNamingConvention {
-						clilog.Warning.Printf("sfdc chanel file %s does not follow the naming "+
-							"convention instanceName_channelName.json\n", channelFile)
-						return nil
+					sfdcNames, err := getSfdcChannelNames(channelFile)
+					if err != nil {
+						return err
 					}
 					version, _, err := sfdc.FindChannel(sfdcNames[1], sfdcNames[0])
 					// create the instance only if the sfdc channel is not found
@@ -522,18 +1636,18 @@ func processSfdcChannels(sfdcchannelsFolder string) (err error) {
 						if err != nil {
 							return err
 						}
-						clilog.Info.Printf("Creating sfdc channel: %s\n", channelFile)
+						clilog.Colored(clilog.Info, clilog.Green).Printf("Creating sfdc channel: %s\n", channelFile)
 						_, err = sfdc.CreateChannelFromContent(version, channelBytes)
 						if err != nil {
-							return nil
+							return err
 						}
-					} else {
-						clilog.Info.Printf("sfdc channel %s already exists\n", channelFile)
+					} else if err = resourceExists("sfdc channel", channelFile); err != nil {
+						return err
 					}
 				}
 			}
 			return nil
-		})
+		}))
 		if err != nil {
 			return err
 		}
@@ -541,43 +1655,36 @@ func processSfdcChannels(sfdcchannelsFolder string) (err error) {
 	return nil
 }
 
-func processIntegration(overridesFile string, integrationFolder string, testsFolder string,
+// getSfdcChannelNames returns the instance and channel names of the sfdc channel file, which
+// is named instanceName<file splitter>channelName.json
+func getSfdcChannelNames(channelFile string) ([]string, error) {
+	const sfdcNamingConvention = 2 // when file is split with the file splitter, the result must be 2
+	sfdcNames := strings.Split(getFilenameWithoutExtension(channelFile), fileSplitter)
+	if len(sfdcNames) != sfdcNamingConvention {
+		return nil, fmt.Errorf("sfdc channel file %s does not follow the naming "+
+			"convention instanceName%schannelName.json", channelFile, fileSplitter)
+	}
+	return sfdcNames, nil
+}
+
+func processIntegration(overridesFiles []string, integrationFolder string, testsFolder string,
 	configVarsFolder string, testConfigFolder string, pipeline string, userLabel string, grantPermission bool,
 	runTests bool,
 ) (err error) {
-	rJSONFiles := regexp.MustCompile(`(\S*)\.json$`)
-
-	var integrationNames []string
 	var overridesBytes []byte
 
 	javascriptFolder := path.Join(integrationFolder, "javascript")
 	jsonnetFolder := path.Join(integrationFolder, "datatransformer")
 
-	if _, err = os.Stat(overridesFile); err == nil {
-		overridesBytes, err = utils.ReadFile(overridesFile)
-		if err != nil {
-			return err
-		}
-	}
-
-	if len(overridesBytes) > 0 {
-		clilog.Info.Printf("Found overrides file %s\n", overridesFile)
+	if overridesBytes, err = readOverrides(overridesFiles); err != nil {
+		return err
 	}
 
 	// get the integration file
-	_ = filepath.Walk(integrationFolder, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return err
-		}
-		if !info.IsDir() {
-			integrationFile := filepath.Base(path)
-			if rJSONFiles.MatchString(integrationFile) {
-				clilog.Info.Printf("Found configuration for integration: %s\n", integrationFile)
-				integrationNames = append(integrationNames, integrationFile)
-			}
-		}
-		return nil
-	})
+	integrationNames := getIntegrationFiles(integrationFolder)
+	for _, integrationFile := range integrationNames {
+		clilog.Info.Printf("Found configuration for integration: %s\n", integrationFile)
+	}
 
 	if len(integrationNames) > 0 {
 		// get only the first file
@@ -585,6 +1692,22 @@ func processIntegration(overridesFile string, integrationFolder string, testsFol
 		if err != nil {
 			return err
 		}
+		if lintBeforeApply {
+			// lint the integration as it is created, with the overrides merged
+			lintBytes := integrationBytes
+			if len(overridesBytes) > 0 {
+				if lintBytes, err = integrations.ApplyOverrides(integrationBytes, overridesBytes); err != nil {
+					return err
+				}
+			}
+			findings, err := integrations.Lint(lintBytes, javascriptFolder, true)
+			if err != nil {
+				return err
+			}
+			if err = checkLintFindings(integrationNames[0], findings); err != nil {
+				return err
+			}
+		}
 		// check for code files
 		codeMap, err := processCodeFolders(javascriptFolder, jsonnetFolder)
 		if err != nil {
@@ -598,7 +1721,7 @@ func processIntegration(overridesFile string, integrationFolder string, testsFol
 			}
 		}
 
-		clilog.Info.Printf("Create integration %s\n", getFilenameWithoutExtension(integrationNames[0]))
+		clilog.Colored(clilog.Info, clilog.Green).Printf("Create integration %s\n", getFilenameWithoutExtension(integrationNames[0]))
 		respBody, err := integrations.CreateVersion(getFilenameWithoutExtension(integrationNames[0]),
 			integrationBytes, overridesBytes, "", userLabel, grantPermission, false)
 		if err != nil {
@@ -610,37 +1733,33 @@ func processIntegration(overridesFile string, integrationFolder string, testsFol
 		}
 
 		// create  test cases for integration
-		if err = processTestCases(testsFolder, getFilenameWithoutExtension(integrationNames[0]), version); err != nil {
+		if skipTestCases {
+			clilog.Colored(clilog.Info, clilog.Yellow).Printf("Skipping applying test cases\n")
+		} else if err = processTestCases(testsFolder, getFilenameWithoutExtension(integrationNames[0]), version); err != nil {
 			return err
 		}
 
-		// publish the integration
-		clilog.Info.Printf("Publish integration %s with version %s\n",
-			getFilenameWithoutExtension(integrationNames[0]), version)
-		// read any config variables
-		configVarsFile := path.Join(configVarsFolder, getFilenameWithoutExtension(integrationNames[0])+"-config.json")
-		var configVarBytes []byte
-		if _, err = os.Stat(configVarsFile); err == nil {
-			configVarBytes, err = utils.ReadFile(configVarsFile)
-			if err != nil {
-				return err
-			}
-		}
-		_, err = integrations.Publish(getFilenameWithoutExtension(integrationNames[0]), version, configVarBytes)
-		if err != nil {
+		resultStatus := "SUCCEEDED"
+		if noPublish {
+			clilog.Colored(clilog.Info, clilog.Yellow).Printf("Skipping publish, integration %s version %s was created as a draft\n",
+				getFilenameWithoutExtension(integrationNames[0]), version)
+			resultStatus = "CREATED_DRAFT"
+		} else if err = publishIntegration(getFilenameWithoutExtension(integrationNames[0]), version,
+			configVarsFolder, userLabel, integrationBytes); err != nil {
 			return err
 		}
+		applyProgress.step(integrationNames[0])
 
 		// Execute test cases
 		if runTests {
-			err = executeAllTestCases(testConfigFolder, getFilenameWithoutExtension(integrationNames[0]), version)
+			err = executeAllTestCases(testConfigFolder, getFilenameWithoutExtension(integrationNames[0]), version, "", "", 1, continueOnError)
 			if err != nil {
 				return err
 			}
 		}
 
 		if pipeline != "" {
-			err = apiclient.WriteResultsFile(outputGCSPath, "SUCCEEDED")
+			err = apiclient.WriteResultsFile(outputGCSPath, resultStatus)
 		}
 		return err
 	}
@@ -648,12 +1767,81 @@ func processIntegration(overridesFile string, integrationFolder string, testsFol
 	return nil
 }
 
+// getIntegrationFiles returns the names of the integration files in the folder, apply uses the first one
+func getIntegrationFiles(integrationFolder string) (integrationNames []string) {
+	rJSONFiles := regexp.MustCompile(`(\S*)\.json$`)
+	_ = filepath.Walk(integrationFolder, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			integrationFile := filepath.Base(path)
+			if rJSONFiles.MatchString(integrationFile) {
+				integrationNames = append(integrationNames, integrationFile)
+			}
+		}
+		return nil
+	})
+	return integrationNames
+}
+
+// publishIntegration publishes the version with the config variables of the integration
+func publishIntegration(name string, version string, configVarsFolder string, userLabel string,
+	integrationBytes []byte,
+) (err error) {
+	clilog.Info.Printf("Publish integration %s with version %s\n", name, version)
+	// read any config variables
+	configVarsFile := path.Join(configVarsFolder, name+"-config.json")
+	var configVarBytes []byte
+	if _, err = os.Stat(configVarsFile); err == nil {
+		configVarBytes, err = utils.ReadFile(configVarsFile)
+		if err != nil {
+			return err
+		}
+		if configVarBytes, err = utils.InterpolateEnv(configVarBytes); err != nil {
+			return fmt.Errorf("unable to interpolate config variables file %s: %w", configVarsFile, err)
+		}
+	}
+	if len(setConfigVarList) > 0 {
+		types, err := integrations.GetConfigVariableTypes(integrationBytes)
+		if err != nil {
+			return err
+		}
+		if configVarBytes, err = mergeConfigVars(configVarBytes, setConfigVarList, types); err != nil {
+			return err
+		}
+	}
+	unused, missing, err := integrations.CheckConfigVariables(integrationBytes, configVarBytes)
+	if err != nil {
+		return err
+	}
+	for _, key := range unused {
+		clilog.Warning.Printf("config variable %s is not used by integration %s\n", key, name)
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("integration %s has no value for the config variables %s; set them in %s or with --set-config-var",
+			name, strings.Join(missing, ", "), configVarsFile)
+	}
+	_, err = integrations.PublishWithRetry(name, version, userLabel, "", configVarBytes)
+	return err
+}
+
+// code files are named after the id of the task they belong to, see writeCodeFiles
+const (
+	javascriptFilePrefix = "javascript_"
+	jsonnetFilePrefix    = "datatransformer_"
+)
+
+var (
+	rTaskId          = regexp.MustCompile(`^\d+$`)
+	rJavaScriptFiles = regexp.MustCompile(`^javascript_\d+\.js$`)
+	rJsonnetFiles    = regexp.MustCompile(`^datatransformer_\d+\.jsonnet$`)
+)
+
 func processCodeFolders(javascriptFolder string, jsonnetFolder string) (codeMap map[string]map[string]string, err error) {
 	codeMap = make(map[string]map[string]string)
 	codeMap["JavaScriptTask"] = make(map[string]string)
 	codeMap["JsonnetMapperTask"] = make(map[string]string)
-	rJavaScriptFiles := regexp.MustCompile(`javascript_\d{1,2}.js`)
-	rJsonnetFiles := regexp.MustCompile(`datatransformer_\d{1,2}.jsonnet`)
 	var javascriptNames, jsonnetNames []string
 
 	_ = filepath.Walk(javascriptFolder, func(path string, info os.FileInfo, err error) error {
@@ -676,7 +1864,7 @@ func processCodeFolders(javascriptFolder string, jsonnetFolder string) (codeMap
 			if err != nil {
 				return nil, err
 			}
-			codeMap["JavaScriptTask"][strings.ReplaceAll(getFilenameWithoutExtension(javascriptName), "javascript_", "")] = strings.ReplaceAll(string(javascriptBytes), "\n", "\\n")
+			codeMap["JavaScriptTask"][strings.ReplaceAll(getFilenameWithoutExtension(javascriptName), javascriptFilePrefix, "")] = string(javascriptBytes)
 		}
 	}
 
@@ -696,11 +1884,17 @@ func processCodeFolders(javascriptFolder string, jsonnetFolder string) (codeMap
 
 	if len(jsonnetNames) > 0 {
 		for _, jsonnetName := range jsonnetNames {
-			jsonnetBytes, err := utils.ReadFile(path.Join(jsonnetFolder, jsonnetName))
-			if err != nil {
+			var jsonnetBytes []byte
+			if evaluateJsonnet {
+				jsonnet, err := inlineJsonnetImports(path.Join(jsonnetFolder, jsonnetName))
+				if err != nil {
+					return nil, err
+				}
+				jsonnetBytes = []byte(jsonnet)
+			} else if jsonnetBytes, err = utils.ReadFile(path.Join(jsonnetFolder, jsonnetName)); err != nil {
 				return nil, err
 			}
-			codeMap["JsonnetMapperTask"][strings.ReplaceAll(getFilenameWithoutExtension(jsonnetName), "datatransformer_", "")] = strings.ReplaceAll(string(jsonnetBytes), "\n", "\\n")
+			codeMap["JsonnetMapperTask"][strings.ReplaceAll(getFilenameWithoutExtension(jsonnetName), jsonnetFilePrefix, "")] = string(jsonnetBytes)
 		}
 	}
 
@@ -739,10 +1933,15 @@ func processTestCases(testsFolder string, integrationName string, version string
 			if err != nil {
 				return err
 			}
-			_, err = integrations.CreateTestCase(integrationName, version, string(testCaseBytes))
+			respBody, err := integrations.CreateTestCase(integrationName, version, string(testCaseBytes))
 			if err != nil {
 				return err
 			}
+			if testCaseID, displayName, err := integrations.GetTestCaseID(respBody); err != nil {
+				clilog.Warning.Printf("Unable to read the test case id created from file %s: %v\n", testCaseFile, err)
+			} else {
+				clilog.Info.Printf("Created test case %s with id %s from file %s\n", displayName, testCaseID, testCaseFile)
+			}
 		}
 	}
 	return nil
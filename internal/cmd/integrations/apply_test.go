@@ -0,0 +1,857 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integrations
+
+import (
+	"errors"
+	"internal/apiclient"
+	"internal/client/integrations"
+	"internal/cmd/utils"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// an invalid payload makes the sfdc create calls fail before reaching the API
+const invalidSfdcPayload = `{"displayName":`
+
+// setupApplyTest sends the API requests through a local proxy that rejects them, so the
+// tests do not reach the APIs
+func setupApplyTest(t *testing.T) string {
+	apiclient.NewIntegrationClient(apiclient.IntegrationClientOptions{
+		SkipCache: true,
+		NoOutput:  true,
+	})
+	apiclient.SetIntegrationToken("test-token")
+	_ = apiclient.SetProjectID("test-project")
+	_ = apiclient.SetRegion("us-west1")
+
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	apiclient.SetProxyURL(proxy.URL)
+	t.Cleanup(func() {
+		apiclient.SetProxyURL("")
+		proxy.Close()
+	})
+	return t.TempDir()
+}
+
+func TestProcessSfdcInstancesCreateError(t *testing.T) {
+	folder := setupApplyTest(t)
+	if err := os.WriteFile(path.Join(folder, "instance.json"), []byte(invalidSfdcPayload), 0o644); err != nil {
+		t.Fatalf("unable to write sfdc instance: %v", err)
+	}
+	if err := processSfdcInstances(folder); err == nil {
+		t.Fatalf("processSfdcInstances succeeded, expected the create error to be returned")
+	}
+}
+
+func TestProcessSfdcChannelsCreateError(t *testing.T) {
+	folder := setupApplyTest(t)
+	fileSplitter = utils.DefaultFileSplitter
+	if err := os.WriteFile(path.Join(folder, "instance__channel.json"), []byte(invalidSfdcPayload), 0o644); err != nil {
+		t.Fatalf("unable to write sfdc channel: %v", err)
+	}
+	if err := processSfdcChannels(folder); err == nil {
+		t.Fatalf("processSfdcChannels succeeded, expected the create error to be returned")
+	}
+}
+
+func TestProcessEndpointsSkipsOtherFiles(t *testing.T) {
+	folder := setupApplyTest(t)
+	if err := os.WriteFile(path.Join(folder, "README.md"), []byte("# endpoints"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := processEndpoints(folder, false); err != nil {
+		t.Errorf("processEndpoints() error = %v, want the file without the json extension skipped", err)
+	}
+}
+
+func TestProcessSfdcChannelsNamingConvention(t *testing.T) {
+	fileSplitter = utils.DefaultFileSplitter
+	tests := []struct {
+		name         string
+		channelFile  string
+		namingErrors bool
+	}{
+		{"zero splitters", "channel.json", true},
+		{"one splitter", "instance__channel.json", false},
+		{"three splitters", "instance__channel__a__b.json", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			folder := setupApplyTest(t)
+			if err := os.WriteFile(path.Join(folder, tt.channelFile), []byte(invalidSfdcPayload), 0o644); err != nil {
+				t.Fatalf("unable to write sfdc channel: %v", err)
+			}
+			err := processSfdcChannels(folder)
+			if err == nil {
+				t.Fatalf("processSfdcChannels succeeded, expected an error")
+			}
+			namingErr := strings.Contains(err.Error(), "naming convention")
+			if namingErr != tt.namingErrors {
+				t.Errorf("processSfdcChannels() error = %v, want naming convention error %t", err, tt.namingErrors)
+			}
+			if tt.namingErrors && !strings.Contains(err.Error(), tt.channelFile) {
+				t.Errorf("processSfdcChannels() error = %v, want the file name %s", err, tt.channelFile)
+			}
+		})
+	}
+}
+
+func TestGetServiceAttachment(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     string
+		sa          string
+		description string
+		wantErr     bool
+	}{
+		{"valid", `{"serviceAttachment":"projects/p/regions/r/serviceAttachments/sa"}`,
+			"projects/p/regions/r/serviceAttachments/sa", "", false},
+		{"with description", `{"serviceAttachment":"sa","description":"private db"}`, "sa", "private db", false},
+		{"nested", `{"endpointAttachment":{"serviceAttachment":"sa","description":"private db"},"labels":{"env":"dev"}}`,
+			"sa", "private db", false},
+		{"object fields", `{"serviceAttachment":"sa","labels":{"env":"dev"}}`, "sa", "", false},
+		{"missing", `{"description":"private db"}`, "", "", true},
+		{"empty", `{"serviceAttachment":""}`, "", "", true},
+		{"invalid", `{"serviceAttachment":`, "", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sa, description, err := getServiceAttachment([]byte(tt.content))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("getServiceAttachment() error = %v, wantErr %t", err, tt.wantErr)
+			}
+			if sa != tt.sa || description != tt.description {
+				t.Errorf("getServiceAttachment() = %q, %q, want %q, %q", sa, description, tt.sa, tt.description)
+			}
+		})
+	}
+}
+
+func TestMergeConfigVarsTypes(t *testing.T) {
+	setupApplyTest(t)
+	types := map[string]string{
+		"`CONFIG_topic`":   "STRING_VALUE",
+		"`CONFIG_retries`": "INT_VALUE",
+		"`CONFIG_enabled`": "BOOLEAN_VALUE",
+	}
+	contents := []byte(`{"` + "`CONFIG_topic`" + `": "old", "` + "`CONFIG_retries`" + `": 1}`)
+
+	merged, err := mergeConfigVars(contents, []string{"topic=new", "CONFIG_retries=3", "enabled=true"}, types)
+	if err != nil {
+		t.Fatalf("mergeConfigVars() error = %v", err)
+	}
+	want := `{"` + "`CONFIG_enabled`" + `":true,"` + "`CONFIG_retries`" + `":3,"` + "`CONFIG_topic`" + `":"new"}`
+	if string(merged) != want {
+		t.Errorf("mergeConfigVars() = %s, want %s", merged, want)
+	}
+
+	if _, err = mergeConfigVars(contents, []string{"retries=three"}, types); err == nil {
+		t.Errorf("mergeConfigVars() succeeded, expected an error for an invalid integer")
+	}
+}
+
+func TestMergeLabels(t *testing.T) {
+	contents := []byte(`{"description":"sample","labels":{"team":"orders"}}`)
+
+	merged, err := mergeLabels(contents, []string{"team=payments", "env=prod"})
+	if err != nil {
+		t.Fatalf("mergeLabels() error = %v", err)
+	}
+	want := `{"description":"sample","labels":{"env":"prod","team":"orders"}}`
+	if string(merged) != want {
+		t.Errorf("mergeLabels() = %s, want %s", merged, want)
+	}
+
+	if _, err = mergeLabels(contents, []string{"env"}); err == nil {
+		t.Errorf("mergeLabels() succeeded, expected an error for a label without a value")
+	}
+}
+
+func TestMergeNodeConfig(t *testing.T) {
+	contents := []byte(`{"description":"sample","nodeConfig":{"maxNodeCount":3,"minNodeCount":1}}`)
+
+	merged, err := mergeNodeConfig(contents, 0, 0)
+	if err != nil || string(merged) != string(contents) {
+		t.Errorf("mergeNodeConfig() = %s, %v, want the contents unchanged", merged, err)
+	}
+
+	if merged, err = mergeNodeConfig(contents, 2, 0); err != nil {
+		t.Fatalf("mergeNodeConfig() error = %v", err)
+	}
+	want := `{"description":"sample","nodeConfig":{"maxNodeCount":3,"minNodeCount":2}}`
+	if string(merged) != want {
+		t.Errorf("mergeNodeConfig() = %s, want %s", merged, want)
+	}
+
+	if merged, err = mergeNodeConfig([]byte(`{"description":"sample"}`), 0, 4); err != nil {
+		t.Fatalf("mergeNodeConfig() error = %v", err)
+	}
+	want = `{"description":"sample","nodeConfig":{"maxNodeCount":4}}`
+	if string(merged) != want {
+		t.Errorf("mergeNodeConfig() = %s, want %s", merged, want)
+	}
+
+	if _, err = mergeNodeConfig(contents, 5, 0); err == nil {
+		t.Errorf("mergeNodeConfig() succeeded, expected an error for a min node count greater than the max")
+	}
+}
+
+func TestMergeOverrides(t *testing.T) {
+	base := []byte(`{"integration_overrides":{"runAsServiceAccount":"base@sa","cloudLoggingDetails":{"enableCloudLogging":false,"cloudLoggingSeverity":"INFO"}},` +
+		`"trigger_overrides":[{"triggerNumber":"1","triggerType":"CLOUD_PUBSUB_EXTERNAL","topicName":"base-topic"},{"triggerNumber":"2","triggerType":"API"}],` +
+		`"task_overrides":[{"taskId":"1","parameters":{"url":{"key":"url","value":{"stringValue":"https://base"}}}}]}`)
+	layer := []byte(`{"integration_overrides":{"cloudLoggingDetails":{"enableCloudLogging":true}},` +
+		`"trigger_overrides":[{"triggerNumber":"1","topicName":"prod-topic"},{"triggerNumber":"3","triggerType":"API"}],` +
+		`"task_overrides":[{"taskId":"1","parameters":{"url":{"key":"url","value":{"stringValue":"https://prod"}}}}]}`)
+
+	merged, err := mergeOverrides(base, layer)
+	if err != nil {
+		t.Fatalf("mergeOverrides() error = %v", err)
+	}
+	want := `{"integration_overrides":{"cloudLoggingDetails":{"cloudLoggingSeverity":"INFO","enableCloudLogging":true},"runAsServiceAccount":"base@sa"},` +
+		`"task_overrides":[{"parameters":{"url":{"key":"url","value":{"stringValue":"https://prod"}}},"taskId":"1"}],` +
+		`"trigger_overrides":[{"topicName":"prod-topic","triggerNumber":"1","triggerType":"CLOUD_PUBSUB_EXTERNAL"},{"triggerNumber":"2","triggerType":"API"},{"triggerNumber":"3","triggerType":"API"}]}`
+	if string(merged) != want {
+		t.Errorf("mergeOverrides() = %s, want %s", merged, want)
+	}
+
+	// arrays that are not overrides arrays are replaced by the layer
+	merged, err = mergeOverrides([]byte(`{"trigger_overrides":[{"triggerNumber":"1","properties":{"a":"1"},"scopes":["a","b"]}]}`),
+		[]byte(`{"trigger_overrides":[{"triggerNumber":"1","scopes":["c"]}]}`))
+	if err != nil {
+		t.Fatalf("mergeOverrides() error = %v", err)
+	}
+	want = `{"trigger_overrides":[{"properties":{"a":"1"},"scopes":["c"],"triggerNumber":"1"}]}`
+	if string(merged) != want {
+		t.Errorf("mergeOverrides() = %s, want %s", merged, want)
+	}
+
+	if merged, err = mergeOverrides(nil, layer); err != nil || string(merged) != string(layer) {
+		t.Errorf("mergeOverrides() = %s, %v, want the layer unchanged", merged, err)
+	}
+	if _, err = mergeOverrides(base, []byte(`{"trigger_overrides":`)); err == nil {
+		t.Errorf("mergeOverrides() succeeded, expected an error for an invalid layer")
+	}
+}
+
+func TestReadOverrides(t *testing.T) {
+	setupApplyTest(t)
+	srcFolder := t.TempDir()
+	for file, contents := range map[string]string{
+		"overrides/overrides.yaml":           "integration_overrides:\n  runAsServiceAccount: base@sa\n  enableVariableMasking: true\n",
+		"prod/overrides/overrides.prod.json": `{"integration_overrides":{"runAsServiceAccount":"prod@sa"}}`,
+	} {
+		if err := os.MkdirAll(path.Dir(path.Join(srcFolder, file)), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path.Join(srcFolder, file), []byte(contents), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	overridesBytes, err := readOverrides(getOverridesFiles(srcFolder, "prod"))
+	if err != nil {
+		t.Fatalf("readOverrides() error = %v", err)
+	}
+	want := `{"integration_overrides":{"enableVariableMasking":true,"runAsServiceAccount":"prod@sa"}}`
+	if string(overridesBytes) != want {
+		t.Errorf("readOverrides() = %s, want %s", overridesBytes, want)
+	}
+
+	if overridesBytes, err = readOverrides(getOverridesFiles(path.Join(srcFolder, "prod"), "")); err != nil || overridesBytes != nil {
+		t.Errorf("readOverrides() = %s, %v, want no overrides", overridesBytes, err)
+	}
+}
+
+func TestResourceExists(t *testing.T) {
+	setupApplyTest(t)
+	defer func() { failIfExists = false }()
+
+	if err := resourceExists("Connector", "gcs.json"); err != nil {
+		t.Errorf("resourceExists() error = %v, want nil", err)
+	}
+	failIfExists = true
+	if err := resourceExists("Connector", "gcs.json"); err == nil || !strings.Contains(err.Error(), "gcs.json") {
+		t.Errorf("resourceExists() error = %v, want an error naming gcs.json", err)
+	}
+}
+
+func TestGetScaffoldNames(t *testing.T) {
+	folder := setupApplyTest(t)
+
+	names, err := getScaffoldNames(path.Join(folder, "connectors"))
+	if err != nil || names != nil {
+		t.Fatalf("getScaffoldNames() = %v, %v, want nil for a missing folder", names, err)
+	}
+
+	if err = os.MkdirAll(path.Join(folder, "connectors"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err = os.WriteFile(path.Join(folder, "connectors", "gcs.json"), []byte(`{}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if names, err = getScaffoldNames(path.Join(folder, "connectors")); err != nil {
+		t.Fatalf("getScaffoldNames() error = %v", err)
+	}
+	if len(names) != 1 || !names["gcs"] {
+		t.Errorf("getScaffoldNames() = %v, want gcs", names)
+	}
+}
+
+func TestReadTargetFile(t *testing.T) {
+	folder := setupApplyTest(t)
+	targetFile := path.Join(folder, "target.json")
+	if err := os.WriteFile(targetFile, []byte(`{"region":"us-west1","project":"my-project"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	region, project, err := readTargetFile(targetFile)
+	if err != nil {
+		t.Fatalf("readTargetFile() error = %v", err)
+	}
+	if region != "us-west1" || project != "my-project" {
+		t.Errorf("readTargetFile() = %s, %s, want us-west1, my-project", region, project)
+	}
+}
+
+const codeIntegration = `{"taskConfigs":[
+	{"task":"JavaScriptTask","taskId":"1","parameters":{"script":{"key":"script","value":{"stringValue":"function executeScript(event) {\n  return \"a\\nb\";\n}"}}}},
+	{"task":"JsonnetMapperTask","taskId":"12","parameters":{"template":{"key":"template","value":{"stringValue":"local a = 1;\n{ a: a }"}}}}]}`
+
+func TestWriteCodeFilesReapply(t *testing.T) {
+	folder := setupApplyTest(t)
+	if err := os.MkdirAll(path.Join(folder, "src"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := writeCodeFiles(folder, []byte(codeIntegration)); err != nil {
+		t.Fatalf("writeCodeFiles() error = %v", err)
+	}
+	for _, f := range []string{"javascript/javascript_1.js", "datatransformer/datatransformer_12.jsonnet"} {
+		if _, err := os.Stat(path.Join(folder, "src", f)); err != nil {
+			t.Errorf("writeCodeFiles() did not write %s: %v", f, err)
+		}
+	}
+
+	codeMap, err := processCodeFolders(path.Join(folder, "src", "javascript"), path.Join(folder, "src", "datatransformer"))
+	if err != nil {
+		t.Fatalf("processCodeFolders() error = %v", err)
+	}
+	withoutCode, err := integrations.RemoveCode([]byte(codeIntegration))
+	if err != nil {
+		t.Fatal(err)
+	}
+	reapplied, err := integrations.SetCode(withoutCode, codeMap)
+	if err != nil {
+		t.Fatalf("SetCode() error = %v", err)
+	}
+
+	want, _ := integrations.GetCode([]byte(codeIntegration))
+	got, err := integrations.GetCode(reapplied)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("re-applied code = %v, want %v", got, want)
+	}
+}
+
+func TestProcessCodeFoldersThreeDigitTaskId(t *testing.T) {
+	folder := setupApplyTest(t)
+	javascriptFolder := path.Join(folder, "javascript")
+	if err := os.MkdirAll(javascriptFolder, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path.Join(javascriptFolder, "javascript_123.js"), []byte("return 123;"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	codeMap, err := processCodeFolders(javascriptFolder, path.Join(folder, "datatransformer"))
+	if err != nil {
+		t.Fatalf("processCodeFolders() error = %v", err)
+	}
+	integrationBytes, err := integrations.SetCode([]byte(`{"taskConfigs":[{"task":"JavaScriptTask","taskId":"123",`+
+		`"parameters":{"script":{"key":"script","value":{"stringValue":""}}}}]}`), codeMap)
+	if err != nil {
+		t.Fatalf("SetCode() error = %v", err)
+	}
+	got, err := integrations.GetCode(integrationBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got["JavaScriptTask"]["123"] != "return 123;" {
+		t.Errorf("SetCode() script for task 123 = %q, want %q", got["JavaScriptTask"]["123"], "return 123;")
+	}
+}
+
+func TestProcessCodeFoldersEvaluateJsonnet(t *testing.T) {
+	folder := setupApplyTest(t)
+	jsonnetFolder := path.Join(folder, "datatransformer")
+	files := map[string]string{
+		"datatransformer_1.jsonnet": "local lib = import 'lib/mapper.libsonnet';\n// import \"ignored.libsonnet\"\n{ name: lib.name, note: importstr \"note.txt\" }",
+		"lib/mapper.libsonnet":      "local common = import \"common.libsonnet\";\n{ name: common.prefix + 'mapper' }",
+		"lib/common.libsonnet":      "{ prefix: 'shared-' }",
+		"note.txt":                  "a \"quoted\" note",
+	}
+	for name, content := range files {
+		if err := os.MkdirAll(path.Dir(path.Join(jsonnetFolder, name)), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path.Join(jsonnetFolder, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	evaluateJsonnet = true
+	defer func() { evaluateJsonnet = false }()
+	codeMap, err := processCodeFolders(path.Join(folder, "javascript"), jsonnetFolder)
+	if err != nil {
+		t.Fatalf("processCodeFolders() error = %v", err)
+	}
+	want := "local lib = (local common = ({ prefix: 'shared-' }\n);\n{ name: common.prefix + 'mapper' }\n);\n" +
+		`// import "ignored.libsonnet"` + "\n" + `{ name: lib.name, note: "a \"quoted\" note" }`
+	if got := codeMap["JsonnetMapperTask"]["1"]; got != want {
+		t.Errorf("processCodeFolders() jsonnet = %s, want %s", got, want)
+	}
+
+	if err = os.WriteFile(path.Join(jsonnetFolder, "lib", "common.libsonnet"), []byte("import 'mapper.libsonnet'"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = processCodeFolders(path.Join(folder, "javascript"), jsonnetFolder); err == nil ||
+		!strings.Contains(err.Error(), "cycle") {
+		t.Errorf("processCodeFolders() error = %v, want an import cycle error", err)
+	}
+
+	// the functions library of the sample is provided by the data transformer
+	sample, err := utils.ReadFile(path.Join("..", "..", "..", "samples", "scaffold-sample", "src",
+		"datatransformer", "datatransformer_1.jsonnet"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = os.WriteFile(path.Join(jsonnetFolder, "datatransformer_1.jsonnet"), sample, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if codeMap, err = processCodeFolders(path.Join(folder, "javascript"), jsonnetFolder); err != nil {
+		t.Fatalf("processCodeFolders() error = %v for the sample", err)
+	}
+	if got := codeMap["JsonnetMapperTask"]["1"]; !strings.HasPrefix(got, `local f = import "functions";`) {
+		t.Errorf("processCodeFolders() jsonnet = %s, want the functions import left as is", got)
+	}
+}
+
+func TestParseSince(t *testing.T) {
+	folder := setupApplyTest(t)
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	stateFile := path.Join(folder, applyStateFile)
+
+	if got, err := parseSince("last", stateFile, now); err != nil || !got.IsZero() {
+		t.Errorf("parseSince(last) = %v, %v without a state file, want no cutoff", got, err)
+	}
+	if err := writeApplyState(stateFile, now.Add(-time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		since string
+		want  time.Time
+	}{
+		{"last", now.Add(-time.Hour)},
+		{"2025-03-01T00:00:00Z", time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{"2h", now.Add(-2 * time.Hour)},
+		{"1d", now.Add(-24 * time.Hour)},
+	}
+	for _, tt := range tests {
+		got, err := parseSince(tt.since, stateFile, now)
+		if err != nil || !got.Equal(tt.want) {
+			t.Errorf("parseSince(%s) = %v, %v, want %v", tt.since, got, err, tt.want)
+		}
+	}
+	if _, err := parseSince("yesterday", stateFile, now); err == nil {
+		t.Errorf("parseSince(yesterday) succeeded, expected an error")
+	}
+}
+
+func TestApplyWalkFuncSince(t *testing.T) {
+	folder := setupApplyTest(t)
+	for _, name := range []string{"old.json", "new.json"} {
+		if err := os.WriteFile(path.Join(folder, name), []byte(`{}`), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	old := time.Now().Add(-48 * time.Hour)
+	if err := os.Chtimes(path.Join(folder, "old.json"), old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	applySince = time.Now().Add(-time.Hour)
+	defer func() { applySince = time.Time{} }()
+
+	var applied []string
+	err := filepath.Walk(folder, applyWalkFunc(func(path string, info os.FileInfo, err error) error {
+		if !info.IsDir() {
+			applied = append(applied, filepath.Base(path))
+		}
+		return err
+	}))
+	if err != nil || strings.Join(applied, ",") != "new.json" {
+		t.Errorf("applied files = %v, %v, want new.json", applied, err)
+	}
+}
+
+func TestApplyWalkFuncCheckpoint(t *testing.T) {
+	folder := setupApplyTest(t)
+	connectorsFolder := path.Join(folder, "dev", "connectors")
+	if err := os.MkdirAll(connectorsFolder, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"a.json", "b.json", "c.json"} {
+		if err := os.WriteFile(path.Join(connectorsFolder, name), []byte(`{}`), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	checkpointFile := path.Join(t.TempDir(), "checkpoint.json")
+	defer func() { applyCheckpoint = nil }()
+
+	walk := func() (applied []string, err error) {
+		err = filepath.Walk(connectorsFolder, applyWalkFunc(func(path string, info os.FileInfo, err error) error {
+			if info.IsDir() {
+				return err
+			}
+			applied = append(applied, filepath.Base(path))
+			if filepath.Base(path) == "b.json" && len(applied) == 2 {
+				return errors.New("transient failure")
+			}
+			return err
+		}))
+		return applied, err
+	}
+
+	var err error
+	if applyCheckpoint, err = readCheckpoint(checkpointFile, folder); err != nil {
+		t.Fatal(err)
+	}
+	if applied, err := walk(); err == nil || strings.Join(applied, ",") != "a.json,b.json" {
+		t.Fatalf("applied files = %v, %v, want a failure after a.json,b.json", applied, err)
+	}
+
+	// the next apply reads the checkpoint and skips a.json
+	if applyCheckpoint, err = readCheckpoint(checkpointFile, folder); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(applyCheckpoint.Completed, ",") != "dev/connectors/a.json" {
+		t.Errorf("checkpoint = %v, want dev/connectors/a.json", applyCheckpoint.Completed)
+	}
+	if applied, err := walk(); err != nil || strings.Join(applied, ",") != "b.json,c.json" {
+		t.Errorf("applied files = %v, %v, want b.json,c.json", applied, err)
+	}
+
+	if err = applyCheckpoint.clear(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = os.Stat(checkpointFile); !os.IsNotExist(err) {
+		t.Errorf("checkpoint file exists after clear, %v", err)
+	}
+}
+
+func TestNewApplyProgress(t *testing.T) {
+	folder := setupApplyTest(t)
+	files := []string{"connectors/a.json", "connectors/b.json", "zones/z.json", "src/flow.json", "src/javascript/javascript_1.js"}
+	for _, f := range files {
+		if err := os.MkdirAll(path.Dir(path.Join(folder, f)), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path.Join(folder, f), []byte(`{}`), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	p := newApplyProgress(path.Join(folder, "src"),
+		[]string{path.Join(folder, "connectors"), path.Join(folder, "zones"), path.Join(folder, "endpoints")})
+	if p.total != 4 {
+		t.Errorf("newApplyProgress() total = %d, want 4", p.total)
+	}
+
+	var nilProgress *progress
+	nilProgress.step("ignored")
+}
+
+func TestValidateApplyOrder(t *testing.T) {
+	reordered := []string{"endpoints", "zones", "custom-connectors", "connectors", "authconfigs",
+		"sfdcinstances", "sfdcchannels", "integration"}
+	if err := validateApplyOrder(reordered); err != nil {
+		t.Errorf("validateApplyOrder() error = %v", err)
+	}
+	if err := validateApplyOrder(defaultApplyOrder[1:]); err == nil || !strings.Contains(err.Error(), "authconfigs") {
+		t.Errorf("validateApplyOrder() error = %v, want missing authconfigs", err)
+	}
+	if err := validateApplyOrder(append([]string{"endpoints"}, defaultApplyOrder...)); err == nil {
+		t.Errorf("validateApplyOrder() succeeded with a duplicate resource type")
+	}
+	if err := validateApplyOrder(append([]string{"secrets"}, defaultApplyOrder...)); err == nil {
+		t.Errorf("validateApplyOrder() succeeded with an unknown resource type")
+	}
+}
+
+func TestListEnvFolders(t *testing.T) {
+	folder := setupApplyTest(t)
+	for _, d := range []string{"src", "connectors", "overrides", ".github", "docs", "prod/overrides",
+		"dev/config-variables", "staging/overrides"} {
+		if err := os.MkdirAll(path.Join(folder, d), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(path.Join(folder, "cloudbuild.yaml"), []byte(""), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	envs, err := listEnvFolders(folder)
+	if err != nil {
+		t.Fatalf("listEnvFolders() error = %v", err)
+	}
+	if want := []string{"dev", "prod", "staging"}; !reflect.DeepEqual(envs, want) {
+		t.Errorf("listEnvFolders() = %v, want %v", envs, want)
+	}
+}
+
+func TestMatchTestCaseFiles(t *testing.T) {
+	setupApplyTest(t)
+	testCaseIDs := map[string]string{"smoke": "1", "regression": "2"}
+
+	matched, err := matchTestCaseFiles([]string{"smoke.json"}, testCaseIDs, true, false)
+	if err != nil || len(matched) != 1 || matched[0] != "smoke.json" {
+		t.Errorf("matchTestCaseFiles() = %v, %v, want smoke.json", matched, err)
+	}
+
+	if _, err = matchTestCaseFiles([]string{"smoke.json", "typo.json"}, testCaseIDs, false, false); err == nil ||
+		!strings.Contains(err.Error(), "typo.json") {
+		t.Errorf("matchTestCaseFiles() error = %v, want an error naming typo.json", err)
+	}
+
+	matched, err = matchTestCaseFiles([]string{"smoke.json", "typo.json"}, testCaseIDs, false, true)
+	if err != nil || len(matched) != 1 || matched[0] != "smoke.json" {
+		t.Errorf("matchTestCaseFiles() = %v, %v, want typo.json skipped", matched, err)
+	}
+}
+
+func TestValidateScaffold(t *testing.T) {
+	setupApplyTest(t)
+	fileSplitter = utils.DefaultFileSplitter
+	srcFolder := t.TempDir()
+	for file, contents := range map[string]string{
+		"src/sample.json": `{"taskConfigs":[{"task":"JavaScriptTask","taskId":"1"}],` +
+			`"integrationConfigParameters":[{"parameter":{"key":"` + "`CONFIG_url`" + `","dataType":"STRING_VALUE"}}]}`,
+		"src/javascript/javascript_1.js":                 "return;",
+		"dev/overrides/overrides.json":                   `{"trigger_overrides":[{"triggerNumber":"1","topicName":"$` + "`CONFIG_topic`" + `$"}]}`,
+		"dev/connectors/gcs.json":                        `{"connectorDetails":`,
+		"dev/sfdcchannels/instance__channel.json":        `{}`,
+		"dev/sfdcchannels/channel.json":                  `{}`,
+		"dev/connectors/subscriptions/gcs.json":          `{}`,
+		"prod/connectors/subscriptions/gcs__orders.json": `{}`,
+		"dev/config-variables/sample-config.json":        `{"` + "`CONFIG_url`" + `":"https://example.com","` + "`CONFIG_old`" + `":"x"}`,
+		"prod/config-variables/sample-config.json":       `{"` + "`CONFIG_url`" + `":"https://example.com"}`,
+		"prod/sfdcchannels/instance__channel.json":       `{}`,
+		// only the JSON files are checked
+		"prod/connectors/README.md":                 `not json`,
+		"prod/custom-connectors/custom__1.json.bak": `{`,
+	} {
+		if err := os.MkdirAll(path.Dir(path.Join(srcFolder, file)), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path.Join(srcFolder, file), []byte(contents), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	problems, warnings, err := validateScaffold(srcFolder, "dev")
+	if err != nil {
+		t.Fatalf("validateScaffold() error = %v", err)
+	}
+	want := []string{
+		path.Join(srcFolder, "dev/connectors/gcs.json") + " is not valid JSON",
+		"sfdc channel file channel.json does not follow the naming convention instanceName__channelName.json",
+		"event subscription file gcs.json does not follow the naming convention connectionName__subscriptionId.json",
+		"the overrides reference config variable CONFIG_topic, which is not declared by sample.json",
+	}
+	if strings.Join(problems, "\n") != strings.Join(want, "\n") {
+		t.Errorf("validateScaffold() problems = %q, want %q", problems, want)
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "CONFIG_old") {
+		t.Errorf("validateScaffold() warnings = %q, want the unused config variable", warnings)
+	}
+
+	if problems, warnings, err = validateScaffold(srcFolder, "prod"); err != nil || len(problems) != 0 || len(warnings) != 0 {
+		t.Errorf("validateScaffold() = %q, %q, %v, want no problems", problems, warnings, err)
+	}
+	if _, _, err = validateScaffold(srcFolder, "staging"); err == nil {
+		t.Errorf("validateScaffold() succeeded, expected an error for a missing environment folder")
+	}
+}
+
+func TestValidateScaffoldWithoutIntegration(t *testing.T) {
+	setupApplyTest(t)
+	srcFolder := t.TempDir()
+	if err := os.MkdirAll(path.Join(srcFolder, "src"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(path.Join(srcFolder, "dev", "connectors"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path.Join(srcFolder, "dev", "connectors", "gcs.json"), []byte(`{}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	// a connectors only scaffold is applied without an integration
+	if problems, warnings, err := validateScaffold(srcFolder, "dev"); err != nil || len(problems) != 0 || len(warnings) != 0 {
+		t.Errorf("validateScaffold() = %q, %q, %v, want no problems", problems, warnings, err)
+	}
+}
+
+func TestValidateStrictJSON(t *testing.T) {
+	setupApplyTest(t)
+	srcFolder := t.TempDir()
+	for file, contents := range map[string]string{
+		"src/sample.json":                   `{"trigerConfigs":[],"taskConfigs":[{"task":"JavaScriptTask","taskId":"1"}]}`,
+		"dev/overrides/overrides.json":      `{"task_overrides":[{"taskId":"1","parameters":{}}],"connection_override":[]}`,
+		"dev/connectors/gcs.json":           `{"connectorDetails":{"name":"gcs"},"nodeConfig":{"minNodeCount":2}}`,
+		"dev/connectors/pubsub.json":        `{"configVariables":[{"key":"project_id","stringVal":"p"}]}`,
+		"dev/custom-connectors/api__1.json": `{"displayName":"api","customConnectorVersion":{"specLocaton":"gs://b/spec.yaml"}}`,
+		"dev/endpoints/db.json":             `{"serviceAttachment":"sa","descripton":"db"}`,
+		"dev/zones/corp.json":               `{"dns":"corp.example.com.","targetProject":"p","targetVpc":"vpc"}`,
+		"dev/sfdcinstances/org.json":        `{"displayName":"org","sfdcOrgId":"1"}`,
+		"dev/sfdcchannels/org__orders.json": `{"displayName":"orders","channelTopc":"/event/Order"}`,
+		"dev/authconfigs/basic.json":        `{"displayName":"basic","decryptedCredental":{}}`,
+		"dev/connectors/crm.json.tmpl":      `{"configVariables":[{"key":"host","stringVal":"{{.host}}"}]}`,
+	} {
+		if err := os.MkdirAll(path.Dir(path.Join(srcFolder, file)), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path.Join(srcFolder, file), []byte(contents), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want := []string{
+		path.Join(srcFolder, "src/sample.json") + `: json: unknown field "trigerConfigs"`,
+		path.Join(srcFolder, "dev/overrides/overrides.json") + `: json: unknown field "connection_override"`,
+		path.Join(srcFolder, "dev/connectors/pubsub.json") + `: json: unknown field "stringVal"`,
+		path.Join(srcFolder, "dev/custom-connectors/api__1.json") + `: json: unknown field "specLocaton"`,
+		path.Join(srcFolder, "dev/endpoints/db.json") + `: json: unknown field "descripton"`,
+		path.Join(srcFolder, "dev/sfdcchannels/org__orders.json") + `: json: unknown field "channelTopc"`,
+	}
+	templateFile := path.Join(srcFolder, "dev/connectors/crm.json.tmpl")
+	wantWarnings := []string{
+		templateFile + " was not checked, pass --values to render it",
+		"the authconfig files are not checked for unknown fields",
+	}
+	problems, warnings := validateStrictJSON(srcFolder, "dev")
+	if strings.Join(problems, "\n") != strings.Join(want, "\n") {
+		t.Errorf("validateStrictJSON() problems = %q, want %q", problems, want)
+	}
+	if strings.Join(warnings, "\n") != strings.Join(wantWarnings, "\n") {
+		t.Errorf("validateStrictJSON() warnings = %q, want %q", warnings, wantWarnings)
+	}
+
+	// with values the rendered connector template is checked
+	templateValues = map[string]interface{}{"host": "crm.example.com"}
+	defer func() { templateValues = nil }()
+	want = append(want[:3:3], append([]string{templateFile + `: json: unknown field "stringVal"`}, want[3:]...)...)
+	problems, warnings = validateStrictJSON(srcFolder, "dev")
+	if strings.Join(problems, "\n") != strings.Join(want, "\n") {
+		t.Errorf("validateStrictJSON() problems = %q, want %q", problems, want)
+	}
+	if strings.Join(warnings, "\n") != strings.Join(wantWarnings[1:], "\n") {
+		t.Errorf("validateStrictJSON() warnings = %q, want %q", warnings, wantWarnings[1:])
+	}
+}
+
+func TestGetEventSubscriptionNames(t *testing.T) {
+	fileSplitter = utils.DefaultFileSplitter
+
+	connectionName, subscriptionId, err := getEventSubscriptionNames("gcs__orders.json")
+	if err != nil || connectionName != "gcs" || subscriptionId != "orders" {
+		t.Errorf("getEventSubscriptionNames() = %s, %s, %v, want gcs, orders", connectionName, subscriptionId, err)
+	}
+	for _, subscriptionFile := range []string{"gcs.json", "gcs__.json", "gcs__orders__a.json"} {
+		if _, _, err = getEventSubscriptionNames(subscriptionFile); err == nil {
+			t.Errorf("getEventSubscriptionNames(%s) succeeded, expected an error", subscriptionFile)
+		}
+	}
+}
+
+func TestCompareNames(t *testing.T) {
+	scaffoldNames := map[string]bool{"a": true, "b": true, "c": true}
+	diff := func(name string) ([]string, error) {
+		if name == "b" {
+			return []string{"description", "labels"}, nil
+		}
+		return nil, nil
+	}
+
+	want := []statusEntry{
+		{"zone", "a", statusMissing, ""},
+		{"zone", "b", statusDiffers, "description, labels"},
+		{"zone", "c", statusInSync, ""},
+		{"zone", "d", statusExtra, ""},
+	}
+	if entries := compareNames("zone", scaffoldNames, []string{"d", "c", "b"}, diff); !reflect.DeepEqual(entries, want) {
+		t.Errorf("compareNames() = %v, want %v", entries, want)
+	}
+	if entries := compareNames("endpoint", scaffoldNames, []string{"b"}, nil); entries[1].status != statusExists {
+		t.Errorf("compareNames() status = %s, want %s", entries[1].status, statusExists)
+	}
+}
+
+func TestRenderConnectorTemplate(t *testing.T) {
+	folder := setupApplyTest(t)
+	valuesFile := path.Join(folder, "values.yaml")
+	templateFile := path.Join(folder, "{{.env}}-crm.json.tmpl")
+	if err := os.WriteFile(valuesFile, []byte("env: dev\ncrm:\n  host: crm.dev.example.com\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(templateFile, []byte(`{"configVariables":[{"key":"host","stringValue":"{{.crm.host}}"}]}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { templateValues = nil }()
+
+	var err error
+	if templateValues, err = readTemplateValues(valuesFile); err != nil {
+		t.Fatal(err)
+	}
+	connectionFile, connectionBytes, err := renderConnectorTemplate(templateFile)
+	if err != nil {
+		t.Fatalf("renderConnectorTemplate() error = %v", err)
+	}
+	if want := `{"configVariables":[{"key":"host","stringValue":"crm.dev.example.com"}]}`; connectionFile != "dev-crm.json" ||
+		string(connectionBytes) != want {
+		t.Errorf("renderConnectorTemplate() = %s, %s, want dev-crm.json, %s", connectionFile, connectionBytes, want)
+	}
+	if names, err := getScaffoldNames(folder); err != nil || !names["dev-crm"] {
+		t.Errorf("getScaffoldNames() = %v, %v, want dev-crm", names, err)
+	}
+
+	templateValues = map[string]interface{}{"env": "dev"}
+	if _, _, err = renderConnectorTemplate(templateFile); err == nil {
+		t.Errorf("renderConnectorTemplate() succeeded with a missing value, expected an error")
+	}
+}
@@ -18,10 +18,11 @@ import (
 	"errors"
 	"internal/apiclient"
 	"internal/client/integrations"
+	"internal/clilog"
 	"internal/cmd/utils"
-	"os"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 // CrtTestCaseCmd to get integration flow
@@ -45,7 +46,9 @@ var CrtTestCaseCmd = &cobra.Command{
 		if err = validate(version, userLabel, snapshot, false); err != nil {
 			return err
 		}
-
+		cmd.Flags().VisitAll(func(f *pflag.Flag) {
+			clilog.Debug.Printf("%s: %s\n", f.Name, f.Value)
+		})
 		return apiclient.SetProjectID(cmdProject)
 	},
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
@@ -57,25 +60,32 @@ var CrtTestCaseCmd = &cobra.Command{
 		userLabel := utils.GetStringParam(cmd.Flag("user-label"))
 		snapshot := utils.GetStringParam(cmd.Flag("snapshot"))
 
-		if _, err := os.Stat(contentPath); os.IsNotExist(err) {
+		content, err := utils.ReadFileOrStdin(contentPath)
+		if err != nil {
 			return err
 		}
 
-		content, err := os.ReadFile(contentPath)
+		// resolve the version the same way test case execute does
+		if version == "" {
+			if version, err = integrations.GetVersion(name, userLabel, snapshot); err != nil {
+				return err
+			}
+		}
+
+		respBody, err := integrations.CreateTestCase(name, version, string(content))
 		if err != nil {
 			return err
 		}
-
-		if version != "" {
-			_, err = integrations.CreateTestCase(name, version, string(content))
-		} else if userLabel != "" {
-			_, err = integrations.CreateTestCaseByUserLabel(name, userLabel, string(content))
-		} else if snapshot != "" {
-			_, err = integrations.CreateTestCaseBySnapshot(name, snapshot, string(content))
+		testCaseID, displayName, err := integrations.GetTestCaseID(respBody)
+		if err != nil {
+			clilog.Warning.Printf("Unable to read the created test case id: %v\n", err)
+			return nil
 		}
-
-		return err
+		clilog.Info.Printf("Created test case %s with id %s\n", displayName, testCaseID)
+		return nil
 	},
+	Example: `Create a test case for the version with a user label: ` + GetExample(21) + `
+Create a test case from generated content on stdin: ` + GetExample(33),
 }
 
 func init() {
@@ -90,7 +100,7 @@ func init() {
 	CrtTestCaseCmd.Flags().StringVarP(&snapshot, "snapshot", "s",
 		"", "Integration flow snapshot number")
 	CrtTestCaseCmd.Flags().StringVarP(&contentPath, "test-case-path", "c",
-		"", "Path to a file containing the test case content")
+		"", "Path to a file containing the test case content, or - to read it from stdin")
 
 	_ = CrtTestCaseCmd.MarkFlagRequired("name")
 	_ = CrtTestCaseCmd.MarkFlagRequired("test-case-path")
@@ -19,6 +19,7 @@ import (
 	"internal/client/integrations"
 	"internal/clilog"
 	"internal/cmd/utils"
+	"strconv"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
@@ -28,7 +29,8 @@ import (
 var DelCmd = &cobra.Command{
 	Use:   "delete",
 	Short: "Deletes an Integration and all versions of it",
-	Long:  "Deletes an Integration and all versions of it",
+	Long: "Deletes an Integration and all versions of it. Published versions are unpublished " +
+		"before they are deleted, the integration is deleted after its last version",
 	Args: func(cmd *cobra.Command, args []string) (err error) {
 		cmdProject := cmd.Flag("proj")
 		cmdRegion := cmd.Flag("reg")
@@ -45,16 +47,46 @@ var DelCmd = &cobra.Command{
 		cmd.SilenceUsage = true
 
 		name := utils.GetStringParam(cmd.Flag("name"))
-		_, err = integrations.Delete(name)
-		return err
+		force, _ := strconv.ParseBool(utils.GetStringParam(cmd.Flag("force")))
+		dryRun, _ := strconv.ParseBool(utils.GetStringParam(cmd.Flag("dry-run")))
+
+		respBody, err := integrations.ListAllVersions(name, -1, "", "", "", 0)
+		if err != nil {
+			return err
+		}
+		summaries, err := integrations.GetVersionSummaries(respBody)
+		if err != nil {
+			return err
+		}
+
+		if err = printVersionTable(summaries); err != nil {
+			return err
+		}
+		if dryRun {
+			clilog.Info.Printf("Integration %s and its %d versions would be deleted\n", name, len(summaries))
+			return nil
+		}
+		if !force {
+			if err = utils.ConfirmDelete("integration", name); err != nil {
+				return err
+			}
+		}
+
+		return integrations.DeleteWithVersions(name, summaries)
 	},
+	Example: `Preview the versions that would be deleted with the integration: ` + GetExample(49),
 }
 
 func init() {
 	var name string
+	var force, dryRun bool
 
 	DelCmd.Flags().StringVarP(&name, "name", "n",
 		"", "Integration name")
+	DelCmd.Flags().BoolVarP(&force, "force", "",
+		false, "Delete the integration without prompting for confirmation; default is false")
+	DelCmd.Flags().BoolVarP(&dryRun, "dry-run", "",
+		false, "List the versions that would be deleted without deleting them; default is false")
 
 	_ = DelCmd.MarkFlagRequired("name")
 }
@@ -16,10 +16,14 @@ package integrations
 
 import (
 	"errors"
+	"fmt"
 	"internal/apiclient"
 	"internal/client/integrations"
 	"internal/clilog"
 	"internal/cmd/utils"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
@@ -29,7 +33,8 @@ import (
 var DelVerCmd = &cobra.Command{
 	Use:   "delete",
 	Short: "Delete an integration flow version",
-	Long:  "Delete an integration flow version",
+	Long: "Delete an integration flow version, or all versions matching a filter and/or " +
+		"older than a duration",
 	Args: func(cmd *cobra.Command, args []string) (err error) {
 		project := utils.GetStringParam(cmd.Flag("proj"))
 		region := utils.GetStringParam(cmd.Flag("reg"))
@@ -40,8 +45,25 @@ var DelVerCmd = &cobra.Command{
 		if err = apiclient.SetRegion(region); err != nil {
 			return err
 		}
+		filter := utils.GetStringParam(cmd.Flag("filter"))
+		olderThan := utils.GetStringParam(cmd.Flag("older-than"))
+
+		if filter != "" || olderThan != "" {
+			if snapshot != "" || userLabel != "" || version != "" {
+				return errors.New("filter and older-than cannot be combined with snapshot, userLabel or version")
+			}
+			if olderThan != "" {
+				if _, err = utils.ParseAge(olderThan); err != nil {
+					return err
+				}
+			}
+			cmd.Flags().VisitAll(func(f *pflag.Flag) {
+				clilog.Debug.Printf("%s: %s\n", f.Name, f.Value)
+			})
+			return apiclient.SetProjectID(project)
+		}
 		if snapshot == "" && userLabel == "" && version == "" {
-			return errors.New("at least one of snapshot, userLabel and version must be supplied")
+			return errors.New("at least one of snapshot, userLabel, version, filter or older-than must be supplied")
 		}
 		if snapshot != "" && (userLabel != "" || version != "") {
 			return errors.New("snapshot cannot be combined with userLabel or version")
@@ -64,6 +86,25 @@ var DelVerCmd = &cobra.Command{
 		userLabel := utils.GetStringParam(cmd.Flag("user-label"))
 		snapshot := utils.GetStringParam(cmd.Flag("snapshot"))
 		name := utils.GetStringParam(cmd.Flag("name"))
+		filter := utils.GetStringParam(cmd.Flag("filter"))
+		olderThan := utils.GetStringParam(cmd.Flag("older-than"))
+		force, _ := strconv.ParseBool(utils.GetStringParam(cmd.Flag("force")))
+
+		if filter != "" || olderThan != "" {
+			return deleteVersions(name, filter, olderThan, force)
+		}
+
+		if !force {
+			kind, id := "version of integration "+name, version
+			if snapshot != "" {
+				kind, id = "snapshot of integration "+name, snapshot
+			} else if version == "" {
+				kind, id = "user label of integration "+name, userLabel
+			}
+			if err = utils.ConfirmDelete(kind, id); err != nil {
+				return err
+			}
+		}
 
 		if version != "" {
 			_, err = integrations.DeleteVersion(name, version)
@@ -74,10 +115,12 @@ var DelVerCmd = &cobra.Command{
 		}
 		return err
 	},
+	Example: `Delete draft versions older than 30 days: ` + GetExample(19),
 }
 
 func init() {
-	var name, userLabel, snapshot, version string
+	var name, userLabel, snapshot, version, filter, olderThan string
+	var force bool
 
 	DelVerCmd.Flags().StringVarP(&name, "name", "n",
 		"", "Integration flow name")
@@ -87,6 +130,77 @@ func init() {
 		"", "Integration flow snapshot number")
 	DelVerCmd.Flags().StringVarP(&userLabel, "user-label", "u",
 		"", "Integration flow user label")
+	DelVerCmd.Flags().StringVarP(&filter, "filter", "",
+		"", "Delete all versions matching the filter, for ex: state=DRAFT")
+	DelVerCmd.Flags().StringVarP(&olderThan, "older-than", "",
+		"", "Delete only versions created before this duration, for ex: 30d or 12h")
+	DelVerCmd.Flags().BoolVarP(&force, "force", "",
+		false, "Delete the versions without asking for confirmation; default is false")
 
 	_ = DelVerCmd.MarkFlagRequired("name")
 }
+
+// deleteVersions deletes the versions matching the filter and created before olderThan
+func deleteVersions(name string, filter string, olderThan string, force bool) (err error) {
+	var cutoff time.Time
+
+	if olderThan != "" {
+		age, err := utils.ParseAge(olderThan)
+		if err != nil {
+			return err
+		}
+		cutoff = time.Now().Add(-age)
+	}
+
+	respBody, err := integrations.ListAllVersions(name, -1, "", filter, "", 0)
+	if err != nil {
+		return err
+	}
+	summaries, err := integrations.GetVersionSummaries(respBody)
+	if err != nil {
+		return err
+	}
+
+	var matches []integrations.VersionSummary
+	for _, s := range summaries {
+		if !cutoff.IsZero() {
+			createTime, err := time.Parse(time.RFC3339, s.CreateTime)
+			if err != nil {
+				return fmt.Errorf("unable to parse createTime of version %s: %w", s.Version, err)
+			}
+			if !createTime.Before(cutoff) {
+				continue
+			}
+		}
+		matches = append(matches, s)
+	}
+
+	if len(matches) == 0 {
+		clilog.Info.Printf("No versions of %s matched\n", name)
+		return nil
+	}
+
+	if err = printVersionTable(matches); err != nil {
+		return err
+	}
+	if !force {
+		if err = utils.Confirm(fmt.Sprintf("Delete %d versions of %s?", len(matches), name)); err != nil {
+			return err
+		}
+	}
+
+	apiclient.ClientPrintHttpResponse.Set(false)
+	defer apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting())
+
+	errs := []string{}
+	for _, s := range matches {
+		clilog.Info.Printf("Deleting version %s of %s\n", s.Version, name)
+		if _, err = integrations.DeleteVersion(name, s.Version); err != nil {
+			errs = append(errs, fmt.Sprintf("version %s: %v", s.Version, err))
+		}
+	}
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "\n"))
+	}
+	return nil
+}
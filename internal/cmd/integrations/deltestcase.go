@@ -15,8 +15,11 @@
 package integrations
 
 import (
+	"errors"
 	"internal/apiclient"
 	"internal/client/integrations"
+	"internal/cmd/utils"
+	"strconv"
 
 	"github.com/spf13/cobra"
 )
@@ -30,23 +33,53 @@ var DelTestCaseCmd = &cobra.Command{
 		cmdProject := cmd.Flag("proj")
 		cmdRegion := cmd.Flag("reg")
 
+		testCaseID := utils.GetStringParam(cmd.Flag("test-case-id"))
+		testCaseName := utils.GetStringParam(cmd.Flag("test-case-name"))
+
 		if err = apiclient.SetRegion(cmdRegion.Value.String()); err != nil {
 			return err
 		}
+		if testCaseID == "" && testCaseName == "" {
+			return errors.New("one of test-case-id or test-case-name must be passed")
+		}
+		if testCaseID != "" && testCaseName != "" {
+			return errors.New("test-case-id and test-case-name cannot be combined")
+		}
 
 		return apiclient.SetProjectID(cmdProject.Value.String())
 	},
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		cmd.SilenceUsage = true
+
 		version := cmd.Flag("ver").Value.String()
 		name := cmd.Flag("name").Value.String()
 		testCaseID := cmd.Flag("test-case-id").Value.String()
+		testCaseName := cmd.Flag("test-case-name").Value.String()
+		force, _ := strconv.ParseBool(utils.GetStringParam(cmd.Flag("force")))
+
+		if testCaseName != "" {
+			apiclient.ClientPrintHttpResponse.Set(false)
+			testCaseID, err = integrations.FindTestCase(name, version, testCaseName, "")
+			apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting())
+			if err != nil {
+				return err
+			}
+		}
+
+		if !force {
+			if err = utils.ConfirmDelete("test case", testCaseID); err != nil {
+				return err
+			}
+		}
+
 		_, err = integrations.DeleteTestCase(name, version, testCaseID)
 		return err
 	},
 }
 
 func init() {
-	var name, version, testCaseID string
+	var name, version, testCaseID, testCaseName string
+	var force bool
 
 	DelTestCaseCmd.Flags().StringVarP(&name, "name", "n",
 		"", "Integration flow name")
@@ -54,8 +87,11 @@ func init() {
 		"", "Integration flow version")
 	DelTestCaseCmd.Flags().StringVarP(&testCaseID, "test-case-id", "c",
 		"", "Test Case ID")
+	DelTestCaseCmd.Flags().StringVarP(&testCaseName, "test-case-name", "",
+		"", "Test Case display name; used to look up the test case ID")
+	DelTestCaseCmd.Flags().BoolVarP(&force, "force", "",
+		false, "Delete the test case without prompting for confirmation; default is false")
 	_ = DelTestCaseCmd.MarkFlagRequired("name")
 	_ = DelTestCaseCmd.MarkFlagRequired("ver")
-	_ = DelTestCaseCmd.MarkFlagRequired("test-case-id")
 
 }
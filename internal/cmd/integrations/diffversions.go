@@ -0,0 +1,126 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integrations
+
+import (
+	"encoding/json"
+	"fmt"
+	"internal/apiclient"
+	"internal/client/integrations"
+	"internal/clilog"
+	"internal/cmd/utils"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// DiffVerCmd to compare two integration flow versions
+var DiffVerCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Compare two integration flow versions",
+	Long: "Compare two integration flow versions and print the triggers, tasks, config variables " +
+		"and parameters that were added, removed or changed. Server generated fields are ignored",
+	Args: func(cmd *cobra.Command, args []string) (err error) {
+		cmdProject := cmd.Flag("proj")
+		cmdRegion := cmd.Flag("reg")
+
+		if err = apiclient.SetRegion(utils.GetStringParam(cmdRegion)); err != nil {
+			return err
+		}
+		cmd.Flags().VisitAll(func(f *pflag.Flag) {
+			clilog.Debug.Printf("%s: %s\n", f.Name, f.Value)
+		})
+		return apiclient.SetProjectID(utils.GetStringParam(cmdProject))
+	},
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		cmd.SilenceUsage = true
+
+		var versionA, versionB []byte
+
+		name := utils.GetStringParam(cmd.Flag("name"))
+		verA := utils.GetStringParam(cmd.Flag("ver-a"))
+		verB := utils.GetStringParam(cmd.Flag("ver-b"))
+		jsonOutput, _ := strconv.ParseBool(utils.GetStringParam(cmd.Flag("json")))
+
+		apiclient.DisableCmdPrintHttpResponse()
+
+		if versionA, err = integrations.Get(name, verA, false, false, false); err != nil {
+			return err
+		}
+		if versionB, err = integrations.Get(name, verB, false, false, false); err != nil {
+			return err
+		}
+
+		changes, err := integrations.DiffVersions(versionA, versionB)
+		if err != nil {
+			return err
+		}
+
+		apiclient.EnableCmdPrintHttpResponse()
+		apiclient.ClientPrintHttpResponse.Set(true)
+
+		if jsonOutput {
+			if changes == nil {
+				changes = []integrations.Change{}
+			}
+			respBody, err := json.Marshal(changes)
+			if err != nil {
+				return err
+			}
+			return apiclient.PrettyPrint(respBody)
+		}
+		printChanges(changes)
+		return nil
+	},
+	Example: `Compare two versions of an integration: ` + GetExample(26),
+}
+
+func init() {
+	var name, verA, verB string
+	var jsonOutput bool
+
+	DiffVerCmd.Flags().StringVarP(&name, "name", "n",
+		"", "Integration flow name")
+	DiffVerCmd.Flags().StringVarP(&verA, "ver-a", "",
+		"", "Integration flow version to compare from")
+	DiffVerCmd.Flags().StringVarP(&verB, "ver-b", "",
+		"", "Integration flow version to compare to")
+	DiffVerCmd.Flags().BoolVarP(&jsonOutput, "json", "",
+		false, "Print the differences as JSON; default is false")
+
+	_ = DiffVerCmd.MarkFlagRequired("name")
+	_ = DiffVerCmd.MarkFlagRequired("ver-a")
+	_ = DiffVerCmd.MarkFlagRequired("ver-b")
+}
+
+func printChanges(changes []integrations.Change) {
+	if len(changes) == 0 {
+		clilog.HTTPResponse.Println("No differences found")
+		return
+	}
+	symbols := map[string]string{"added": "+", "removed": "-", "changed": "~"}
+	for _, c := range changes {
+		line := fmt.Sprintf("%s %s %s", symbols[c.Kind], c.Type, c.Id)
+		if c.Name != "" {
+			line += fmt.Sprintf(" (%s)", c.Name)
+		}
+		if len(c.Fields) > 0 {
+			line += ": " + strings.Join(c.Fields, ", ")
+		}
+		clilog.HTTPResponse.Println(line)
+	}
+}
@@ -15,13 +15,14 @@
 package integrations
 
 import (
+	"encoding/json"
 	"errors"
-	"fmt"
 	"internal/apiclient"
 	"internal/client/integrations"
 	"internal/clilog"
 	"internal/cmd/utils"
 	"os"
+	"strings"
 
 	"github.com/google/uuid"
 	"github.com/spf13/cobra"
@@ -32,7 +33,8 @@ import (
 var ExecuteCmd = &cobra.Command{
 	Use:   "execute",
 	Short: "Execute an integration",
-	Long:  "execute an integration",
+	Long: "Execute the published version of an integration, or a specific version when one of " +
+		"--ver, --snapshot or --user-label is set, and print the execution id and output parameters",
 	Args: func(cmd *cobra.Command, args []string) (err error) {
 		cmdProject := cmd.Flag("proj")
 		cmdRegion := cmd.Flag("reg")
@@ -43,6 +45,15 @@ var ExecuteCmd = &cobra.Command{
 		if executionFile != "" && triggerID != "" {
 			return errors.New("cannot pass trigger id and execution file")
 		}
+		if executionFile != "" && inputFile != "" {
+			return errors.New("cannot pass input file and execution file")
+		}
+		version := utils.GetStringParam(cmd.Flag("ver"))
+		userLabel := utils.GetStringParam(cmd.Flag("user-label"))
+		snapshot := utils.GetStringParam(cmd.Flag("snapshot"))
+		if (version != "" && (userLabel != "" || snapshot != "")) || (userLabel != "" && snapshot != "") {
+			return errors.New("must pass only one of version, userLabel or snapshot")
+		}
 		cmd.Flags().VisitAll(func(f *pflag.Flag) {
 			clilog.Debug.Printf("%s: %s\n", f.Name, f.Value)
 		})
@@ -51,9 +62,18 @@ var ExecuteCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
 		cmd.SilenceUsage = true
 
-		var content []byte
+		var content, inputParameters []byte
 		name := utils.GetStringParam(cmd.Flag("name"))
 		requestID := utils.GetStringParam(cmd.Flag("request-id"))
+		version := utils.GetStringParam(cmd.Flag("ver"))
+		userLabel := utils.GetStringParam(cmd.Flag("user-label"))
+		snapshot := utils.GetStringParam(cmd.Flag("snapshot"))
+
+		if userLabel != "" || snapshot != "" {
+			if version, err = integrations.GetVersion(name, userLabel, snapshot); err != nil {
+				return err
+			}
+		}
 
 		if executionFile != "" {
 			if _, err := os.Stat(executionFile); os.IsNotExist(err) {
@@ -64,26 +84,43 @@ var ExecuteCmd = &cobra.Command{
 			if err != nil {
 				return err
 			}
-		} else if triggerID != "" {
+		} else {
+			if inputFile != "" {
+				if inputParameters, err = utils.ReadFile(inputFile); err != nil {
+					return err
+				}
+			}
+			if triggerID == "" {
+				if triggerID, err = integrations.GetApiTriggerId(name, version); err != nil {
+					return err
+				}
+			}
 			if requestID == "" {
 				requestID = uuid.New().String()
 			}
-			content = []byte(fmt.Sprintf("{\"triggerId\": \"api_trigger/%s\",\"doNotPropagateError\": %t,\"requestId\": \"%s\",\"inputParameters\": {}}",
-				triggerID, doNotPropagateError, requestID))
+			if content, err = getExecuteRequest(triggerID, requestID, inputParameters); err != nil {
+				return err
+			}
 		}
 
+		if version != "" {
+			_, err = integrations.ExecuteVersion(name, version, content)
+			return err
+		}
 		_, err = integrations.Execute(name, content)
 		return err
 	},
+	Example: `Execute the published version of an integration: ` + GetExample(29) + `
+Execute a version with input parameters: ` + GetExample(30),
 }
 
 var (
-	executionFile, triggerID string
-	doNotPropagateError      bool
+	executionFile, triggerID, inputFile string
+	doNotPropagateError                 bool
 )
 
 func init() {
-	var name, requestID string
+	var name, requestID, version, userLabel, snapshot string
 
 	ExecuteCmd.Flags().StringVarP(&name, "name", "n",
 		"", "Integration flow name")
@@ -92,8 +129,17 @@ func init() {
 			" https://cloud.google.com/application-integration/docs/reference/"+
 			"rest/v1/projects.locations.integrations/execute#request-body")
 	ExecuteCmd.Flags().StringVarP(&triggerID, "trigger-id", "",
-		"", "Specify only the trigger id of the integration if there "+
-			"are no input parameters to be sent. Cannot be combined with -f")
+		"", "Trigger id of the integration. Required when the integration has more than one API trigger. "+
+			"Cannot be combined with -f")
+	ExecuteCmd.Flags().StringVarP(&inputFile, "input-file", "",
+		"", "JSON file with the input parameters of the execution, in the inputParameters format of the "+
+			"execute request. Cannot be combined with -f")
+	ExecuteCmd.Flags().StringVarP(&version, "ver", "v",
+		"", "Integration flow version to execute; the published version is executed by default")
+	ExecuteCmd.Flags().StringVarP(&userLabel, "user-label", "u",
+		"", "Integration flow user label of the version to execute")
+	ExecuteCmd.Flags().StringVarP(&snapshot, "snapshot", "s",
+		"", "Integration flow snapshot number of the version to execute")
 	ExecuteCmd.Flags().StringVarP(&requestID, "request-id", "",
 		"", "This is used to de-dup incoming request")
 	ExecuteCmd.Flags().BoolVarP(&doNotPropagateError, "do-not-propagate-error", "",
@@ -101,3 +147,19 @@ func init() {
 
 	_ = ExecuteCmd.MarkFlagRequired("name")
 }
+
+// getExecuteRequest returns the execute request for an API trigger and input parameters
+func getExecuteRequest(triggerID string, requestID string, inputParameters []byte) ([]byte, error) {
+	if len(inputParameters) == 0 {
+		inputParameters = []byte("{}")
+	}
+	if !json.Valid(inputParameters) {
+		return nil, errors.New("input parameters must be a JSON object")
+	}
+	return json.Marshal(map[string]interface{}{
+		"triggerId":           "api_trigger/" + strings.TrimPrefix(triggerID, "api_trigger/"),
+		"doNotPropagateError": doNotPropagateError,
+		"requestId":           requestID,
+		"inputParameters":     json.RawMessage(inputParameters),
+	})
+}
@@ -16,11 +16,14 @@ package integrations
 
 import (
 	"errors"
+	"fmt"
 	"internal/apiclient"
 	"internal/client/integrations"
 	"internal/clilog"
 	"internal/cmd/utils"
-	"os"
+	"path/filepath"
+	"strconv"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
@@ -35,6 +38,7 @@ var ExecuteTestCaseCmd = &cobra.Command{
 		cmdProject := utils.GetStringParam(cmd.Flag("proj"))
 		cmdRegion := utils.GetStringParam(cmd.Flag("reg"))
 		testCaseID := utils.GetStringParam(cmd.Flag("test-case-id"))
+		testCaseName := utils.GetStringParam(cmd.Flag("test-case-name"))
 		version := utils.GetStringParam(cmd.Flag("ver"))
 		userLabel := utils.GetStringParam(cmd.Flag("user-label"))
 		snapshot := utils.GetStringParam(cmd.Flag("snapshot"))
@@ -52,8 +56,8 @@ var ExecuteTestCaseCmd = &cobra.Command{
 			return err
 		}
 
-		if inputFile != "" && testCaseID == "" {
-			return errors.New("test case id must be set with input-file")
+		if inputFile != "" && testCaseID == "" && testCaseName == "" {
+			return errors.New("test case id or test case name must be set with input-file")
 		}
 
 		if inputFile == "" && inputFolder == "" {
@@ -64,8 +68,21 @@ var ExecuteTestCaseCmd = &cobra.Command{
 			return errors.New("only one of input-file or input-folder can be passed")
 		}
 
-		if inputFolder != "" && testCaseID != "" {
-			return errors.New("test case id cannot be set with input-folder")
+		if inputFolder != "" && (testCaseID != "" || testCaseName != "") {
+			return errors.New("test case id or test case name cannot be set with input-folder")
+		}
+
+		if parallel, _ := cmd.Flags().GetInt("parallel"); parallel < 1 {
+			return errors.New("parallel must be at least 1")
+		}
+
+		if pattern := utils.GetStringParam(cmd.Flag("pattern")); pattern != "" {
+			if inputFolder == "" {
+				return errors.New("pattern can only be set with input-folder")
+			}
+			if _, err = filepath.Match(pattern, ""); err != nil {
+				return fmt.Errorf("invalid pattern %s: %w", pattern, err)
+			}
 		}
 
 		return apiclient.SetProjectID(cmdProject)
@@ -78,8 +95,12 @@ var ExecuteTestCaseCmd = &cobra.Command{
 		snapshot := utils.GetStringParam(cmd.Flag("snapshot"))
 		name := utils.GetStringParam(cmd.Flag("name"))
 		testCaseID := utils.GetStringParam(cmd.Flag("test-case-id"))
+		testCaseName := utils.GetStringParam(cmd.Flag("test-case-name"))
 		inputFile := utils.GetStringParam(cmd.Flag("input-file"))
 		inputFolder := utils.GetStringParam(cmd.Flag("input-folder"))
+		junitOutput := utils.GetStringParam(cmd.Flag("junit-output"))
+		continueOnError, _ := strconv.ParseBool(utils.GetStringParam(cmd.Flag("continue-on-error")))
+		parallel, _ := cmd.Flags().GetInt("parallel")
 
 		if version == "" {
 			version, err = integrations.GetVersion(name, userLabel, snapshot)
@@ -88,19 +109,40 @@ var ExecuteTestCaseCmd = &cobra.Command{
 			}
 		}
 
+		// the test case id takes precedence over the display name
+		if testCaseID == "" && testCaseName != "" {
+			apiclient.ClientPrintHttpResponse.Set(false)
+			testCaseID, err = integrations.FindTestCase(name, version, testCaseName, "")
+			apiclient.ClientPrintHttpResponse.Set(true)
+			if err != nil {
+				return err
+			}
+		}
+
 		apiclient.EnableCmdPrintHttpResponse()
 
 		if inputFile != "" {
-			if _, err := os.Stat(inputFile); os.IsNotExist(err) {
+			content, err := utils.ReadFileOrStdin(inputFile)
+			if err != nil {
 				return err
 			}
-
-			content, err := os.ReadFile(inputFile)
+			integrationBody, err := getIntegrationInputSchema(name, version)
 			if err != nil {
 				return err
 			}
+			if err = integrations.ValidateTestInput(content, integrationBody); err != nil {
+				return fmt.Errorf("%s: %w", inputFile, err)
+			}
 			clilog.Info.Printf("Executing test cases from file %s for integration: %s\n", inputFile, name)
+			start := time.Now()
 			testCaseResp, err := integrations.ExecuteTestCase(name, version, testCaseID, string(content))
+			if junitOutput != "" {
+				report := integrations.NewJUnitTestSuite(name, version)
+				report.AddTestCase(testCaseID, time.Since(start), testCaseResp, err)
+				if werr := report.Write(junitOutput); werr != nil {
+					return werr
+				}
+			}
 			if err != nil {
 				return err
 			}
@@ -110,14 +152,17 @@ var ExecuteTestCaseCmd = &cobra.Command{
 			}
 		}
 		if inputFolder != "" {
-			return executeAllTestCases(inputFolder, name, version)
+			return executeAllTestCases(inputFolder, name, version,
+				utils.GetStringParam(cmd.Flag("pattern")), junitOutput, parallel, continueOnError)
 		}
 		return err
 	},
 }
 
 func init() {
-	var name, version, testCaseID, inputFile, inputFolder, userLabel, snapshot string
+	var name, version, testCaseID, testCaseName, inputFile, inputFolder, pattern, userLabel, snapshot, junitOutput string
+	var parallel int
+	var continueOnError bool
 
 	ExecuteTestCaseCmd.Flags().StringVarP(&name, "name", "n",
 		"", "Integration flow name")
@@ -129,10 +174,21 @@ func init() {
 		"", "Integration flow snapshot number")
 	ExecuteTestCaseCmd.Flags().StringVarP(&testCaseID, "test-case-id", "c",
 		"", "Test Case ID")
+	ExecuteTestCaseCmd.Flags().StringVarP(&testCaseName, "test-case-name", "",
+		"", "Test Case display name; used to look up the test case ID when test-case-id is not set")
 	ExecuteTestCaseCmd.Flags().StringVarP(&inputFile, "input-file", "f",
-		"", "Path to a file containing input parameters. For a sample see ./samples/test-config.json")
+		"", "Path to a file containing input parameters, or - to read them from stdin. "+
+			"For a sample see ./samples/test-config.json")
 	ExecuteTestCaseCmd.Flags().StringVarP(&inputFolder, "input-folder", "d",
 		"", "Path to a folder containing files for test case execution. File names MUST match display names")
+	ExecuteTestCaseCmd.Flags().StringVarP(&pattern, "pattern", "",
+		"", "Glob pattern to select the files in input-folder to execute, for ex: smoke_*")
+	ExecuteTestCaseCmd.Flags().IntVarP(&parallel, "parallel", "",
+		1, "Number of test cases in input-folder to execute concurrently")
+	ExecuteTestCaseCmd.Flags().StringVarP(&junitOutput, "junit-output", "",
+		"", "Path to write a JUnit XML report of the test case results")
+	ExecuteTestCaseCmd.Flags().BoolVarP(&continueOnError, "continue-on-error", "",
+		false, "Skip the files in input-folder that match no test case display name with a warning instead of failing")
 
 	_ = ExecuteTestCaseCmd.MarkFlagRequired("name")
 
@@ -28,7 +28,9 @@ var ExecCmd = &cobra.Command{
 
 func init() {
 	ExecCmd.AddCommand(ListExecCmd)
-	ExecCmd.AddCommand(SuspendCmd)
+	ExecCmd.AddCommand(GetExecCmd)
+	ExecCmd.AddCommand(WaitExecCmd)
+	ExecCmd.AddCommand(execSuspendCmd)
 	ExecCmd.AddCommand(CancelExecCmd)
 	ExecCmd.AddCommand(ReplayExecCmd)
 }
@@ -0,0 +1,91 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integrations
+
+import (
+	"errors"
+	"internal/apiclient"
+	"internal/client/integrations"
+	"internal/clilog"
+	"internal/cmd/utils"
+
+	"github.com/spf13/cobra"
+)
+
+// ExportTestCaseCmd to export the test cases of an integration flow version
+var ExportTestCaseCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export integration flow version test cases to a folder",
+	Long: "Export the test input parameters of each test case of an integration flow version to a file " +
+		"named after the test case display name. The folder can be passed to execute --input-folder",
+	Args: func(cmd *cobra.Command, args []string) (err error) {
+		cmdProject := utils.GetStringParam(cmd.Flag("proj"))
+		cmdRegion := utils.GetStringParam(cmd.Flag("reg"))
+		version := utils.GetStringParam(cmd.Flag("ver"))
+		userLabel := utils.GetStringParam(cmd.Flag("user-label"))
+		snapshot := utils.GetStringParam(cmd.Flag("snapshot"))
+
+		if err = apiclient.SetRegion(cmdRegion); err != nil {
+			return err
+		}
+		if userLabel == "" && version == "" && snapshot == "" {
+			return errors.New("at least one of userLabel, version or snapshot must be passed")
+		}
+		if err = validate(version, userLabel, snapshot, false); err != nil {
+			return err
+		}
+		return apiclient.SetProjectID(cmdProject)
+	},
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		cmd.SilenceUsage = true
+
+		version := utils.GetStringParam(cmd.Flag("ver"))
+		name := utils.GetStringParam(cmd.Flag("name"))
+		userLabel := utils.GetStringParam(cmd.Flag("user-label"))
+		snapshot := utils.GetStringParam(cmd.Flag("snapshot"))
+		folder := utils.GetStringParam(cmd.Flag("folder"))
+
+		if version == "" {
+			if version, err = integrations.GetVersion(name, userLabel, snapshot); err != nil {
+				return err
+			}
+		}
+
+		if err = integrations.ExportTestCases(name, version, folder); err != nil {
+			return err
+		}
+		clilog.Info.Printf("Exported the test cases of version %s to %s\n", version, folder)
+		return nil
+	},
+	Example: `Export the test cases of a user label to a folder: ` + GetExample(43),
+}
+
+func init() {
+	var name, version, userLabel, snapshot, folder string
+
+	ExportTestCaseCmd.Flags().StringVarP(&name, "name", "n",
+		"", "Integration flow name")
+	ExportTestCaseCmd.Flags().StringVarP(&version, "ver", "v",
+		"", "Integration flow version")
+	ExportTestCaseCmd.Flags().StringVarP(&userLabel, "user-label", "u",
+		"", "Integration flow user label")
+	ExportTestCaseCmd.Flags().StringVarP(&snapshot, "snapshot", "s",
+		"", "Integration flow snapshot number")
+	ExportTestCaseCmd.Flags().StringVarP(&folder, "folder", "f",
+		"", "Folder to export the test cases to")
+
+	_ = ExportTestCaseCmd.MarkFlagRequired("name")
+	_ = ExportTestCaseCmd.MarkFlagRequired("folder")
+}
@@ -0,0 +1,105 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integrations
+
+import (
+	"internal/apiclient"
+	"internal/client/integrations"
+	"internal/clilog"
+	"internal/cmd/utils"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// GenerateOverridesCmd to generate an overrides file for another region
+var GenerateOverridesCmd = &cobra.Command{
+	Use:   "generate-overrides",
+	Short: "Generate an overrides file to apply an integration flow to another region",
+	Long: "Compare the connections, triggers and config variables of an integration flow with the target " +
+		"project and region and generate the overrides file apply needs there. Connections that are not " +
+		"found in the target region and config variables referencing another region are reported. " +
+		"The values of an existing overrides file are kept",
+	Args: func(cmd *cobra.Command, args []string) (err error) {
+		if err = apiclient.SetRegion(utils.GetStringParam(cmd.Flag("target-region"))); err != nil {
+			return err
+		}
+		cmd.Flags().VisitAll(func(f *pflag.Flag) {
+			clilog.Debug.Printf("%s: %s\n", f.Name, f.Value)
+		})
+		return apiclient.SetProjectID(utils.GetStringParam(cmd.Flag("proj")))
+	},
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		cmd.SilenceUsage = true
+
+		integrationFile := utils.GetStringParam(cmd.Flag("integration"))
+		overridesFile := utils.GetStringParam(cmd.Flag("overrides"))
+		outputFile := utils.GetStringParam(cmd.Flag("output"))
+
+		integrationBody, err := utils.ReadFile(integrationFile)
+		if err != nil {
+			return err
+		}
+
+		overridesBody, err := integrations.GenerateOverrides(integrationBody)
+		if err != nil {
+			return err
+		}
+		if overridesFile != "" {
+			if _, err = os.Stat(overridesFile); err != nil {
+				return err
+			}
+			// the existing overrides are layered on top, the same way apply layers overrides files
+			existingBody, err := readOverrides([]string{overridesFile})
+			if err != nil {
+				return err
+			}
+			if len(existingBody) > 0 {
+				if overridesBody, err = mergeOverrides(overridesBody, existingBody); err != nil {
+					return err
+				}
+			}
+		}
+		if overridesBody, err = apiclient.PrettifyJson(overridesBody); err != nil {
+			return err
+		}
+		if outputFile != "" {
+			return apiclient.WriteByteArrayToFile(outputFile, false, overridesBody)
+		}
+
+		if apiclient.GetCmdPrintHttpResponseSetting() {
+			clilog.HTTPResponse.Println(string(overridesBody))
+		}
+		return nil
+	},
+	Example: `Generate the overrides of an integration for another region: ` + GetExample(50),
+}
+
+func init() {
+	var integrationFile, targetRegion, overridesFile, outputFile string
+
+	GenerateOverridesCmd.Flags().StringVarP(&integrationFile, "integration", "",
+		"", "Path to the integration flow JSON file")
+	GenerateOverridesCmd.Flags().StringVarP(&targetRegion, "target-region", "",
+		"", "Region the integration flow is applied to")
+	GenerateOverridesCmd.Flags().StringVarP(&overridesFile, "overrides", "",
+		"", "Path to an existing overrides file whose values are kept")
+	GenerateOverridesCmd.Flags().StringVarP(&outputFile, "output", "o",
+		"", "Write the overrides to this file instead of stdout")
+
+	_ = GenerateOverridesCmd.MarkFlagRequired("integration")
+	_ = GenerateOverridesCmd.MarkFlagRequired("target-region")
+}
@@ -0,0 +1,34 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integrations
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// GetCmd to get an integration flow version in the API shape
+var GetCmd = &cobra.Command{
+	Use:   "get",
+	Short: "Get an integration flow version",
+	Long: "Get an integration flow version as returned by the API, optionally written to a file. " +
+		"This is the same as integrations versions get",
+	Example: `Write a version to a file without its code: ` + GetExample(24),
+}
+
+func init() {
+	GetCmd.Args = GetVerCmd.Args
+	GetCmd.RunE = GetVerCmd.RunE
+	addGetVersionFlags(GetCmd)
+}
@@ -0,0 +1,92 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integrations
+
+import (
+	"encoding/json"
+	"internal/apiclient"
+	"internal/client/integrations"
+	"internal/clilog"
+	"internal/cmd/utils"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// GetExecCmd to get an execution of an integration
+var GetExecCmd = &cobra.Command{
+	Use:   "get",
+	Short: "Get an execution of an integration",
+	Long: "Get the state of an execution of an integration, the state of each task " +
+		"and the errors reported by the tasks",
+	Args: func(cmd *cobra.Command, args []string) (err error) {
+		cmdProject := cmd.Flag("proj")
+		cmdRegion := cmd.Flag("reg")
+
+		if err = apiclient.SetRegion(utils.GetStringParam(cmdRegion)); err != nil {
+			return err
+		}
+		cmd.Flags().VisitAll(func(f *pflag.Flag) {
+			clilog.Debug.Printf("%s: %s\n", f.Name, f.Value)
+		})
+		return apiclient.SetProjectID(utils.GetStringParam(cmdProject))
+	},
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		cmd.SilenceUsage = true
+
+		name := utils.GetStringParam(cmd.Flag("name"))
+		executionID := utils.GetStringParam(cmd.Flag("execution-id"))
+		full, _ := strconv.ParseBool(utils.GetStringParam(cmd.Flag("full")))
+
+		if full {
+			_, err = integrations.GetExecution(name, executionID)
+			return err
+		}
+
+		apiclient.DisableCmdPrintHttpResponse()
+		respBody, err := integrations.GetExecution(name, executionID)
+		if err != nil {
+			return err
+		}
+		summary, err := integrations.GetExecutionSummary(respBody)
+		if err != nil {
+			return err
+		}
+		if respBody, err = json.Marshal(summary); err != nil {
+			return err
+		}
+
+		apiclient.EnableCmdPrintHttpResponse()
+		apiclient.ClientPrintHttpResponse.Set(true)
+		return apiclient.PrettyPrint(respBody)
+	},
+	Example: `Get the state and task errors of an execution: ` + GetExample(31),
+}
+
+func init() {
+	var name, executionID string
+	var full bool
+
+	GetExecCmd.Flags().StringVarP(&name, "name", "n",
+		"", "Integration flow name")
+	GetExecCmd.Flags().StringVarP(&executionID, "execution-id", "e",
+		"", "Execution id")
+	GetExecCmd.Flags().BoolVarP(&full, "full", "",
+		false, "Print the full execution, including the parameters of every snapshot; default is false")
+
+	_ = GetExecCmd.MarkFlagRequired("name")
+	_ = GetExecCmd.MarkFlagRequired("execution-id")
+}
@@ -49,8 +49,6 @@ var GetTestCaseCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
 		cmd.SilenceUsage = true
 
-		var integrationBody []byte
-
 		version := utils.GetStringParam(cmd.Flag("ver"))
 		name := utils.GetStringParam(cmd.Flag("name"))
 		testCaseID := utils.GetStringParam(cmd.Flag("test-case-id"))
@@ -66,18 +64,20 @@ var GetTestCaseCmd = &cobra.Command{
 			}
 		} else {
 			if version != "" {
-				integrationBody, err = integrations.Get(name, version, true, false, false)
-			} else if snapshot != "" {
-				integrationBody, err = integrations.GetBySnapshot(name, snapshot, true, false, false)
-			} else if userLabel != "" {
-				integrationBody, err = integrations.GetByUserlabel(name, userLabel, true, false, false)
+				integrationBody, err := integrations.Get(name, version, true, false, false)
+				if err != nil {
+					return err
+				}
+				if version, err = integrations.GetIntegrationVersion(integrationBody); err != nil {
+					return err
+				}
+			} else if snapshot != "" || userLabel != "" {
+				if version, err = integrations.GetVersion(name, userLabel, snapshot); err != nil {
+					return err
+				}
 			} else {
 				return errors.New("latest version not found. Must pass oneOf version, snapshot or user-label or fix the integration name")
 			}
-			version, err = integrations.GetIntegrationVersion(integrationBody)
-			if err != nil {
-				return err
-			}
 		}
 
 		apiclient.EnableCmdPrintHttpResponse()
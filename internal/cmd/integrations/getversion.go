@@ -51,8 +51,12 @@ var GetVerCmd = &cobra.Command{
 		basic, _ := strconv.ParseBool(utils.GetStringParam(cmd.Flag("basic")))
 		configVar, _ := strconv.ParseBool(utils.GetStringParam(cmd.Flag("config-vars")))
 
+		excludeCode, _ := strconv.ParseBool(utils.GetStringParam(cmd.Flag("exclude-code")))
+
 		if configVar && (overrides || minimal || basic) {
 			return errors.New("config-vars cannot be combined with overrides, minimal or basic")
+		} else if excludeCode && (configVar || overrides || basic) {
+			return errors.New("exclude-code cannot be combined with config-vars, overrides or basic")
 		} else if err = validate(version, userLabel, snapshot, latest); err != nil {
 			return err
 		}
@@ -76,8 +80,10 @@ var GetVerCmd = &cobra.Command{
 		configVar, _ := strconv.ParseBool(utils.GetStringParam(cmd.Flag("config-vars")))
 		userLabel := utils.GetStringParam(cmd.Flag("user-label"))
 		snapshot := utils.GetStringParam(cmd.Flag("snapshot"))
+		outputFile := utils.GetStringParam(cmd.Flag("output"))
+		excludeCode, _ := strconv.ParseBool(utils.GetStringParam(cmd.Flag("exclude-code")))
 
-		if configVar {
+		if configVar || outputFile != "" || excludeCode {
 			apiclient.DisableCmdPrintHttpResponse()
 		}
 
@@ -113,33 +119,58 @@ var GetVerCmd = &cobra.Command{
 			}
 			return nil
 		}
+		if excludeCode {
+			if integrationBody, err = integrations.RemoveCode(integrationBody); err != nil {
+				return err
+			}
+		}
+		if outputFile != "" {
+			if integrationBody, err = apiclient.PrettifyJson(integrationBody); err != nil {
+				return err
+			}
+			return apiclient.WriteByteArrayToFile(outputFile, false, integrationBody)
+		}
+		if excludeCode {
+			apiclient.EnableCmdPrintHttpResponse()
+			apiclient.ClientPrintHttpResponse.Set(true)
+			return apiclient.PrettyPrint(integrationBody)
+		}
 		return err
 	},
 }
 
 func init() {
-	var name, userLabel, snapshot, version, basic string
-	minimal, overrides, configVar := false, false, false
+	addGetVersionFlags(GetVerCmd)
+}
+
+// addGetVersionFlags adds the flags shared by integrations get and integrations versions get
+func addGetVersionFlags(cmd *cobra.Command) {
+	var name, userLabel, snapshot, version, basic, outputFile string
+	minimal, overrides, configVar, excludeCode := false, false, false, false
 	latest := true
 
-	GetVerCmd.Flags().StringVarP(&name, "name", "n",
+	cmd.Flags().StringVarP(&name, "name", "n",
 		"", "Integration flow name")
-	GetVerCmd.Flags().StringVarP(&version, "ver", "v",
+	cmd.Flags().StringVarP(&version, "ver", "v",
 		"", "Integration flow version")
-	GetVerCmd.Flags().StringVarP(&snapshot, "snapshot", "s",
+	cmd.Flags().StringVarP(&snapshot, "snapshot", "s",
 		"", "Integration flow snapshot number")
-	GetVerCmd.Flags().StringVarP(&userLabel, "user-label", "u",
+	cmd.Flags().StringVarP(&userLabel, "user-label", "u",
 		"", "Integration flow user label")
-	GetVerCmd.Flags().StringVarP(&basic, "basic", "b",
+	cmd.Flags().StringVarP(&basic, "basic", "b",
 		"", "Returns snapshot and version only")
-	GetVerCmd.Flags().BoolVarP(&overrides, "overrides", "o",
+	cmd.Flags().BoolVarP(&overrides, "overrides", "o",
 		false, "Returns overrides only for integration")
-	GetVerCmd.Flags().BoolVarP(&minimal, "minimal", "",
+	cmd.Flags().BoolVarP(&minimal, "minimal", "",
 		false, "fields of the Integration to be returned; default is false")
-	GetVerCmd.Flags().BoolVarP(&configVar, "config-vars", "",
+	cmd.Flags().BoolVarP(&configVar, "config-vars", "",
 		false, "Returns config variables for the integration")
-	GetVerCmd.Flags().BoolVarP(&latest, "latest", "",
+	cmd.Flags().BoolVarP(&latest, "latest", "",
 		true, "Get the version with the highest snapshot number in SNAPSHOT state. If none found, selects the highest snapshot in DRAFT state; default is true")
+	cmd.Flags().StringVarP(&outputFile, "output", "",
+		"", "Write the integration version to this file instead of stdout")
+	cmd.Flags().BoolVarP(&excludeCode, "exclude-code", "",
+		false, "Clear the inline JavaScript and Jsonnet code of the tasks; default is false")
 
-	_ = GetVerCmd.MarkFlagRequired("name")
+	_ = cmd.MarkFlagRequired("name")
 }
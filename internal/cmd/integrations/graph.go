@@ -0,0 +1,117 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integrations
+
+import (
+	"fmt"
+	"internal/apiclient"
+	"internal/client/integrations"
+	"internal/clilog"
+	"internal/cmd/utils"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// GraphCmd to print the task graph of an integration flow version
+var GraphCmd = &cobra.Command{
+	Use:   "graph",
+	Short: "Print the task graph of an integration flow version",
+	Long: "Print the triggers, tasks and error catchers of an integration flow version and the edges " +
+		"between them as a Graphviz DOT or Mermaid flowchart",
+	Args: func(cmd *cobra.Command, args []string) (err error) {
+		cmdProject := cmd.Flag("proj")
+		cmdRegion := cmd.Flag("reg")
+		version := utils.GetStringParam(cmd.Flag("ver"))
+		userLabel := utils.GetStringParam(cmd.Flag("user-label"))
+		snapshot := utils.GetStringParam(cmd.Flag("snapshot"))
+		format := utils.GetStringParam(cmd.Flag("format"))
+
+		if format != integrations.GraphFormatDot && format != integrations.GraphFormatMermaid {
+			return fmt.Errorf("format must be one of %s or %s", integrations.GraphFormatDot, integrations.GraphFormatMermaid)
+		}
+		if err = apiclient.SetRegion(utils.GetStringParam(cmdRegion)); err != nil {
+			return err
+		}
+		if err = validate(version, userLabel, snapshot, false); err != nil {
+			return err
+		}
+		cmd.Flags().VisitAll(func(f *pflag.Flag) {
+			clilog.Debug.Printf("%s: %s\n", f.Name, f.Value)
+		})
+		return apiclient.SetProjectID(utils.GetStringParam(cmdProject))
+	},
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		cmd.SilenceUsage = true
+
+		var integrationBody []byte
+
+		name := utils.GetStringParam(cmd.Flag("name"))
+		version := utils.GetStringParam(cmd.Flag("ver"))
+		userLabel := utils.GetStringParam(cmd.Flag("user-label"))
+		snapshot := utils.GetStringParam(cmd.Flag("snapshot"))
+		format := utils.GetStringParam(cmd.Flag("format"))
+		outputFile := utils.GetStringParam(cmd.Flag("output"))
+
+		apiclient.DisableCmdPrintHttpResponse()
+
+		switch {
+		case version != "":
+			integrationBody, err = integrations.Get(name, version, false, false, false)
+		case userLabel != "":
+			integrationBody, err = integrations.GetByUserlabel(name, userLabel, false, false, false)
+		default:
+			integrationBody, err = integrations.GetBySnapshot(name, snapshot, false, false, false)
+		}
+		if err != nil {
+			return err
+		}
+
+		graph, err := integrations.Graph(integrationBody, format)
+		if err != nil {
+			return err
+		}
+		if outputFile != "" {
+			return apiclient.WriteByteArrayToFile(outputFile, false, []byte(graph))
+		}
+
+		apiclient.EnableCmdPrintHttpResponse()
+		if apiclient.GetCmdPrintHttpResponseSetting() {
+			clilog.HTTPResponse.Print(graph)
+		}
+		return nil
+	},
+	Example: `Write the task graph of a version as Graphviz DOT: ` + GetExample(40) + `
+Print the task graph of a version as a Mermaid flowchart: ` + GetExample(41),
+}
+
+func init() {
+	var name, version, userLabel, snapshot, format, outputFile string
+
+	GraphCmd.Flags().StringVarP(&name, "name", "n",
+		"", "Integration flow name")
+	GraphCmd.Flags().StringVarP(&version, "ver", "v",
+		"", "Integration flow version")
+	GraphCmd.Flags().StringVarP(&userLabel, "user-label", "u",
+		"", "Integration flow user label")
+	GraphCmd.Flags().StringVarP(&snapshot, "snapshot", "s",
+		"", "Integration flow snapshot number")
+	GraphCmd.Flags().StringVarP(&format, "format", "",
+		integrations.GraphFormatDot, "Graph format, must be one of dot or mermaid")
+	GraphCmd.Flags().StringVarP(&outputFile, "output", "o",
+		"", "Write the graph to this file instead of stdout")
+
+	_ = GraphCmd.MarkFlagRequired("name")
+}
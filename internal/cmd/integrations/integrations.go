@@ -15,6 +15,7 @@
 package integrations
 
 import (
+	"errors"
 	"fmt"
 	"internal/apiclient"
 	"internal/client/integrations"
@@ -24,7 +25,10 @@ import (
 	"path"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/spf13/cobra"
 )
@@ -57,6 +61,42 @@ var examples = []string{
 	`integrationcli integrations versions unpublish -n $name --default-token`,
 	`integrationcli integrations versions unpublish -n $name -u $userLabel --default-token`,
 	`integrationcli integrations apply -f . --env=dev --tests-folder=./test-configs --default-token`,
+	`integrationcli integrations versions delete -n $name --filter=state=DRAFT --older-than=30d --default-token`,
+	"integrationcli integrations versions publish -n $name -s $snapshot --config-vars=./config-variables/$name-config.json --config-var=httpbin=https://httpbin.org/get --default-token",
+	`integrationcli integrations versions testcases create -n $name -u $userLabel -c ./tests/$name.json --default-token`,
+	`integrationcli integrations versions label -n $name -v $version -u prod --move --default-token`,
+	`integrationcli integrations versions label -n $name -v $version -u "" --default-token`,
+	`integrationcli integrations get -n $name -u $userLabel --exclude-code --output ./$name.json --default-token`,
+	`integrationcli integrations preview-overrides --integration ./src/$name.json -o ./overrides/$env/overrides.json --default-token`,
+	`integrationcli integrations versions diff -n $name --ver-a 3 --ver-b 5 --default-token`,
+	`integrationcli integrations prune -f . --env=dev --dry-run --default-token`,
+	`integrationcli integrations prune -f . --env=dev --force --default-token`,
+	`integrationcli integrations execute -n $name --input-file ./payload.json --default-token`,
+	`integrationcli integrations execute -n $name -u $userLabel --trigger-id $triggerId --input-file ./payload.json --default-token`,
+	`integrationcli integrations executions get -n $name -e $executionId --default-token`,
+	`integrationcli integrations executions list -n $name --filter "state=FAILED" --limit 10 --default-token`,
+	`jq '.testCase' ./tests/$name.json | integrationcli integrations versions testcases create -n $name -u $userLabel -c - --default-token`,
+	`integrationcli integrations apply -f . --env=prod --target-file ./prod/target.json --default-token`,
+	`integrationcli integrations lint -f ./src/$name.json --default-token`,
+	`integrationcli integrations reconfigure -n $name -u $userLabel --config-vars-file ./prod/config-variables/$name-config.json --default-token`,
+	`integrationcli integrations executions wait -n $name -e $(integrationcli integrations execute -n $name --input-file ./payload.json --default-token | jq -r '.executionId') --timeout 5m --default-token`,
+	`integrationcli integrations apply -f . --env=dev --order endpoints,zones,custom-connectors,connectors,authconfigs,sfdcinstances,sfdcchannels,integration --default-token`,
+	`integrationcli integrations apply -f . --envs=dev,staging,prod --wait=true --default-token`,
+	`integrationcli integrations graph -n $name -v $version -o graph.dot --default-token && dot -Tsvg graph.dot -o graph.svg`,
+	`integrationcli integrations graph -n $name -u $userLabel --format mermaid --default-token`,
+	`integrationcli integrations apply -f . --env=prod --min-nodes=2 --max-nodes=5 --wait=true --default-token`,
+	`integrationcli integrations versions testcases export -n $name -u $userLabel -f ./tests --default-token`,
+	`version=$(integrationcli integrations resolve -n $name -s $snapshot --default-token)`,
+	`integrationcli integrations apply --git https://github.com/$owner/$repo --ref main --path scaffold --env=dev --default-token`,
+	`integrationcli integrations versions publish -n $name -v $version --at 2025-01-01T09:00:00Z --default-token`,
+	`integrationcli integrations schema -n $name -u $userLabel -o schema.json --default-token`,
+	`integrationcli integrations scaffold validate -f . --env=dev`,
+	`integrationcli integrations delete -n $name --dry-run --default-token`,
+	`integrationcli integrations generate-overrides --integration src/$name.json --target-region=$region -o overrides.json --default-token`,
+	`integrationcli integrations apply -f . --env=dev --checkpoint apply-checkpoint.json --default-token`,
+	`integrationcli integrations status -f . --env=dev --default-token`,
+	`integrationcli integrations suspensions resolve -n $name -e $execution --action LIFT --default-token`,
+	`integrationcli integrations apply -f . --env=dev --values values.yaml --default-token`,
 }
 
 func init() {
@@ -70,6 +110,8 @@ func init() {
 
 	Cmd.AddCommand(ListCmd)
 	Cmd.AddCommand(VerCmd)
+	Cmd.AddCommand(GetCmd)
+	Cmd.AddCommand(PreviewOverridesCmd)
 	Cmd.AddCommand(CleanCmd)
 	Cmd.AddCommand(ExecuteCmd)
 	Cmd.AddCommand(ExecCmd)
@@ -81,6 +123,16 @@ func init() {
 	Cmd.AddCommand(ScaffoldCmd)
 	Cmd.AddCommand(ApplyCmd)
 	Cmd.AddCommand(TestCasesCmd)
+	Cmd.AddCommand(MigrateCmd)
+	Cmd.AddCommand(PruneCmd)
+	Cmd.AddCommand(LintCmd)
+	Cmd.AddCommand(ReconfigureCmd)
+	Cmd.AddCommand(GraphCmd)
+	Cmd.AddCommand(ResolveCmd)
+	Cmd.AddCommand(SchemaCmd)
+	Cmd.AddCommand(GenerateOverridesCmd)
+	Cmd.AddCommand(StatusCmd)
+	Cmd.AddCommand(SuspendCmd)
 }
 
 func GetExample(i int) string {
@@ -127,7 +179,13 @@ func getLatestVersion(name string) (version string, err error) {
 	return version, nil
 }
 
-func executeAllTestCases(inputFolder string, name string, version string) (err error) {
+// executeAllTestCases runs the test cases in inputFolder whose file names match pattern.
+// An empty pattern runs all the test cases. Up to parallel test cases are executed at
+// the same time; results are always reported in file order. Files that match no test case
+// display name fail the run, or are skipped with a warning when continueOnError is set
+func executeAllTestCases(inputFolder string, name string, version string, pattern string,
+	junitOutput string, parallel int, continueOnError bool,
+) (err error) {
 
 	if stat, err := os.Stat(inputFolder); stat == nil || (err != nil && !stat.IsDir()) {
 		return fmt.Errorf("supplied path is not a folder: %v", err)
@@ -142,37 +200,203 @@ func executeAllTestCases(inputFolder string, name string, version string) (err e
 		}
 		if !info.IsDir() {
 			inputFileName := filepath.Base(path)
-			if rJSONFiles.MatchString(inputFileName) {
-				clilog.Info.Printf("Found test case file %s for integration: %s\n", inputFileName, name)
-				inputFiles = append(inputFiles, inputFileName)
+			if !rJSONFiles.MatchString(inputFileName) {
+				return nil
 			}
+			if pattern != "" {
+				if matched, _ := filepath.Match(pattern, inputFileName); !matched {
+					return nil
+				}
+			}
+			clilog.Info.Printf("Found test case file %s for integration: %s\n", inputFileName, name)
+			inputFiles = append(inputFiles, inputFileName)
 		}
 		return nil
 	})
 
-	if len(inputFiles) > 0 {
-		for _, inputFileName := range inputFiles {
-			content, err := utils.ReadFile(path.Join(inputFolder, inputFileName))
-			if err != nil {
-				return err
-			}
-			testDisplayName := strings.TrimSuffix(filepath.Base(inputFileName), filepath.Ext(filepath.Base(inputFileName)))
-			apiclient.ClientPrintHttpResponse.Set(false)
-			testCaseID, err := integrations.FindTestCase(name, version, testDisplayName, "")
-			apiclient.ClientPrintHttpResponse.Set(true)
-			if err != nil {
-				return err
+	if pattern != "" && len(inputFiles) == 0 {
+		return fmt.Errorf("no test case files in %s match the pattern %s", inputFolder, pattern)
+	}
+
+	testCaseIDs, err := getTestCaseIDs(name, version)
+	if err != nil {
+		return err
+	}
+	if inputFiles, err = matchTestCaseFiles(inputFiles, testCaseIDs, pattern == "", continueOnError); err != nil {
+		return err
+	}
+
+	var report *integrations.JUnitTestSuite
+	var errs []string
+
+	integrationBody, err := getIntegrationInputSchema(name, version)
+	if err != nil {
+		return err
+	}
+
+	if junitOutput != "" {
+		report = integrations.NewJUnitTestSuite(name, version)
+	}
+
+	type testCaseResult struct {
+		displayName string
+		resp        []byte
+		err         error
+		elapsed     time.Duration
+	}
+
+	results := make([]testCaseResult, len(inputFiles))
+
+	runTestCase := func(i int) {
+		inputFileName := inputFiles[i]
+		testDisplayName := strings.TrimSuffix(filepath.Base(inputFileName), filepath.Ext(filepath.Base(inputFileName)))
+		start := time.Now()
+		testCaseResp, err := executeTestCaseFile(path.Join(inputFolder, inputFileName), name, version,
+			testCaseIDs[testDisplayName], integrationBody)
+		results[i] = testCaseResult{testDisplayName, testCaseResp, err, time.Since(start)}
+	}
+
+	if parallel > 1 {
+		// responses are printed in file order once all the test cases complete
+		printSetting := apiclient.ClientPrintHttpResponse.Get()
+		apiclient.ClientPrintHttpResponse.Set(false)
+
+		var wg sync.WaitGroup
+		sem := make(chan struct{}, parallel)
+		for i := range inputFiles {
+			wg.Add(1)
+			sem <- struct{}{}
+			go func(i int) {
+				defer wg.Done()
+				defer func() { <-sem }()
+				runTestCase(i)
+			}(i)
+		}
+		wg.Wait()
+
+		apiclient.ClientPrintHttpResponse.Set(printSetting)
+		for _, r := range results {
+			if r.resp != nil {
+				apiclient.PrettyPrint(r.resp)
 			}
-			clilog.Info.Printf("Executing test cases from file %s for integration: %s\n", inputFileName, name)
-			testCaseResp, err := integrations.ExecuteTestCase(name, version, testCaseID, string(content))
-			if err != nil {
-				return err
+		}
+	} else {
+		for i := range inputFiles {
+			runTestCase(i)
+			// without a report, stop at the first failure
+			if report == nil {
+				if results[i].err != nil {
+					return results[i].err
+				}
+				if err = integrations.AssertTestExecutionResult(results[i].resp); err != nil {
+					return err
+				}
 			}
-			err = integrations.AssertTestExecutionResult(testCaseResp)
-			if err != nil {
-				return err
+		}
+	}
+
+	for _, r := range results {
+		if report != nil {
+			report.AddTestCase(r.displayName, r.elapsed, r.resp, r.err)
+		}
+		err := r.err
+		if err == nil {
+			err = integrations.AssertTestExecutionResult(r.resp)
+		}
+		if err != nil {
+			if report == nil {
+				return fmt.Errorf("%s: %w", r.displayName, err)
 			}
+			errs = append(errs, fmt.Sprintf("%s: %v", r.displayName, err))
+		}
+	}
+
+	if report != nil {
+		if err = report.Write(junitOutput); err != nil {
+			return err
 		}
+		clilog.Info.Printf("JUnit report written to %s\n", junitOutput)
+	}
+
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "\n"))
 	}
 	return nil
 }
+
+func executeTestCaseFile(inputFile string, name string, version string, testCaseID string,
+	integrationBody []byte,
+) (testCaseResp []byte, err error) {
+	content, err := utils.ReadFile(inputFile)
+	if err != nil {
+		return nil, err
+	}
+	if err = integrations.ValidateTestInput(content, integrationBody); err != nil {
+		return nil, fmt.Errorf("%s: %w", filepath.Base(inputFile), err)
+	}
+	clilog.Info.Printf("Executing test cases from file %s for integration: %s\n", filepath.Base(inputFile), name)
+	return integrations.ExecuteTestCase(name, version, testCaseID, string(content))
+}
+
+// getTestCaseIDs returns the test case ids of the integration version keyed by display name
+func getTestCaseIDs(name string, version string) (map[string]string, error) {
+	printSetting := apiclient.ClientPrintHttpResponse.Get()
+	apiclient.ClientPrintHttpResponse.Set(false)
+	respBody, err := integrations.ListAllTestCases(name, version)
+	apiclient.ClientPrintHttpResponse.Set(printSetting)
+	if err != nil {
+		return nil, err
+	}
+	return integrations.GetTestCaseIDs(respBody)
+}
+
+// matchTestCaseFiles returns the input files whose names match a test case display name.
+// Files that match nothing are an error unless continueOnError is set. When reportMissing
+// is set, the test cases without an input file are logged
+func matchTestCaseFiles(inputFiles []string, testCaseIDs map[string]string, reportMissing bool,
+	continueOnError bool,
+) (matched []string, err error) {
+	var unmatched []string
+	found := map[string]bool{}
+
+	for _, inputFileName := range inputFiles {
+		displayName := strings.TrimSuffix(inputFileName, filepath.Ext(inputFileName))
+		if _, ok := testCaseIDs[displayName]; !ok {
+			unmatched = append(unmatched, inputFileName)
+			continue
+		}
+		found[displayName] = true
+		matched = append(matched, inputFileName)
+	}
+
+	if len(unmatched) > 0 {
+		msg := fmt.Sprintf("no test case display name matches the files %s", strings.Join(unmatched, ", "))
+		if !continueOnError {
+			return nil, errors.New(msg)
+		}
+		clilog.Warning.Printf("%s, skipping them\n", msg)
+	}
+
+	if reportMissing {
+		var missing []string
+		for displayName := range testCaseIDs {
+			if !found[displayName] {
+				missing = append(missing, displayName)
+			}
+		}
+		if len(missing) > 0 {
+			sort.Strings(missing)
+			clilog.Warning.Printf("No input file found for the test cases %s\n", strings.Join(missing, ", "))
+		}
+	}
+	return matched, nil
+}
+
+// getIntegrationInputSchema returns the integration version used to validate test inputs
+func getIntegrationInputSchema(name string, version string) (integrationBody []byte, err error) {
+	if apiclient.GetCmdPrintHttpResponseSetting() {
+		apiclient.DisableCmdPrintHttpResponse()
+		defer apiclient.EnableCmdPrintHttpResponse()
+	}
+	return integrations.Get(name, version, false, true, false)
+}
@@ -0,0 +1,190 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integrations
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"internal/cmd/utils"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// inlineJsonnetImports returns the jsonnet file with each local import replaced by the
+// content of the imported file, so the data transformer does not depend on files that
+// are not sent with the integration. import "lib.libsonnet" becomes the parenthesized
+// library and importstr "file.txt" becomes a string literal. Paths are relative to the
+// importing file. Imports of files that do not exist, such as import "functions", are left
+// for the data transformer to resolve
+func inlineJsonnetImports(jsonnetFile string) (content string, err error) {
+	return inlineJsonnetFile(jsonnetFile, nil)
+}
+
+func inlineJsonnetFile(jsonnetFile string, importing []string) (content string, err error) {
+	if jsonnetFile, err = filepath.Abs(jsonnetFile); err != nil {
+		return "", err
+	}
+	if slices.Contains(importing, jsonnetFile) {
+		return "", fmt.Errorf("jsonnet import cycle: %s", strings.Join(append(importing, jsonnetFile), " -> "))
+	}
+	src, err := utils.ReadFile(jsonnetFile)
+	if err != nil {
+		return "", err
+	}
+	importing = append(importing, jsonnetFile)
+
+	s := string(src)
+	var b strings.Builder
+	for i := 0; i < len(s); {
+		switch {
+		case strings.HasPrefix(s[i:], "//") || s[i] == '#':
+			end := strings.IndexByte(s[i:], '\n')
+			if end < 0 {
+				end = len(s) - i
+			}
+			b.WriteString(s[i : i+end])
+			i += end
+		case strings.HasPrefix(s[i:], "/*"):
+			end := strings.Index(s[i+2:], "*/")
+			if end < 0 {
+				return "", fmt.Errorf("%s: unterminated comment", jsonnetFile)
+			}
+			b.WriteString(s[i : i+end+4])
+			i += end + 4
+		case strings.HasPrefix(s[i:], "|||"):
+			end := strings.Index(s[i+3:], "|||")
+			if end < 0 {
+				return "", fmt.Errorf("%s: unterminated text block", jsonnetFile)
+			}
+			b.WriteString(s[i : i+end+6])
+			i += end + 6
+		case s[i] == '@' && i+1 < len(s) && (s[i+1] == '"' || s[i+1] == '\''):
+			end, err := jsonnetVerbatimStringEnd(s, i+1)
+			if err != nil {
+				return "", fmt.Errorf("%s: %w", jsonnetFile, err)
+			}
+			b.WriteString(s[i:end])
+			i = end
+		case s[i] == '"' || s[i] == '\'':
+			end, err := jsonnetStringEnd(s, i)
+			if err != nil {
+				return "", fmt.Errorf("%s: %w", jsonnetFile, err)
+			}
+			b.WriteString(s[i:end])
+			i = end
+		case isJsonnetIdentifierStart(s, i):
+			end := i
+			for end < len(s) && isJsonnetIdentifierChar(s[end]) {
+				end++
+			}
+			keyword := s[i:end]
+			if keyword != "import" && keyword != "importstr" {
+				b.WriteString(keyword)
+				i = end
+				continue
+			}
+			start := end
+			for start < len(s) && strings.ContainsRune(" \t\r\n", rune(s[start])) {
+				start++
+			}
+			if start == len(s) || (s[start] != '"' && s[start] != '\'') {
+				return "", fmt.Errorf("%s: %s must be followed by a string literal", jsonnetFile, keyword)
+			}
+			stop, err := jsonnetStringEnd(s, start)
+			if err != nil {
+				return "", fmt.Errorf("%s: %w", jsonnetFile, err)
+			}
+			var importPath string
+			if err = json.Unmarshal([]byte(`"`+s[start+1:stop-1]+`"`), &importPath); err != nil {
+				return "", fmt.Errorf("%s: invalid import path %s", jsonnetFile, s[start:stop])
+			}
+			importPath = filepath.Join(filepath.Dir(jsonnetFile), importPath)
+			if _, err = os.Stat(importPath); errors.Is(err, fs.ErrNotExist) {
+				// not a local file, such as the functions library of the data transformer
+				b.WriteString(s[i:stop])
+				i = stop
+				continue
+			}
+
+			if keyword == "import" {
+				imported, err := inlineJsonnetFile(importPath, importing)
+				if err != nil {
+					return "", err
+				}
+				b.WriteString("(" + imported + "\n)")
+			} else {
+				imported, err := utils.ReadFile(importPath)
+				if err != nil {
+					return "", err
+				}
+				quoted, _ := json.Marshal(string(imported))
+				b.Write(quoted)
+			}
+			i = stop
+		default:
+			b.WriteByte(s[i])
+			i++
+		}
+	}
+	return b.String(), nil
+}
+
+// jsonnetStringEnd returns the index after the quoted string that starts at i
+func jsonnetStringEnd(s string, i int) (int, error) {
+	quote := s[i]
+	for j := i + 1; j < len(s); j++ {
+		switch s[j] {
+		case '\\':
+			j++
+		case quote:
+			return j + 1, nil
+		}
+	}
+	return 0, fmt.Errorf("unterminated string")
+}
+
+// jsonnetVerbatimStringEnd returns the index after the verbatim string whose quote is at i.
+// A verbatim string has no escapes, a doubled quote stands for the quote
+func jsonnetVerbatimStringEnd(s string, i int) (int, error) {
+	quote := s[i]
+	for j := i + 1; j < len(s); j++ {
+		if s[j] != quote {
+			continue
+		}
+		if j+1 < len(s) && s[j+1] == quote {
+			j++
+			continue
+		}
+		return j + 1, nil
+	}
+	return 0, fmt.Errorf("unterminated string")
+}
+
+func isJsonnetIdentifierStart(s string, i int) bool {
+	c := s[i]
+	if !(c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')) {
+		return false
+	}
+	// a field access such as std.import is not an import
+	return i == 0 || (!isJsonnetIdentifierChar(s[i-1]) && s[i-1] != '.')
+}
+
+func isJsonnetIdentifierChar(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
@@ -0,0 +1,108 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integrations
+
+import (
+	"internal/apiclient"
+	"internal/client/integrations"
+	"internal/clilog"
+	"internal/cmd/utils"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// LabelVerCmd to set the user label of an integration flow version
+var LabelVerCmd = &cobra.Command{
+	Use:   "label",
+	Short: "Set the user label of an integration flow version",
+	Long: "Set the user label of an existing integration flow version. Pass an empty user label to clear it. " +
+		"With --move, the label is cleared from any other version that has it",
+	Args: func(cmd *cobra.Command, args []string) (err error) {
+		cmdProject := cmd.Flag("proj")
+		cmdRegion := cmd.Flag("reg")
+
+		if err = apiclient.SetRegion(utils.GetStringParam(cmdRegion)); err != nil {
+			return err
+		}
+		cmd.Flags().VisitAll(func(f *pflag.Flag) {
+			clilog.Debug.Printf("%s: %s\n", f.Name, f.Value)
+		})
+		return apiclient.SetProjectID(utils.GetStringParam(cmdProject))
+	},
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		cmd.SilenceUsage = true
+
+		name := utils.GetStringParam(cmd.Flag("name"))
+		version := utils.GetStringParam(cmd.Flag("ver"))
+		userLabel := utils.GetStringParam(cmd.Flag("user-label"))
+		move, _ := strconv.ParseBool(utils.GetStringParam(cmd.Flag("move")))
+
+		if move && userLabel != "" {
+			if err = clearUserLabel(name, version, userLabel); err != nil {
+				return err
+			}
+		}
+
+		_, err = integrations.SetUserLabel(name, version, userLabel)
+		return err
+	},
+	Example: `Promote a version by moving the user label: ` + GetExample(22) + `
+Clear the user label of a version: ` + GetExample(23),
+}
+
+func init() {
+	var name, version, userLabel string
+	var move bool
+
+	LabelVerCmd.Flags().StringVarP(&name, "name", "n",
+		"", "Integration flow name")
+	LabelVerCmd.Flags().StringVarP(&version, "ver", "v",
+		"", "Integration flow version")
+	LabelVerCmd.Flags().StringVarP(&userLabel, "user-label", "u",
+		"", "Integration flow user label; pass an empty string to clear the label")
+	LabelVerCmd.Flags().BoolVarP(&move, "move", "",
+		false, "Clear the user label from any other version that has it; default is false")
+
+	_ = LabelVerCmd.MarkFlagRequired("name")
+	_ = LabelVerCmd.MarkFlagRequired("ver")
+	_ = LabelVerCmd.MarkFlagRequired("user-label")
+}
+
+// clearUserLabel clears the user label from the versions other than version
+func clearUserLabel(name string, version string, userLabel string) error {
+	apiclient.ClientPrintHttpResponse.Set(false)
+	defer apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting())
+
+	respBody, err := integrations.ListAllVersions(name, -1, "", "userLabel="+userLabel, "", 0)
+	if err != nil {
+		return err
+	}
+	summaries, err := integrations.GetVersionSummaries(respBody)
+	if err != nil {
+		return err
+	}
+	for _, summary := range summaries {
+		if summary.Version == version {
+			continue
+		}
+		clilog.Info.Printf("Clearing user label %s from version %s\n", userLabel, summary.Version)
+		if _, err = integrations.SetUserLabel(name, summary.Version, ""); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integrations
+
+import (
+	"fmt"
+	"internal/apiclient"
+	"internal/client/integrations"
+	"internal/clilog"
+	"internal/cmd/utils"
+	"path"
+	"path/filepath"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// LintCmd to check an integration flow file for common mistakes
+var LintCmd = &cobra.Command{
+	Use:   "lint",
+	Short: "Check an integration flow file for common mistakes",
+	Long: "Check an integration flow file for duplicate task ids, undeclared config variables, " +
+		"JavaScript tasks without a code file and authconfigs that are not in the region. " +
+		"Exits with a non-zero code when problems are found",
+	Args: func(cmd *cobra.Command, args []string) (err error) {
+		cmdProject := cmd.Flag("proj")
+		cmdRegion := cmd.Flag("reg")
+
+		if err = apiclient.SetRegion(utils.GetStringParam(cmdRegion)); err != nil {
+			return err
+		}
+		cmd.Flags().VisitAll(func(f *pflag.Flag) {
+			clilog.Debug.Printf("%s: %s\n", f.Name, f.Value)
+		})
+		return apiclient.SetProjectID(utils.GetStringParam(cmdProject))
+	},
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		cmd.SilenceUsage = true
+
+		integrationFile := utils.GetStringParam(cmd.Flag("file"))
+		javascriptFolder := utils.GetStringParam(cmd.Flag("javascript-folder"))
+		skipAuthconfigs, _ := strconv.ParseBool(utils.GetStringParam(cmd.Flag("skip-authconfigs")))
+
+		if javascriptFolder == "" {
+			javascriptFolder = path.Join(filepath.Dir(integrationFile), "javascript")
+		}
+
+		content, err := utils.ReadFile(integrationFile)
+		if err != nil {
+			return err
+		}
+		findings, err := integrations.Lint(content, javascriptFolder, !skipAuthconfigs)
+		if err != nil {
+			return err
+		}
+		return checkLintFindings(integrationFile, findings)
+	},
+	Example: `Check a scaffolded integration before applying it: ` + GetExample(35),
+}
+
+func init() {
+	var integrationFile, javascriptFolder string
+	var skipAuthconfigs bool
+
+	LintCmd.Flags().StringVarP(&integrationFile, "file", "f",
+		"", "Integration flow JSON file path")
+	LintCmd.Flags().StringVarP(&javascriptFolder, "javascript-folder", "",
+		"", "Folder with the JavaScript code files; default is the javascript folder next to the file")
+	LintCmd.Flags().BoolVarP(&skipAuthconfigs, "skip-authconfigs", "",
+		false, "Do not check the authconfigs exist in the region; default is false")
+
+	_ = LintCmd.MarkFlagRequired("file")
+}
+
+// checkLintFindings prints the findings and returns an error when there are any
+func checkLintFindings(integrationFile string, findings []string) error {
+	if len(findings) == 0 {
+		clilog.Info.Printf("No problems found in %s\n", integrationFile)
+		return nil
+	}
+	for _, finding := range findings {
+		clilog.Error.Printf("%s: %s\n", integrationFile, finding)
+	}
+	return fmt.Errorf("found %d problems in %s", len(findings), integrationFile)
+}
@@ -45,14 +45,26 @@ var ListExecCmd = &cobra.Command{
 		cmd.SilenceUsage = true
 
 		name := utils.GetStringParam(cmd.Flag("name"))
-		_, err = integrations.ListExecutions(name, pageSize,
-			utils.GetStringParam(cmd.Flag("pageToken")),
-			utils.GetStringParam(cmd.Flag("filter")),
-			utils.GetStringParam(cmd.Flag("orderBy")))
+		pageToken := utils.GetStringParam(cmd.Flag("pageToken"))
+		filter := utils.GetStringParam(cmd.Flag("filter"))
+		orderBy := utils.GetStringParam(cmd.Flag("orderBy"))
+
+		if execLimit > 0 {
+			respBody, err := integrations.ListAllExecutions(name, pageSize, pageToken, filter, orderBy, execLimit)
+			if err != nil {
+				return err
+			}
+			return apiclient.PrettyPrint(respBody)
+		}
+
+		_, err = integrations.ListExecutions(name, pageSize, pageToken, filter, orderBy)
 		return err
 	},
+	Example: `List the last failed executions across pages: ` + GetExample(32),
 }
 
+var execLimit int
+
 func init() {
 	var name, pageToken, filter, orderBy string
 
@@ -66,6 +78,8 @@ func init() {
 		"", "Filter results")
 	ListExecCmd.Flags().StringVarP(&orderBy, "orderBy", "",
 		"", "The results would be returned in order")
+	ListExecCmd.Flags().IntVarP(&execLimit, "limit", "",
+		0, "Follow the page tokens until this many executions are returned; by default a single page is returned")
 
 	_ = ListExecCmd.MarkFlagRequired("name")
 }
@@ -28,7 +28,7 @@ import (
 var ListSuspCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List all suspensions of an integration",
-	Long:  "List all suspensions of an integration",
+	Long:  "List all suspensions of an integration, or of one of its executions",
 	Args: func(cmd *cobra.Command, args []string) (err error) {
 		cmdProject := cmd.Flag("proj")
 		cmdRegion := cmd.Flag("reg")
@@ -45,7 +45,7 @@ var ListSuspCmd = &cobra.Command{
 		cmd.SilenceUsage = true
 
 		name := utils.GetStringParam(cmd.Flag("name"))
-		_, err = integrations.ListSuspensions(name, execution, pageSize,
+		_, err = integrations.ListSuspensions(name, utils.GetStringParam(cmd.Flag("execution")), pageSize,
 			utils.GetStringParam(cmd.Flag("pageToken")),
 			utils.GetStringParam(cmd.Flag("filter")),
 			utils.GetStringParam(cmd.Flag("orderBy")))
@@ -59,7 +59,7 @@ func init() {
 	ListSuspCmd.Flags().StringVarP(&name, "name", "n",
 		"", "Integration flow name")
 	ListSuspCmd.Flags().StringVarP(&execution, "execution", "e",
-		"", "Execution Id of the integration")
+		"-", "Execution Id of the integration; default is all the executions")
 	ListSuspCmd.Flags().IntVarP(&pageSize, "pageSize", "",
 		-1, "The maximum number of versions to return")
 	ListSuspCmd.Flags().StringVarP(&pageToken, "pageToken", "",
@@ -70,5 +70,4 @@ func init() {
 		"", "The results would be returned in order")
 
 	_ = ListSuspCmd.MarkFlagRequired("name")
-	_ = ListSuspCmd.MarkFlagRequired("execution")
 }
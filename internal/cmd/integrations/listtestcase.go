@@ -57,19 +57,40 @@ var ListTestCaseCmd = &cobra.Command{
 		filter := utils.GetStringParam(cmd.Flag("filter"))
 		orderBy := utils.GetStringParam(cmd.Flag("orderBy"))
 
-		if version != "" {
+		var respBody []byte
+
+		if version == "" {
+			if version, err = integrations.GetVersion(name, userLabel, snapshot); err != nil {
+				return err
+			}
+		}
+
+		if rawJSON {
 			_, err = integrations.ListTestCases(name, version, full, filter, pageSize, pageToken, orderBy)
-		} else if userLabel != "" {
-			_, err = integrations.ListTestCasesByUserlabel(name, userLabel, full, filter, pageSize, pageToken, orderBy)
-		} else if snapshot != "" {
-			_, err = integrations.ListTestCasesBySnapshot(name, snapshot, full, filter, pageSize, pageToken, orderBy)
+			return err
+		}
+
+		// the summary is built from the full list response, of every page unless a page is requested
+		apiclient.ClientPrintHttpResponse.Set(false)
+		if pageToken == "" {
+			respBody, err = integrations.ListAllTestCasesByFilter(name, version, filter, orderBy)
+		} else {
+			respBody, err = integrations.ListTestCases(name, version, true, filter, pageSize, pageToken, orderBy)
+		}
+		apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting())
+		if err != nil {
+			return err
 		}
 
-		return err
+		summary, err := integrations.GetTestCaseSummary(respBody)
+		if err != nil {
+			return err
+		}
+		return apiclient.PrettyPrint(summary)
 	},
 }
 
-var full bool
+var full, rawJSON bool
 
 func init() {
 	var name, version, userLabel, snapshot, pageToken, filter, orderBy string
@@ -84,10 +105,12 @@ func init() {
 		"", "Integration flow snapshot number")
 	ListTestCaseCmd.Flags().BoolVarP(&full, "full", "",
 		false, "Full test case response")
+	ListTestCaseCmd.Flags().BoolVarP(&rawJSON, "json", "",
+		false, "Print the raw list response instead of test case ids and display names")
 	ListTestCaseCmd.Flags().IntVarP(&pageSize, "pageSize", "",
 		-1, "The maximum number of versions to return")
 	ListTestCaseCmd.Flags().StringVarP(&pageToken, "pageToken", "",
-		"", "A page token, received from a previous call")
+		"", "A page token, received from a previous call; without it the summary lists the test cases of every page")
 	ListTestCaseCmd.Flags().StringVarP(&filter, "filter", "",
 		"", "Filter results")
 	ListTestCaseCmd.Flags().StringVarP(&orderBy, "orderBy", "",
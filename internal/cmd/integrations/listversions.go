@@ -15,10 +15,12 @@
 package integrations
 
 import (
+	"fmt"
 	"internal/apiclient"
 	"internal/client/integrations"
 	"internal/clilog"
 	"internal/cmd/utils"
+	"text/tabwriter"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
@@ -28,7 +30,8 @@ import (
 var ListVerCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List all versions of an integration flow",
-	Long:  "List all versions of an integration flow",
+	Long: "List all versions of an integration flow. Page tokens are followed until all versions, " +
+		"or the number set by limit, are returned",
 	Args: func(cmd *cobra.Command, args []string) (err error) {
 		cmdProject := cmd.Flag("proj")
 		cmdRegion := cmd.Flag("reg")
@@ -46,24 +49,46 @@ var ListVerCmd = &cobra.Command{
 
 		name := utils.GetStringParam(cmd.Flag("name"))
 		basic := utils.GetBasicInfo(cmd, "basic")
-		_, err = integrations.ListVersions(name, pageSize,
+
+		respBody, err := integrations.ListAllVersions(name, pageSize,
 			utils.GetStringParam(cmd.Flag("pageToken")),
 			utils.GetStringParam(cmd.Flag("filter")),
 			utils.GetStringParam(cmd.Flag("orderBy")),
-			false, false, basic)
-		return err
+			limit)
+		if err != nil {
+			return err
+		}
+
+		if rawJSON {
+			return apiclient.PrettyPrint(respBody)
+		}
+		if basic {
+			basicResp, err := integrations.GetBasicVersions(respBody)
+			if err != nil {
+				return err
+			}
+			return apiclient.PrettyPrint(basicResp)
+		}
+
+		summaries, err := integrations.GetVersionSummaries(respBody)
+		if err != nil {
+			return err
+		}
+		return printVersionTable(summaries)
 	},
 	Example: `Return a list of versions with basic information: ` + GetExample(3) + `
 Return the version that is published: ` + GetExample(4),
 }
 
+var limit int
+
 func init() {
 	var pageToken, filter, orderBy, name, basic string
 
 	ListVerCmd.Flags().StringVarP(&name, "name", "n",
 		"", "Integration flow name")
 	ListVerCmd.Flags().IntVarP(&pageSize, "pageSize", "",
-		-1, "The maximum number of versions to return")
+		-1, "The maximum number of versions to return per page")
 	ListVerCmd.Flags().StringVarP(&pageToken, "pageToken", "",
 		"", "A page token, received from a previous call")
 	ListVerCmd.Flags().StringVarP(&filter, "filter", "",
@@ -72,6 +97,23 @@ func init() {
 		"", "The results would be returned in order")
 	ListVerCmd.Flags().StringVarP(&basic, "basic", "b",
 		"", "Returns snapshot and version only; default is false")
+	ListVerCmd.Flags().IntVarP(&limit, "limit", "",
+		0, "The maximum number of versions to return across pages; default is all versions")
+	ListVerCmd.Flags().BoolVarP(&rawJSON, "json", "",
+		false, "Print the list response instead of a table of versions")
 
 	_ = ListVerCmd.MarkFlagRequired("name")
 }
+
+// printVersionTable prints the version, state, snapshot number and user label of each version
+func printVersionTable(summaries []integrations.VersionSummary) error {
+	if !apiclient.GetCmdPrintHttpResponseSetting() {
+		return nil
+	}
+	w := tabwriter.NewWriter(clilog.HTTPResponse.Writer(), 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tSTATE\tSNAPSHOT\tUSER LABEL")
+	for _, s := range summaries {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", s.Version, s.State, s.SnapshotNumber, s.UserLabel)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,162 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integrations
+
+import (
+	"errors"
+	"internal/apiclient"
+	"internal/client/integrations"
+	"internal/clilog"
+	"internal/cmd/utils"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// MigrateCmd to copy an integration version from one region to another
+var MigrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Migrate an integration version to another region",
+	Long:  "Create and publish an integration version from the source region in the target region",
+	Args: func(cmd *cobra.Command, args []string) (err error) {
+		cmdProject := utils.GetStringParam(cmd.Flag("proj"))
+		version := utils.GetStringParam(cmd.Flag("ver"))
+		userLabel := utils.GetStringParam(cmd.Flag("user-label"))
+		snapshot := utils.GetStringParam(cmd.Flag("snapshot"))
+		latest, _ := strconv.ParseBool(utils.GetStringParam(cmd.Flag("latest")))
+		sourceRegion := utils.GetStringParam(cmd.Flag("source-region"))
+		targetRegion := utils.GetStringParam(cmd.Flag("target-region"))
+
+		if sourceRegion == targetRegion {
+			return errors.New("source-region and target-region must be different")
+		}
+		if err = validate(version, userLabel, snapshot, latest); err != nil {
+			return err
+		}
+		cmd.Flags().VisitAll(func(f *pflag.Flag) {
+			clilog.Debug.Printf("%s: %s\n", f.Name, f.Value)
+		})
+		return apiclient.SetProjectID(cmdProject)
+	},
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		cmd.SilenceUsage = true
+
+		var integrationBody, overridesBody, configVarsBody []byte
+
+		name := utils.GetStringParam(cmd.Flag("name"))
+		version := utils.GetStringParam(cmd.Flag("ver"))
+		userLabel := utils.GetStringParam(cmd.Flag("user-label"))
+		snapshot := utils.GetStringParam(cmd.Flag("snapshot"))
+		sourceRegion := utils.GetStringParam(cmd.Flag("source-region"))
+		targetRegion := utils.GetStringParam(cmd.Flag("target-region"))
+		overridesFile := utils.GetStringParam(cmd.Flag("overrides"))
+		configVarsFile := utils.GetStringParam(cmd.Flag("config-vars"))
+		grantPermission, _ := strconv.ParseBool(utils.GetStringParam(cmd.Flag("grant-permission")))
+
+		if overridesFile != "" {
+			if overridesBody, err = utils.ReadFile(overridesFile); err != nil {
+				return err
+			}
+		}
+		if configVarsFile != "" {
+			if configVarsBody, err = utils.ReadFile(configVarsFile); err != nil {
+				return err
+			}
+		}
+
+		// switch regions for the duration of the command only
+		defaultRegion := apiclient.GetRegion()
+		defer apiclient.SetRegion(defaultRegion)
+
+		if err = apiclient.SetRegion(sourceRegion); err != nil {
+			return err
+		}
+
+		apiclient.DisableCmdPrintHttpResponse()
+
+		if ignoreLatest(version, userLabel, snapshot) {
+			if version, err = getLatestVersion(name); err != nil {
+				return err
+			}
+			apiclient.DisableCmdPrintHttpResponse()
+		}
+
+		clilog.Info.Printf("Reading integration %s from region %s\n", name, sourceRegion)
+		if version != "" {
+			integrationBody, err = integrations.Get(name, version, false, true, false)
+		} else if userLabel != "" {
+			integrationBody, err = integrations.GetByUserlabel(name, userLabel, false, true, false)
+		} else if snapshot != "" {
+			integrationBody, err = integrations.GetBySnapshot(name, snapshot, false, true, false)
+		} else {
+			return errors.New("latest version not found. Must pass oneOf version, snapshot or user-label or fix the integration name")
+		}
+		if err != nil {
+			return err
+		}
+
+		if err = apiclient.SetRegion(targetRegion); err != nil {
+			return err
+		}
+
+		clilog.Info.Printf("Creating integration %s in region %s\n", name, targetRegion)
+		respBody, err := integrations.CreateVersion(name, integrationBody, overridesBody, "", userLabel, grantPermission, false)
+		if err != nil {
+			return err
+		}
+		newVersion, err := getVersion(respBody)
+		if err != nil {
+			return err
+		}
+
+		clilog.Info.Printf("Publishing integration %s with version %s in region %s\n", name, newVersion, targetRegion)
+		if _, err = integrations.Publish(name, newVersion, configVarsBody); err != nil {
+			return err
+		}
+		clilog.Info.Printf("Integration %s migrated from %s to %s\n", name, sourceRegion, targetRegion)
+		return nil
+	},
+}
+
+func init() {
+	var name, version, userLabel, snapshot, sourceRegion, targetRegion, overridesFile, configVarsFile string
+	var latest, grantPermission bool
+
+	MigrateCmd.Flags().StringVarP(&name, "name", "n",
+		"", "Integration flow name")
+	MigrateCmd.Flags().StringVarP(&version, "ver", "v",
+		"", "Integration flow version in the source region")
+	MigrateCmd.Flags().StringVarP(&userLabel, "user-label", "u",
+		"", "Integration flow user label in the source region")
+	MigrateCmd.Flags().StringVarP(&snapshot, "snapshot", "s",
+		"", "Integration flow snapshot number in the source region")
+	MigrateCmd.Flags().BoolVarP(&latest, "latest", "",
+		true, "Migrates the version with the highest snapshot number in SNAPSHOT state. If none found, selects the highest snapshot in DRAFT state; default is true")
+	MigrateCmd.Flags().StringVarP(&sourceRegion, "source-region", "",
+		"", "Region to read the integration version from")
+	MigrateCmd.Flags().StringVarP(&targetRegion, "target-region", "",
+		"", "Region to create and publish the integration version in")
+	MigrateCmd.Flags().StringVarP(&overridesFile, "overrides", "o",
+		"", "Path to an overrides file applied to the integration in the target region")
+	MigrateCmd.Flags().StringVarP(&configVarsFile, "config-vars", "",
+		"", "Path to file containing config variables used to publish in the target region")
+	MigrateCmd.Flags().BoolVarP(&grantPermission, "grant-permission", "g",
+		false, "Grant the service account permission for integration triggers; default is false")
+
+	_ = MigrateCmd.MarkFlagRequired("name")
+	_ = MigrateCmd.MarkFlagRequired("source-region")
+	_ = MigrateCmd.MarkFlagRequired("target-region")
+}
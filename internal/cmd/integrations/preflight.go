@@ -0,0 +1,148 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integrations
+
+import (
+	"errors"
+	"fmt"
+	"internal/apiclient"
+	"internal/client/connections"
+	"internal/client/integrations"
+	"internal/clilog"
+	"internal/cmd/utils"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// preflightGrantPermissions checks the caller can grant service accounts access to
+// the resources referenced by new connectors and trigger overrides before apply
+// creates anything
+func preflightGrantPermissions(connectorsFolder string, overridesFiles []string, createSecret bool) (err error) {
+	var checks []apiclient.IAMCheck
+
+	if !skipConnectors {
+		restore := apiclient.UseConnectorContext()
+		connectorChecks, err := getConnectorIAMChecks(connectorsFolder, createSecret)
+		restore()
+		if err != nil {
+			return err
+		}
+		checks = append(checks, connectorChecks...)
+	}
+
+	overridesBytes, err := readOverrides(overridesFiles)
+	if err != nil {
+		return err
+	}
+	if len(overridesBytes) > 0 {
+		overridesChecks, err := integrations.GetIAMChecks(overridesBytes)
+		if err != nil {
+			return err
+		}
+		checks = append(checks, overridesChecks...)
+	}
+
+	errs := []string{}
+	for _, check := range mergeIAMChecks(checks) {
+		clilog.Info.Printf("Checking permissions %s on %s\n", strings.Join(check.Permissions, ", "), check.Resource())
+		missing, err := apiclient.TestIAMPermissions(check)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("unable to test permissions on %s: %v", check.Resource(), err))
+			continue
+		}
+		for _, permission := range missing {
+			errs = append(errs, fmt.Sprintf("missing permission %s on %s", permission, check.Resource()))
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.New("grant-permission preflight failed:\n" + strings.Join(errs, "\n"))
+	}
+	return nil
+}
+
+// getConnectorIAMChecks returns the checks for connectors that apply will create
+func getConnectorIAMChecks(connectorsFolder string, createSecret bool) (checks []apiclient.IAMCheck, err error) {
+	rJSONFiles := regexp.MustCompile(`(\S*)\.json$`)
+
+	if stat, err := os.Stat(connectorsFolder); err != nil || !stat.IsDir() {
+		return nil, nil
+	}
+
+	err = filepath.Walk(connectorsFolder, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() && filepath.Base(path) == subscriptionsFolderName {
+			return filepath.SkipDir
+		}
+		if info.IsDir() {
+			return nil
+		}
+		var connectionBytes []byte
+		connectionFile := filepath.Base(path)
+		if strings.HasSuffix(connectionFile, connectorTemplateExt) {
+			if connectionFile, connectionBytes, err = renderConnectorTemplate(path); err != nil {
+				return err
+			}
+		} else if !rJSONFiles.MatchString(connectionFile) {
+			return nil
+		}
+		// existing connectors are not created again
+		if _, err = connections.Get(getFilenameWithoutExtension(connectionFile), "", true, false); err == nil {
+			return nil
+		}
+		if connectionBytes == nil {
+			if connectionBytes, err = utils.ReadFile(path); err != nil {
+				return err
+			}
+		}
+		connectionChecks, err := connections.GetIAMChecks(connectionBytes, createSecret)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		checks = append(checks, connectionChecks...)
+		return nil
+	})
+	return checks, err
+}
+
+// mergeIAMChecks combines the permissions of checks on the same resource
+func mergeIAMChecks(checks []apiclient.IAMCheck) (merged []apiclient.IAMCheck) {
+	index := map[string]int{}
+	for _, check := range checks {
+		i, ok := index[check.Resource()]
+		if !ok {
+			index[check.Resource()] = len(merged)
+			merged = append(merged, apiclient.IAMCheck{Endpoint: check.Endpoint, Name: check.Name})
+			i = len(merged) - 1
+		}
+		for _, permission := range check.Permissions {
+			found := false
+			for _, p := range merged[i].Permissions {
+				if p == permission {
+					found = true
+					break
+				}
+			}
+			if !found {
+				merged[i].Permissions = append(merged[i].Permissions, permission)
+			}
+		}
+	}
+	return merged
+}
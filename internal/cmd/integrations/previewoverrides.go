@@ -0,0 +1,80 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integrations
+
+import (
+	"internal/apiclient"
+	"internal/client/integrations"
+	"internal/clilog"
+	"internal/cmd/utils"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// PreviewOverridesCmd to print an integration with overrides merged
+var PreviewOverridesCmd = &cobra.Command{
+	Use:   "preview-overrides",
+	Short: "Preview an integration flow with overrides applied",
+	Long: "Merge an overrides file into an integration flow the same way create and apply do, " +
+		"and print the result without creating a version. Connection overrides and the default " +
+		"service account of pubsub triggers are looked up in the project",
+	Args: func(cmd *cobra.Command, args []string) (err error) {
+		// the project and region are only needed to resolve connection overrides
+		_ = apiclient.SetRegion(utils.GetStringParam(cmd.Flag("reg")))
+		_ = apiclient.SetProjectID(utils.GetStringParam(cmd.Flag("proj")))
+		cmd.Flags().VisitAll(func(f *pflag.Flag) {
+			clilog.Debug.Printf("%s: %s\n", f.Name, f.Value)
+		})
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		cmd.SilenceUsage = true
+
+		integrationFile := utils.GetStringParam(cmd.Flag("integration"))
+		overridesFile := utils.GetStringParam(cmd.Flag("overrides"))
+
+		integrationBody, err := utils.ReadFile(integrationFile)
+		if err != nil {
+			return err
+		}
+		overridesBody, err := utils.ReadFile(overridesFile)
+		if err != nil {
+			return err
+		}
+		if overridesBody, err = utils.InterpolateEnv(overridesBody); err != nil {
+			return err
+		}
+
+		respBody, err := integrations.ApplyOverrides(integrationBody, overridesBody)
+		if err != nil {
+			return err
+		}
+		return apiclient.PrettyPrint(respBody)
+	},
+	Example: `Preview the overrides of an environment: ` + GetExample(25),
+}
+
+func init() {
+	var integrationFile, overridesFile string
+
+	PreviewOverridesCmd.Flags().StringVarP(&integrationFile, "integration", "",
+		"", "Path to the integration flow JSON file")
+	PreviewOverridesCmd.Flags().StringVarP(&overridesFile, "overrides", "o",
+		"", "Path to the overrides file")
+
+	_ = PreviewOverridesCmd.MarkFlagRequired("integration")
+	_ = PreviewOverridesCmd.MarkFlagRequired("overrides")
+}
@@ -0,0 +1,270 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integrations
+
+import (
+	"errors"
+	"fmt"
+	"internal/apiclient"
+	"internal/client/authconfigs"
+	"internal/client/connections"
+	"internal/clilog"
+	"internal/cmd/utils"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// PruneCmd to delete region resources that are not in a scaffold folder
+var PruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Delete region resources that are not present in a scaffold folder",
+	Long: "Delete the connectors, authconfigs, endpoint attachments and managed zones of the region that " +
+		"have no configuration file in the scaffold folder. A resource type is skipped when its folder " +
+		"is missing from the scaffold",
+	Args: func(cmd *cobra.Command, args []string) (err error) {
+		cmdProject := cmd.Flag("proj")
+		cmdRegion := cmd.Flag("reg")
+
+		if err = apiclient.SetRegion(utils.GetStringParam(cmdRegion)); err != nil {
+			return err
+		}
+		cmd.Flags().VisitAll(func(f *pflag.Flag) {
+			clilog.Debug.Printf("%s: %s\n", f.Name, f.Value)
+		})
+		return apiclient.SetProjectID(utils.GetStringParam(cmdProject))
+	},
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		cmd.SilenceUsage = true
+
+		pruneFolder := utils.GetStringParam(cmd.Flag("folder"))
+		pruneEnv := utils.GetStringParam(cmd.Flag("env"))
+		dryRun, _ := strconv.ParseBool(utils.GetStringParam(cmd.Flag("dry-run")))
+		force, _ := strconv.ParseBool(utils.GetStringParam(cmd.Flag("force")))
+
+		if pruneEnv != "" {
+			pruneFolder = path.Join(pruneFolder, pruneEnv)
+		}
+		if stat, err := os.Stat(pruneFolder); err != nil || !stat.IsDir() {
+			return fmt.Errorf("problem with supplied path, %w", err)
+		}
+
+		if valuesFile := utils.GetStringParam(cmd.Flag("values")); valuesFile != "" {
+			if templateValues, err = readTemplateValues(valuesFile); err != nil {
+				return err
+			}
+			defer func() { templateValues = nil }()
+		}
+
+		apiclient.DisableCmdPrintHttpResponse()
+
+		resources, err := getPruneResources(pruneFolder)
+		if err != nil {
+			return err
+		}
+		if len(resources) == 0 {
+			clilog.Info.Println("No resources to prune")
+			return nil
+		}
+
+		apiclient.EnableCmdPrintHttpResponse()
+		if err = printPruneResources(resources); err != nil {
+			return err
+		}
+		if dryRun {
+			return nil
+		}
+		if !force {
+			if err = utils.Confirm(fmt.Sprintf("Delete %d resources?", len(resources))); err != nil {
+				return err
+			}
+		}
+
+		apiclient.DisableCmdPrintHttpResponse()
+
+		errs := []string{}
+		for _, r := range resources {
+			clilog.Info.Printf("Deleting %s %s\n", r.kind, r.name)
+			if err = r.delete(); err != nil {
+				errs = append(errs, fmt.Sprintf("%s %s: %v", r.kind, r.name, err))
+			}
+		}
+		if len(errs) > 0 {
+			return errors.New(strings.Join(errs, "\n"))
+		}
+		return nil
+	},
+	Example: `Preview the resources that are not in the scaffold: ` + GetExample(27) + `
+Delete the resources that are not in the scaffold without prompting: ` + GetExample(28),
+}
+
+func init() {
+	var pruneFolder, pruneEnv, valuesFile string
+	var dryRun, force bool
+
+	PruneCmd.Flags().StringVarP(&pruneFolder, "folder", "f",
+		"", "Folder containing scaffolding configuration")
+	PruneCmd.Flags().StringVarP(&pruneEnv, "env", "e",
+		"", "Environment name for the scaffolding")
+	PruneCmd.Flags().BoolVarP(&dryRun, "dry-run", "",
+		false, "List the resources that would be deleted without deleting them; default is false")
+	PruneCmd.Flags().BoolVarP(&force, "force", "",
+		false, "Delete the resources without prompting for confirmation; default is false")
+	PruneCmd.Flags().StringVarP(&valuesFile, "values", "",
+		"", "YAML file with the values of the connector templates")
+
+	_ = PruneCmd.MarkFlagRequired("folder")
+}
+
+// pruneResource is a region resource without a configuration file in the scaffold
+type pruneResource struct {
+	kind   string
+	name   string
+	delete func() error
+}
+
+// getPruneResources returns the resources of each type whose scaffold folder exists,
+// but that have no configuration file in it
+func getPruneResources(folder string) (resources []pruneResource, err error) {
+	var scaffoldNames map[string]bool
+	var names []string
+
+	if scaffoldNames, err = getScaffoldNames(path.Join(folder, "connectors")); err != nil {
+		return nil, err
+	}
+	if scaffoldNames != nil {
+		if names, err = connections.ListNames(); err != nil {
+			return nil, err
+		}
+		for _, name := range names {
+			if !scaffoldNames[name] {
+				resources = append(resources, pruneResource{"connector", name, func() error {
+					_, err := connections.Delete(name)
+					return err
+				}})
+			}
+		}
+	}
+
+	if scaffoldNames, err = getScaffoldNames(path.Join(folder, "authconfigs")); err != nil {
+		return nil, err
+	}
+	if scaffoldNames != nil {
+		respBody, err := authconfigs.ListAll("")
+		if err != nil {
+			return nil, err
+		}
+		summaries, err := authconfigs.GetSummaries(respBody)
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range summaries {
+			// authconfig files are named after the display name
+			if !scaffoldNames[s.DisplayName] {
+				id := s.Id
+				resources = append(resources, pruneResource{"authconfig", s.DisplayName, func() error {
+					_, err := authconfigs.Delete(id)
+					return err
+				}})
+			}
+		}
+	}
+
+	if scaffoldNames, err = getScaffoldNames(path.Join(folder, "endpoints")); err != nil {
+		return nil, err
+	}
+	if scaffoldNames != nil {
+		if names, err = connections.ListEndpointNames(); err != nil {
+			return nil, err
+		}
+		for _, name := range names {
+			if !scaffoldNames[name] {
+				resources = append(resources, pruneResource{"endpoint", name, func() error {
+					_, err := connections.DeleteEndpoint(name)
+					return err
+				}})
+			}
+		}
+	}
+
+	if scaffoldNames, err = getScaffoldNames(path.Join(folder, "zones")); err != nil {
+		return nil, err
+	}
+	if scaffoldNames != nil {
+		if names, err = connections.ListZoneNames(); err != nil {
+			return nil, err
+		}
+		for _, name := range names {
+			if !scaffoldNames[name] {
+				resources = append(resources, pruneResource{"zone", name, func() error {
+					_, err := connections.DeleteZone(name)
+					return err
+				}})
+			}
+		}
+	}
+
+	return resources, nil
+}
+
+// getScaffoldNames returns the names of the files in the folder, or nil if the folder does not exist
+func getScaffoldNames(folder string) (names map[string]bool, err error) {
+	if stat, err := os.Stat(folder); err != nil || !stat.IsDir() {
+		clilog.Info.Printf("Skipping %s, the folder was not found\n", filepath.Base(folder))
+		return nil, nil
+	}
+	names = map[string]bool{}
+	err = filepath.Walk(folder, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		// event subscriptions are named after their connection
+		if info.IsDir() && info.Name() == subscriptionsFolderName {
+			return filepath.SkipDir
+		}
+		if info.IsDir() {
+			return nil
+		}
+		if strings.HasSuffix(path, connectorTemplateExt) {
+			name, err := getConnectorTemplateName(path)
+			if err != nil {
+				return err
+			}
+			names[name] = true
+			return nil
+		}
+		names[getFilenameWithoutExtension(filepath.Base(path))] = true
+		return nil
+	})
+	return names, err
+}
+
+func printPruneResources(resources []pruneResource) error {
+	if !apiclient.GetCmdPrintHttpResponseSetting() {
+		return nil
+	}
+	w := tabwriter.NewWriter(clilog.HTTPResponse.Writer(), 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TYPE\tNAME")
+	for _, r := range resources {
+		fmt.Fprintf(w, "%s\t%s\n", r.kind, r.name)
+	}
+	return w.Flush()
+}
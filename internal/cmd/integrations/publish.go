@@ -15,6 +15,7 @@
 package integrations
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"internal/apiclient"
@@ -23,6 +24,8 @@ import (
 	"internal/cmd/utils"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
@@ -32,7 +35,8 @@ import (
 var PublishVerCmd = &cobra.Command{
 	Use:   "publish",
 	Short: "Publish an integration flow version",
-	Long:  "Publish an integration flow version",
+	Long: "Publish an integration flow version. The API does not schedule publishing, with at or delay " +
+		"the command waits before publishing",
 	Args: func(cmd *cobra.Command, args []string) (err error) {
 		cmdProject := cmd.Flag("proj")
 		cmdRegion := cmd.Flag("reg")
@@ -40,7 +44,11 @@ var PublishVerCmd = &cobra.Command{
 		userLabel := utils.GetStringParam(cmd.Flag("user-label"))
 		snapshot := utils.GetStringParam(cmd.Flag("snapshot"))
 		latest, _ := strconv.ParseBool(utils.GetStringParam(cmd.Flag("latest")))
+		delay, _ := time.ParseDuration(utils.GetStringParam(cmd.Flag("delay")))
 
+		if _, err = getPublishDelay(utils.GetStringParam(cmd.Flag("at")), delay, time.Now()); err != nil {
+			return err
+		}
 		if err = apiclient.SetRegion(utils.GetStringParam(cmdRegion)); err != nil {
 			return err
 		}
@@ -80,6 +88,24 @@ var PublishVerCmd = &cobra.Command{
 			contents = []byte(configVarsJson)
 		}
 
+		if len(configVarList) > 0 {
+			if contents, err = mergeConfigVars(contents, configVarList, nil); err != nil {
+				return err
+			}
+		}
+
+		// the API does not schedule publishing, wait before publishing instead
+		delay, _ := time.ParseDuration(utils.GetStringParam(cmd.Flag("delay")))
+		delay, err = getPublishDelay(utils.GetStringParam(cmd.Flag("at")), delay, time.Now())
+		if err != nil {
+			return err
+		}
+		if delay > 0 {
+			clilog.Info.Printf("Waiting until %s to publish integration %s\n",
+				time.Now().Add(delay).Format(time.RFC3339), name)
+			time.Sleep(delay)
+		}
+
 		latest := ignoreLatest(version, userLabel, snapshot)
 
 		if latest {
@@ -119,12 +145,39 @@ var PublishVerCmd = &cobra.Command{
 		return err
 	},
 	Example: `Publishes an integration vesion with the highest snapshot in SNAPSHOT state: ` + GetExample(14) + `
-Publishes an integration version that matches user supplied snapshot number: ` + GetExample(15),
+Publishes an integration version that matches user supplied snapshot number: ` + GetExample(15) + `
+Publishes an integration version overriding a config variable from the file: ` + GetExample(20) + `
+Publishes an integration version at a given time: ` + GetExample(46),
+}
+
+var configVarList []string
+
+// getPublishDelay returns how long to wait before publishing, given the time to publish at
+// in RFC3339 format or the delay
+func getPublishDelay(at string, delay time.Duration, now time.Time) (time.Duration, error) {
+	if delay < 0 {
+		return 0, fmt.Errorf("delay %s must not be negative", delay)
+	}
+	if at == "" {
+		return delay, nil
+	}
+	if delay > 0 {
+		return 0, errors.New("at and delay cannot be used together")
+	}
+	publishTime, err := time.Parse(time.RFC3339, at)
+	if err != nil {
+		return 0, fmt.Errorf("invalid at %q, must be in RFC3339 format like 2025-01-01T09:00:00Z: %w", at, err)
+	}
+	if !publishTime.After(now) {
+		return 0, fmt.Errorf("at %s is not in the future", at)
+	}
+	return publishTime.Sub(now), nil
 }
 
 func init() {
-	var name, version, userLabel, snapshot, configVars, configVarsJson string
+	var name, version, userLabel, snapshot, configVars, configVarsJson, at string
 	var latest bool
+	var delay time.Duration
 
 	PublishVerCmd.Flags().StringVarP(&name, "name", "n",
 		"", "Integration flow name")
@@ -138,12 +191,97 @@ func init() {
 		"", "Path to file containing config variables")
 	PublishVerCmd.Flags().StringVarP(&configVarsJson, "config-vars-json", "",
 		"", "JSON string containing the config variables.")
+	PublishVerCmd.Flags().StringArrayVarP(&configVarList, "config-var", "",
+		nil, "Config variable as name=value, merged over config-vars or config-vars-json. "+
+			"The value is sent as a string. Repeat this flag for multiple config variables")
 	PublishVerCmd.Flags().BoolVarP(&latest, "latest", "",
 		true, "Publishes the integeration version with the highest snapshot number in SNAPSHOT state; default is true")
+	PublishVerCmd.Flags().StringVarP(&at, "at", "",
+		"", "Wait until this time, in RFC3339 format, before publishing; the command must keep running until then")
+	PublishVerCmd.Flags().DurationVarP(&delay, "delay", "",
+		0, "Wait for this duration, like 30m or 2h, before publishing")
 
 	_ = PublishVerCmd.MarkFlagRequired("name")
 }
 
+// mergeConfigVars sets each name=value pair over the config variables in contents. When types
+// holds the declared data types of the config variables, values are converted to that type
+func mergeConfigVars(contents []byte, configVarList []string, types map[string]string) ([]byte, error) {
+	configVars := map[string]interface{}{}
+
+	if len(contents) > 0 {
+		if err := json.Unmarshal(contents, &configVars); err != nil {
+			return nil, fmt.Errorf("unable to parse config variables: %w", err)
+		}
+	}
+
+	for _, configVar := range configVarList {
+		name, value, found := strings.Cut(configVar, "=")
+		if !found || name == "" {
+			return nil, fmt.Errorf("config-var %q must be of the form name=value", configVar)
+		}
+		key := getConfigVarKey(name)
+		if types == nil {
+			configVars[key] = value
+			continue
+		}
+		dataType, declared := types[key]
+		if !declared {
+			clilog.Warning.Printf("config variable %s is not declared in the integration\n", name)
+		}
+		typedValue, err := getConfigVarValue(value, dataType)
+		if err != nil {
+			return nil, fmt.Errorf("config variable %s: %w", name, err)
+		}
+		configVars[key] = typedValue
+	}
+
+	return json.Marshal(configVars)
+}
+
+// getConfigVarValue converts the value of a config variable to its declared data type
+func getConfigVarValue(value string, dataType string) (interface{}, error) {
+	switch dataType {
+	case "INT_VALUE":
+		i, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a valid integer", value)
+		}
+		return i, nil
+	case "DOUBLE_VALUE":
+		d, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a valid double", value)
+		}
+		return d, nil
+	case "BOOLEAN_VALUE":
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a valid boolean", value)
+		}
+		return b, nil
+	case "JSON_VALUE":
+		var j interface{}
+		if err := json.Unmarshal([]byte(value), &j); err != nil {
+			return nil, fmt.Errorf("%q is not valid json", value)
+		}
+		return j, nil
+	case "STRING_ARRAY":
+		return strings.Split(value, ","), nil
+	default:
+		return value, nil
+	}
+}
+
+// getConfigVarKey returns the key of a config variable wrapped in backticks with the CONFIG_ prefix
+func getConfigVarKey(name string) string {
+	name = strings.Trim(name, "`")
+	if !strings.HasPrefix(name, "CONFIG_") {
+		name = "CONFIG_" + name
+	}
+	return "`" + name + "`"
+}
+
 func validate(version string, userLabel string, snapshot string, latest bool) (err error) {
 	switch {
 	case !latest && (version == "" && userLabel == "" && snapshot == ""):
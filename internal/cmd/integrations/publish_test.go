@@ -0,0 +1,48 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integrations
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGetPublishDelay(t *testing.T) {
+	now := time.Date(2025, 1, 1, 8, 0, 0, 0, time.UTC)
+
+	if delay, err := getPublishDelay("", 0, now); err != nil || delay != 0 {
+		t.Errorf("getPublishDelay() = %v, %v, want no delay", delay, err)
+	}
+	if delay, err := getPublishDelay("", 30*time.Minute, now); err != nil || delay != 30*time.Minute {
+		t.Errorf("getPublishDelay() = %v, %v, want 30m", delay, err)
+	}
+	if delay, err := getPublishDelay("2025-01-01T10:00:00+01:00", 0, now); err != nil || delay != time.Hour {
+		t.Errorf("getPublishDelay() = %v, %v, want 1h", delay, err)
+	}
+
+	for _, test := range []struct {
+		at    string
+		delay time.Duration
+	}{
+		{"2025-01-01T07:00:00Z", 0},
+		{"2025-01-01 09:00", 0},
+		{"2025-01-01T09:00:00Z", time.Minute},
+		{"", -time.Minute},
+	} {
+		if _, err := getPublishDelay(test.at, test.delay, now); err == nil {
+			t.Errorf("getPublishDelay(%q, %v) succeeded, expected an error", test.at, test.delay)
+		}
+	}
+}
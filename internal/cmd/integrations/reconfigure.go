@@ -0,0 +1,108 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integrations
+
+import (
+	"fmt"
+	"internal/apiclient"
+	"internal/client/integrations"
+	"internal/clilog"
+	"internal/cmd/utils"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// ReconfigureCmd to set the config variables of a published integration flow version
+var ReconfigureCmd = &cobra.Command{
+	Use:   "reconfigure",
+	Short: "Set the config variables of a published integration flow version",
+	Long: "Set the config variables of a published integration flow version without creating a new version. " +
+		"The version is published again with the config variables; if it is rejected because the version " +
+		"is already published, it is unpublished and published with the config variables. " +
+		"${VAR} references in the config variables file are replaced with environment variables",
+	Args: func(cmd *cobra.Command, args []string) (err error) {
+		cmdProject := cmd.Flag("proj")
+		cmdRegion := cmd.Flag("reg")
+		version := utils.GetStringParam(cmd.Flag("ver"))
+		userLabel := utils.GetStringParam(cmd.Flag("user-label"))
+		snapshot := utils.GetStringParam(cmd.Flag("snapshot"))
+
+		if err = apiclient.SetRegion(utils.GetStringParam(cmdRegion)); err != nil {
+			return err
+		}
+		if err = validate(version, userLabel, snapshot, false); err != nil {
+			return err
+		}
+		cmd.Flags().VisitAll(func(f *pflag.Flag) {
+			clilog.Debug.Printf("%s: %s\n", f.Name, f.Value)
+		})
+		return apiclient.SetProjectID(utils.GetStringParam(cmdProject))
+	},
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		cmd.SilenceUsage = true
+
+		name := utils.GetStringParam(cmd.Flag("name"))
+		version := utils.GetStringParam(cmd.Flag("ver"))
+		userLabel := utils.GetStringParam(cmd.Flag("user-label"))
+		snapshot := utils.GetStringParam(cmd.Flag("snapshot"))
+		configVarsFile := utils.GetStringParam(cmd.Flag("config-vars-file"))
+
+		contents, err := utils.ReadFile(configVarsFile)
+		if err != nil {
+			return err
+		}
+		if contents, err = utils.InterpolateEnv(contents); err != nil {
+			return fmt.Errorf("unable to interpolate config variables file %s: %w", configVarsFile, err)
+		}
+
+		var filter, info string
+		switch {
+		case version != "":
+			info = "version " + version
+		case userLabel != "":
+			filter = "userLabel=" + userLabel
+			info = "user label " + userLabel
+		default:
+			filter = "snapshotNumber=" + snapshot
+			info = "snapshot number " + snapshot
+		}
+
+		if _, err = integrations.Reconfigure(name, version, filter, contents); err != nil {
+			return err
+		}
+		clilog.Info.Printf("Integration %s %s reconfigured successfully\n", name, info)
+		return nil
+	},
+	Example: `Set the config variables of the published version with a user label: ` + GetExample(36),
+}
+
+func init() {
+	var name, version, userLabel, snapshot, configVarsFile string
+
+	ReconfigureCmd.Flags().StringVarP(&name, "name", "n",
+		"", "Integration flow name")
+	ReconfigureCmd.Flags().StringVarP(&version, "ver", "v",
+		"", "Integration flow version")
+	ReconfigureCmd.Flags().StringVarP(&userLabel, "user-label", "u",
+		"", "Integration flow user label")
+	ReconfigureCmd.Flags().StringVarP(&snapshot, "snapshot", "s",
+		"", "Integration flow snapshot number")
+	ReconfigureCmd.Flags().StringVarP(&configVarsFile, "config-vars-file", "",
+		"", "Path to file containing the config variables")
+
+	_ = ReconfigureCmd.MarkFlagRequired("name")
+	_ = ReconfigureCmd.MarkFlagRequired("config-vars-file")
+}
@@ -0,0 +1,81 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integrations
+
+import (
+	"errors"
+	"internal/apiclient"
+	"internal/client/integrations"
+	"internal/clilog"
+	"internal/cmd/utils"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// ResolveCmd to print the version id of a snapshot or user label
+var ResolveCmd = &cobra.Command{
+	Use:   "resolve",
+	Short: "Print the version id of an integration flow snapshot or user label",
+	Long: "Print only the version id of an integration flow snapshot or user label, " +
+		"so it can be captured in a variable",
+	Args: func(cmd *cobra.Command, args []string) (err error) {
+		cmdProject := cmd.Flag("proj")
+		cmdRegion := cmd.Flag("reg")
+		userLabel := utils.GetStringParam(cmd.Flag("user-label"))
+		snapshot := utils.GetStringParam(cmd.Flag("snapshot"))
+
+		if (userLabel == "") == (snapshot == "") {
+			return errors.New("must pass oneOf snapshot or user-label")
+		}
+		if err = apiclient.SetRegion(utils.GetStringParam(cmdRegion)); err != nil {
+			return err
+		}
+		cmd.Flags().VisitAll(func(f *pflag.Flag) {
+			clilog.Debug.Printf("%s: %s\n", f.Name, f.Value)
+		})
+		return apiclient.SetProjectID(utils.GetStringParam(cmdProject))
+	},
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		cmd.SilenceUsage = true
+
+		name := utils.GetStringParam(cmd.Flag("name"))
+		userLabel := utils.GetStringParam(cmd.Flag("user-label"))
+		snapshot := utils.GetStringParam(cmd.Flag("snapshot"))
+
+		version, err := integrations.GetVersion(name, userLabel, snapshot)
+		if err != nil {
+			return err
+		}
+		if apiclient.GetCmdPrintHttpResponseSetting() {
+			clilog.HTTPResponse.Println(version)
+		}
+		return nil
+	},
+	Example: `Capture the version id of a snapshot: ` + GetExample(44),
+}
+
+func init() {
+	var name, userLabel, snapshot string
+
+	ResolveCmd.Flags().StringVarP(&name, "name", "n",
+		"", "Integration flow name")
+	ResolveCmd.Flags().StringVarP(&userLabel, "user-label", "u",
+		"", "Integration flow user label")
+	ResolveCmd.Flags().StringVarP(&snapshot, "snapshot", "s",
+		"", "Integration flow snapshot number")
+
+	_ = ResolveCmd.MarkFlagRequired("name")
+}
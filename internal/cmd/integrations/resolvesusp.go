@@ -0,0 +1,98 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integrations
+
+import (
+	"fmt"
+	"internal/apiclient"
+	"internal/client/integrations"
+	"internal/clilog"
+	"internal/cmd/utils"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// ResolveSuspCmd to resolve suspensions of an integration execution
+var ResolveSuspCmd = &cobra.Command{
+	Use:   "resolve",
+	Short: "Resolve the suspensions of an integration execution",
+	Long: "Resolve a suspension of an integration execution, or all its pending suspensions " +
+		"when no suspension id is passed. LIFT resumes the execution, RAISE rejects the suspension",
+	Args: func(cmd *cobra.Command, args []string) (err error) {
+		cmdProject := cmd.Flag("proj")
+		cmdRegion := cmd.Flag("reg")
+		action := strings.ToUpper(utils.GetStringParam(cmd.Flag("action")))
+
+		if action != "LIFT" && action != "RAISE" {
+			return fmt.Errorf("invalid action %s, must be one of LIFT or RAISE", action)
+		}
+		if err = apiclient.SetRegion(utils.GetStringParam(cmdRegion)); err != nil {
+			return err
+		}
+		cmd.Flags().VisitAll(func(f *pflag.Flag) {
+			clilog.Debug.Printf("%s: %s\n", f.Name, f.Value)
+		})
+		return apiclient.SetProjectID(utils.GetStringParam(cmdProject))
+	},
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		cmd.SilenceUsage = true
+
+		name := utils.GetStringParam(cmd.Flag("name"))
+		executionID := utils.GetStringParam(cmd.Flag("execution-id"))
+		suspensionID := utils.GetStringParam(cmd.Flag("suspension"))
+		action := utils.GetStringParam(cmd.Flag("action"))
+
+		if suspensionID != "" {
+			_, err = integrations.Resolve(name, executionID, suspensionID, action)
+			return err
+		}
+
+		suspensionIDs, err := integrations.ListPendingSuspensions(name, executionID)
+		if err != nil {
+			return err
+		}
+		if len(suspensionIDs) == 0 {
+			clilog.Info.Printf("Execution %s has no pending suspensions\n", executionID)
+			return nil
+		}
+		for _, id := range suspensionIDs {
+			clilog.Info.Printf("Resolving suspension %s with %s\n", id, strings.ToUpper(action))
+			if _, err = integrations.Resolve(name, executionID, id, action); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+	Example: `Lift all the pending suspensions of an execution: ` + GetExample(53),
+}
+
+func init() {
+	var name, executionID, suspensionID, action string
+
+	ResolveSuspCmd.Flags().StringVarP(&name, "name", "n",
+		"", "Integration flow name")
+	ResolveSuspCmd.Flags().StringVarP(&executionID, "execution-id", "e",
+		"", "Execution ID")
+	ResolveSuspCmd.Flags().StringVarP(&suspensionID, "suspension", "s",
+		"", "Suspension ID; default is all the pending suspensions of the execution")
+	ResolveSuspCmd.Flags().StringVarP(&action, "action", "",
+		"", "Resolution of the suspension, must be one of LIFT or RAISE")
+
+	_ = ResolveSuspCmd.MarkFlagRequired("name")
+	_ = ResolveSuspCmd.MarkFlagRequired("execution-id")
+	_ = ResolveSuspCmd.MarkFlagRequired("action")
+}
@@ -52,6 +52,8 @@ var ScaffoldCmd = &cobra.Command{
 			return err
 		} else if err = validate(version, userLabel, snapshot, latest); err != nil {
 			return err
+		} else if err = setFileSplitter(); err != nil {
+			return err
 		}
 		cmd.Flags().VisitAll(func(f *pflag.Flag) {
 			clilog.Debug.Printf("%s: %s\n", f.Name, f.Value)
@@ -62,7 +64,6 @@ var ScaffoldCmd = &cobra.Command{
 		cmd.SilenceUsage = true
 
 		const jsonExt = ".json"
-		var fileSplitter string
 		var integrationBody, overridesBody, testCasesBody []byte
 		version := utils.GetStringParam(cmd.Flag("ver"))
 		userLabel := utils.GetStringParam(cmd.Flag("user-label"))
@@ -70,12 +71,6 @@ var ScaffoldCmd = &cobra.Command{
 		name := utils.GetStringParam(cmd.Flag("name"))
 		githubAction, _ := strconv.ParseBool(utils.GetStringParam(cmd.Flag("github-action")))
 
-		if useUnderscore {
-			fileSplitter = utils.LegacyFileSplitter
-		} else {
-			fileSplitter = utils.DefaultFileSplitter
-		}
-
 		if folder != "" {
 			if stat, err := os.Stat(folder); err != nil || !stat.IsDir() {
 				return fmt.Errorf("problem with supplied path, %w", err)
@@ -209,40 +204,9 @@ var ScaffoldCmd = &cobra.Command{
 
 		// extract code
 		if extractCode {
-			codeMap, err := integrations.ExtractCode(integrationBody)
-			if err != nil {
+			if err = writeCodeFiles(baseFolder, integrationBody); err != nil {
 				return err
 			}
-			if len(codeMap["JavaScriptTask"]) > 0 {
-				javascriptFolder := path.Join(baseFolder, "src", "javascript")
-				if err = generateFolder(javascriptFolder); err != nil {
-					return err
-				}
-				clilog.Info.Printf("Found JavaScript files in the integration; generating separate files\n")
-				for taskId, taskContent := range codeMap["JavaScriptTask"] {
-					if err = apiclient.WriteByteArrayToFile(
-						path.Join(javascriptFolder, "javascript_"+string(taskId)+".js"),
-						false,
-						[]byte(taskContent)); err != nil {
-						return err
-					}
-				}
-			}
-			if len(codeMap["JsonnetMapperTask"]) > 0 {
-				jsonnetFolder := path.Join(baseFolder, "src", "datatransformer")
-				if err = generateFolder(jsonnetFolder); err != nil {
-					return err
-				}
-				clilog.Info.Printf("Found Jsonnet files in the integration; generating separate files\n")
-				for taskId, taskContent := range codeMap["JsonnetMapperTask"] {
-					if err = apiclient.WriteByteArrayToFile(
-						path.Join(jsonnetFolder, "datatransformer_"+string(taskId)+".jsonnet"),
-						false,
-						[]byte(taskContent)); err != nil {
-						return err
-					}
-				}
-			}
 		}
 
 		// auth config
@@ -264,6 +228,11 @@ var ScaffoldCmd = &cobra.Command{
 					}
 					authConfigName := getName(authConfigResp)
 					clilog.Info.Printf("Storing authconfig %s\n", authConfigName)
+					if secretPlaceholders {
+						if authConfigResp, err = authconfigs.SetSecretPlaceholders(authConfigResp, apiclient.GetProjectID()); err != nil {
+							return err
+						}
+					}
 					authConfigResp, err = apiclient.PrettifyJson(authConfigResp)
 					if err != nil {
 						return err
@@ -333,6 +302,9 @@ var ScaffoldCmd = &cobra.Command{
 							connectionResp); err != nil {
 							return err
 						}
+						if err = generateConnectionDependencies(connectionResp, folder); err != nil {
+							return err
+						}
 					}
 				}
 			}
@@ -426,8 +398,8 @@ Generate scaffold for integration and produce cloud deploy config: ` + GetExampl
 }
 
 var (
-	cloudBuild, cloudDeploy, skipConnectors, skipAuthconfigs, useUnderscore, extractCode bool
-	env                                                                                  string
+	cloudBuild, cloudDeploy, skipConnectors, skipAuthconfigs, useUnderscore, extractCode, secretPlaceholders bool
+	env, fileSplitter                                                                                        string
 )
 
 const jsonExt = ".json"
@@ -463,12 +435,58 @@ func init() {
 		false, "Exclude authconfigs from scaffold")
 	ScaffoldCmd.Flags().BoolVarP(&useUnderscore, "use-underscore", "",
 		false, "Use underscore as a file splitter; default is __")
+	ScaffoldCmd.Flags().StringVarP(&fileSplitter, "file-splitter", "",
+		utils.DefaultFileSplitter, "Separator used in custom connector and sfdc channel file names")
+	ScaffoldCmd.Flags().BoolVarP(&secretPlaceholders, "secret-placeholders", "",
+		false, "Store the authconfig passwords, tokens and secrets as placeholders for Secret Manager secrets named "+
+			"{displayName}-{field}, which apply reads when it creates the authconfig; default is false")
 	ScaffoldCmd.Flags().BoolVarP(&extractCode, "extract-code", "x",
 		false, "Extract JavaScript and Jsonnet code as separate files; default is false")
 	ScaffoldCmd.Flags().BoolVarP(&latest, "latest", "",
 		true, "Scaffolds the version with the highest snapshot number in SNAPSHOT state. If none found, selects the highest snapshot in DRAFT state; default is true")
 
 	_ = ScaffoldCmd.MarkFlagRequired("name")
+
+	ScaffoldCmd.AddCommand(ValidateScaffoldCmd)
+}
+
+// writeCodeFiles stores the code of each JavaScript and Data Transformer task in a file named
+// after the task id, for ex: src/javascript/javascript_1.js or src/datatransformer/datatransformer_12.jsonnet.
+// The task id keeps the numbering stable between exports and lets apply inject the code back into the task
+func writeCodeFiles(baseFolder string, integrationBody []byte) (err error) {
+	codeMap, err := integrations.GetCode(integrationBody)
+	if err != nil {
+		return err
+	}
+	codeFolders := []struct {
+		taskType, folder, prefix, ext string
+	}{
+		{"JavaScriptTask", "javascript", javascriptFilePrefix, ".js"},
+		{"JsonnetMapperTask", "datatransformer", jsonnetFilePrefix, ".jsonnet"},
+	}
+	for _, c := range codeFolders {
+		if len(codeMap[c.taskType]) == 0 {
+			continue
+		}
+		codeFolder := path.Join(baseFolder, "src", c.folder)
+		if err = generateFolder(codeFolder); err != nil {
+			return err
+		}
+		clilog.Info.Printf("Found %s code in the integration; generating separate files\n", c.folder)
+		for taskId, taskContent := range codeMap[c.taskType] {
+			// apply only reads code files with a numeric task id
+			if !rTaskId.MatchString(taskId) {
+				return fmt.Errorf("task id %s of the %s task is not numeric, its code cannot be extracted", taskId, c.taskType)
+			}
+			if err = apiclient.WriteByteArrayToFile(
+				path.Join(codeFolder, c.prefix+taskId+c.ext),
+				false,
+				[]byte(taskContent)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
 }
 
 func generateFolder(name string) (err error) {
@@ -479,6 +497,49 @@ func generateFolder(name string) (err error) {
 	return err
 }
 
+// generateConnectionDependencies stores the endpoint attachments and managed zones
+// used by the connection destinations
+func generateConnectionDependencies(connectionResp []byte, folder string) (err error) {
+	hosts, err := connections.GetConnectionHosts(connectionResp)
+	if err != nil {
+		return err
+	}
+	for _, host := range hosts {
+		endpointName, endpointResp, err := connections.FindEndpointByHost(host)
+		if err != nil {
+			return err
+		}
+		if endpointName != "" {
+			if err = writeDependency(path.Join(folder, "endpoints"), endpointName, endpointResp); err != nil {
+				return err
+			}
+			clilog.Info.Printf("Storing endpoint attachment %s\n", endpointName)
+			continue
+		}
+		zoneName, zoneResp, err := connections.FindZoneByHost(host)
+		if err != nil {
+			return err
+		}
+		if zoneName != "" {
+			if err = writeDependency(path.Join(folder, "zones"), zoneName, zoneResp); err != nil {
+				return err
+			}
+			clilog.Info.Printf("Storing managed zone %s\n", zoneName)
+		}
+	}
+	return nil
+}
+
+func writeDependency(dependencyFolder string, name string, content []byte) (err error) {
+	if err = generateFolder(dependencyFolder); err != nil {
+		return err
+	}
+	if content, err = apiclient.PrettifyJson(content); err != nil {
+		return err
+	}
+	return apiclient.WriteByteArrayToFile(path.Join(dependencyFolder, name+jsonExt), false, content)
+}
+
 func getName(authConfigResp []byte) string {
 	var m map[string]string
 	_ = json.Unmarshal(authConfigResp, &m)
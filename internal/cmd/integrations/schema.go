@@ -0,0 +1,111 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integrations
+
+import (
+	"internal/apiclient"
+	"internal/client/integrations"
+	"internal/clilog"
+	"internal/cmd/utils"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// SchemaCmd to export the JSON Schema of the parameters of an integration flow version
+var SchemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Export the JSON Schema of the parameters of an integration flow version",
+	Long: "Export a JSON Schema document describing the input and output parameters of an integration " +
+		"flow version, to generate clients and validate execution inputs",
+	Args: func(cmd *cobra.Command, args []string) (err error) {
+		cmdProject := cmd.Flag("proj")
+		cmdRegion := cmd.Flag("reg")
+		version := utils.GetStringParam(cmd.Flag("ver"))
+		userLabel := utils.GetStringParam(cmd.Flag("user-label"))
+		snapshot := utils.GetStringParam(cmd.Flag("snapshot"))
+
+		if err = apiclient.SetRegion(utils.GetStringParam(cmdRegion)); err != nil {
+			return err
+		}
+		if err = validate(version, userLabel, snapshot, false); err != nil {
+			return err
+		}
+		cmd.Flags().VisitAll(func(f *pflag.Flag) {
+			clilog.Debug.Printf("%s: %s\n", f.Name, f.Value)
+		})
+		return apiclient.SetProjectID(utils.GetStringParam(cmdProject))
+	},
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		cmd.SilenceUsage = true
+
+		var integrationBody []byte
+
+		name := utils.GetStringParam(cmd.Flag("name"))
+		version := utils.GetStringParam(cmd.Flag("ver"))
+		userLabel := utils.GetStringParam(cmd.Flag("user-label"))
+		snapshot := utils.GetStringParam(cmd.Flag("snapshot"))
+		outputFile := utils.GetStringParam(cmd.Flag("output"))
+
+		apiclient.DisableCmdPrintHttpResponse()
+
+		switch {
+		case version != "":
+			integrationBody, err = integrations.Get(name, version, false, false, false)
+		case userLabel != "":
+			integrationBody, err = integrations.GetByUserlabel(name, userLabel, false, false, false)
+		default:
+			integrationBody, err = integrations.GetBySnapshot(name, snapshot, false, false, false)
+		}
+		if err != nil {
+			return err
+		}
+
+		schema, err := integrations.Schema(integrationBody, name)
+		if err != nil {
+			return err
+		}
+		if schema, err = apiclient.PrettifyJson(schema); err != nil {
+			return err
+		}
+		if outputFile != "" {
+			return apiclient.WriteByteArrayToFile(outputFile, false, schema)
+		}
+
+		apiclient.EnableCmdPrintHttpResponse()
+		if apiclient.GetCmdPrintHttpResponseSetting() {
+			clilog.HTTPResponse.Println(string(schema))
+		}
+		return nil
+	},
+	Example: `Write the JSON Schema of the parameters of a version: ` + GetExample(47),
+}
+
+func init() {
+	var name, version, userLabel, snapshot, outputFile string
+
+	SchemaCmd.Flags().StringVarP(&name, "name", "n",
+		"", "Integration flow name")
+	SchemaCmd.Flags().StringVarP(&version, "ver", "v",
+		"", "Integration flow version")
+	SchemaCmd.Flags().StringVarP(&userLabel, "user-label", "u",
+		"", "Integration flow user label")
+	SchemaCmd.Flags().StringVarP(&snapshot, "snapshot", "s",
+		"", "Integration flow snapshot number")
+	SchemaCmd.Flags().StringVarP(&outputFile, "output", "o",
+		"", "Write the schema to this file instead of stdout")
+
+	_ = SchemaCmd.MarkFlagRequired("name")
+}
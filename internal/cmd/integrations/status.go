@@ -0,0 +1,351 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integrations
+
+import (
+	"fmt"
+	"internal/apiclient"
+	"internal/client/authconfigs"
+	"internal/client/connections"
+	"internal/client/integrations"
+	"internal/clilog"
+	"internal/cmd/utils"
+	"os"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// StatusCmd to compare a scaffold folder with the region
+var StatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Compare a scaffold folder with the resources of the region",
+	Long: "Report the scaffold resources that are missing in the region, the ones that exist but differ " +
+		"and the region resources that are not in the scaffold, without changing anything. The integration " +
+		"is compared with its published version and managed zones field by field, the other resource types " +
+		"are compared by name. A resource type is skipped when its folder is missing from the scaffold",
+	Args: func(cmd *cobra.Command, args []string) (err error) {
+		cmdProject := cmd.Flag("proj")
+		cmdRegion := cmd.Flag("reg")
+
+		if err = apiclient.SetRegion(utils.GetStringParam(cmdRegion)); err != nil {
+			return err
+		}
+		if err = setFileSplitter(); err != nil {
+			return err
+		}
+		cmd.Flags().VisitAll(func(f *pflag.Flag) {
+			clilog.Debug.Printf("%s: %s\n", f.Name, f.Value)
+		})
+		return apiclient.SetProjectID(utils.GetStringParam(cmdProject))
+	},
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		cmd.SilenceUsage = true
+
+		srcFolder := utils.GetStringParam(cmd.Flag("folder"))
+		statusEnv := utils.GetStringParam(cmd.Flag("env"))
+
+		folder := srcFolder
+		if statusEnv != "" {
+			folder = path.Join(folder, statusEnv)
+		}
+		if stat, err := os.Stat(folder); err != nil || !stat.IsDir() {
+			return fmt.Errorf("problem with supplied path, %w", err)
+		}
+
+		if valuesFile := utils.GetStringParam(cmd.Flag("values")); valuesFile != "" {
+			if templateValues, err = readTemplateValues(valuesFile); err != nil {
+				return err
+			}
+			defer func() { templateValues = nil }()
+		}
+
+		apiclient.DisableCmdPrintHttpResponse()
+
+		entries, err := getStatusEntries(srcFolder, statusEnv)
+		if err != nil {
+			return err
+		}
+
+		apiclient.EnableCmdPrintHttpResponse()
+		return printStatusEntries(entries)
+	},
+	Example: `Report the drift between the scaffold of an environment and the region: ` + GetExample(52),
+}
+
+func init() {
+	var folder, valuesFile string
+
+	StatusCmd.Flags().StringVarP(&folder, "folder", "f",
+		"", "Folder containing scaffolding configuration")
+	StatusCmd.Flags().StringVarP(&env, "env", "e",
+		"", "Environment name for the scaffolding")
+	StatusCmd.Flags().BoolVarP(&useUnderscore, "use-underscore", "",
+		false, "Use underscore as a file splitter; default is __")
+	StatusCmd.Flags().StringVarP(&fileSplitter, "file-splitter", "",
+		utils.DefaultFileSplitter, "Separator used in custom connector and sfdc channel file names")
+	StatusCmd.Flags().StringVarP(&valuesFile, "values", "",
+		"", "YAML file with the values of the connector templates")
+
+	_ = StatusCmd.MarkFlagRequired("folder")
+}
+
+const (
+	statusMissing = "missing"
+	statusDiffers = "differs"
+	statusExtra   = "not in scaffold"
+	statusExists  = "exists"
+	statusInSync  = "in sync"
+)
+
+// statusEntry is the state of a scaffold or region resource
+type statusEntry struct {
+	kind   string
+	name   string
+	status string
+	detail string
+}
+
+// getStatusEntries compares the resources of the scaffold for the environment with the region
+func getStatusEntries(srcFolder string, env string) (entries []statusEntry, err error) {
+	var scaffoldNames map[string]bool
+	var names []string
+
+	folder := srcFolder
+	if env != "" {
+		folder = path.Join(folder, env)
+	}
+
+	if scaffoldNames, err = getScaffoldNames(path.Join(folder, "authconfigs")); err != nil {
+		return nil, err
+	}
+	if scaffoldNames != nil {
+		respBody, err := authconfigs.ListAll("")
+		if err != nil {
+			return nil, err
+		}
+		summaries, err := authconfigs.GetSummaries(respBody)
+		if err != nil {
+			return nil, err
+		}
+		// authconfig files are named after the display name
+		names = nil
+		for _, s := range summaries {
+			names = append(names, s.DisplayName)
+		}
+		entries = append(entries, compareNames("authconfig", scaffoldNames, names, nil)...)
+	}
+
+	if scaffoldNames, err = getScaffoldNames(path.Join(folder, "endpoints")); err != nil {
+		return nil, err
+	}
+	if scaffoldNames != nil {
+		if names, err = connections.ListEndpointNames(); err != nil {
+			return nil, err
+		}
+		entries = append(entries, compareNames("endpoint", scaffoldNames, names, nil)...)
+	}
+
+	zonesFolder := path.Join(folder, "zones")
+	if scaffoldNames, err = getScaffoldNames(zonesFolder); err != nil {
+		return nil, err
+	}
+	if scaffoldNames != nil {
+		if names, err = connections.ListZoneNames(); err != nil {
+			return nil, err
+		}
+		entries = append(entries, compareNames("zone", scaffoldNames, names, func(name string) ([]string, error) {
+			return diffZone(path.Join(zonesFolder, name+jsonExt), name)
+		})...)
+	}
+
+	if scaffoldNames, err = getScaffoldNames(path.Join(folder, "connectors")); err != nil {
+		return nil, err
+	}
+	if scaffoldNames != nil {
+		if names, err = connections.ListNames(); err != nil {
+			return nil, err
+		}
+		entries = append(entries, compareNames("connector", scaffoldNames, names, nil)...)
+	}
+
+	integrationEntry, err := getIntegrationStatus(srcFolder, env)
+	if err != nil {
+		return nil, err
+	}
+	if integrationEntry != nil {
+		entries = append(entries, *integrationEntry)
+	}
+	return entries, nil
+}
+
+// compareNames returns the scaffold resources missing in the region, the region resources not in
+// the scaffold and the resources in both. The resources in both are compared with diff, if set
+func compareNames(kind string, scaffoldNames map[string]bool, regionNames []string,
+	diff func(name string) ([]string, error),
+) (entries []statusEntry) {
+	inRegion := map[string]bool{}
+	for _, name := range regionNames {
+		inRegion[name] = true
+	}
+
+	var names []string
+	for name := range scaffoldNames {
+		names = append(names, name)
+	}
+	for name := range inRegion {
+		if !scaffoldNames[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		entry := statusEntry{kind: kind, name: name}
+		switch {
+		case !inRegion[name]:
+			entry.status = statusMissing
+		case !scaffoldNames[name]:
+			entry.status = statusExtra
+		case diff == nil:
+			entry.status = statusExists
+		default:
+			fields, err := diff(name)
+			switch {
+			case err != nil:
+				entry.status, entry.detail = statusExists, fmt.Sprintf("unable to compare: %v", err)
+			case len(fields) > 0:
+				entry.status, entry.detail = statusDiffers, strings.Join(fields, ", ")
+			default:
+				entry.status = statusInSync
+			}
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// diffZone returns the fields of the managed zone file that differ from the deployed zone
+func diffZone(zoneFile string, name string) ([]string, error) {
+	zoneBytes, err := utils.ReadFile(zoneFile)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := connections.GetZone(name, true)
+	if err != nil {
+		return nil, err
+	}
+	updateMask, _, err := connections.DiffZone(respBody, zoneBytes)
+	return updateMask, err
+}
+
+// getIntegrationStatus compares the integration of the scaffold, with its code files and
+// overrides, to its published version. It returns nil when the scaffold has no integration
+func getIntegrationStatus(srcFolder string, env string) (*statusEntry, error) {
+	integrationFolder := path.Join(srcFolder, "src")
+	integrationNames := getIntegrationFiles(integrationFolder)
+	if len(integrationNames) == 0 {
+		return nil, nil
+	}
+	name := getFilenameWithoutExtension(integrationNames[0])
+	entry := &statusEntry{kind: "integration", name: name}
+
+	names, err := integrations.ListNames()
+	if err != nil {
+		return nil, err
+	}
+	if !slices.Contains(names, name) {
+		entry.status = statusMissing
+		return entry, nil
+	}
+
+	respBody, err := integrations.ListAllVersions(name, -1, "", "state=ACTIVE", "", 1)
+	if err != nil {
+		return nil, err
+	}
+	summaries, err := integrations.GetVersionSummaries(respBody)
+	if err != nil {
+		return nil, err
+	}
+	if len(summaries) == 0 {
+		entry.status, entry.detail = statusDiffers, "no published version"
+		return entry, nil
+	}
+	published, err := integrations.Get(name, summaries[0].Version, false, false, false)
+	if err != nil {
+		return nil, err
+	}
+
+	integrationBytes, err := utils.ReadFile(path.Join(integrationFolder, integrationNames[0]))
+	if err != nil {
+		return nil, err
+	}
+	codeMap, err := processCodeFolders(path.Join(integrationFolder, "javascript"),
+		path.Join(integrationFolder, "datatransformer"))
+	if err != nil {
+		return nil, err
+	}
+	if integrationBytes, err = integrations.SetCode(integrationBytes, codeMap); err != nil {
+		return nil, err
+	}
+	overridesBytes, err := readOverrides(getOverridesFiles(srcFolder, env))
+	if err != nil {
+		return nil, err
+	}
+	if len(overridesBytes) > 0 {
+		if integrationBytes, err = integrations.ApplyOverrides(integrationBytes, overridesBytes); err != nil {
+			return nil, err
+		}
+	}
+
+	changes, err := integrations.DiffVersions(published, integrationBytes)
+	if err != nil {
+		return nil, err
+	}
+	if len(changes) == 0 {
+		entry.status = statusInSync
+		return entry, nil
+	}
+	var fields []string
+	for _, c := range changes {
+		fields = append(fields, fmt.Sprintf("%s %s %s", c.Kind, c.Type, c.Id))
+	}
+	entry.status, entry.detail = statusDiffers, strings.Join(fields, ", ")
+	return entry, nil
+}
+
+func printStatusEntries(entries []statusEntry) error {
+	counts := map[string]int{}
+	for _, e := range entries {
+		counts[e.status]++
+	}
+	clilog.Info.Printf("%d resources missing in the region, %d differ, %d not in the scaffold\n",
+		counts[statusMissing], counts[statusDiffers], counts[statusExtra])
+
+	if !apiclient.GetCmdPrintHttpResponseSetting() {
+		return nil
+	}
+	w := tabwriter.NewWriter(clilog.HTTPResponse.Writer(), 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TYPE\tNAME\tSTATUS\tDETAIL")
+	for _, e := range entries {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", e.kind, e.name, e.status, e.detail)
+	}
+	return w.Flush()
+}
@@ -22,11 +22,39 @@ import (
 var SuspendCmd = &cobra.Command{
 	Use:     "suspensions",
 	Aliases: []string{"susp"},
-	Short:   "Manage suspensions of an integrations flow version",
-	Long:    "Manage suspensions of an integrations flow version",
+	Short:   "Manage suspensions of integration executions",
+	Long:    "Manage the suspensions of integration executions waiting on a suspend task",
+}
+
+// execSuspendCmd keeps the suspensions commands under executions, where they used to be
+var execSuspendCmd = &cobra.Command{
+	Use:        "suspensions",
+	Aliases:    []string{"susp"},
+	Short:      SuspendCmd.Short,
+	Long:       SuspendCmd.Long,
+	Deprecated: "use integrations suspensions instead",
 }
 
 func init() {
 	SuspendCmd.AddCommand(ListSuspCmd)
 	SuspendCmd.AddCommand(LiftSuspCmd)
+	SuspendCmd.AddCommand(ResolveSuspCmd)
+
+	execSuspendCmd.AddCommand(deprecatedSuspCmd(ListSuspCmd))
+	execSuspendCmd.AddCommand(deprecatedSuspCmd(LiftSuspCmd))
+}
+
+// deprecatedSuspCmd returns a copy of the suspensions command, with the same flags,
+// for the deprecated integrations executions suspensions path
+func deprecatedSuspCmd(cmd *cobra.Command) *cobra.Command {
+	c := &cobra.Command{
+		Use:        cmd.Use,
+		Short:      cmd.Short,
+		Long:       cmd.Long,
+		Args:       cmd.Args,
+		RunE:       cmd.RunE,
+		Deprecated: "use integrations suspensions " + cmd.Name() + " instead",
+	}
+	c.Flags().AddFlagSet(cmd.Flags())
+	return c
 }
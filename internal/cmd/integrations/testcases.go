@@ -31,4 +31,5 @@ func init() {
 	TestCasesCmd.AddCommand(ListTestCaseCmd)
 	TestCasesCmd.AddCommand(CrtTestCaseCmd)
 	TestCasesCmd.AddCommand(ExecuteTestCaseCmd)
+	TestCasesCmd.AddCommand(ExportTestCaseCmd)
 }
@@ -26,11 +26,12 @@ import (
 	"github.com/spf13/pflag"
 )
 
-// UnPublishVerCmd to publish an integration flow version
+// UnPublishVerCmd to unpublish an integration flow version
 var UnPublishVerCmd = &cobra.Command{
 	Use:   "unpublish",
 	Short: "Unpublish an integration flow version",
-	Long:  "Unpublish an integration flow version",
+	Long: "Unpublish an integration flow version, which takes it out of service without deleting it. " +
+		"The version can be published again later",
 	Args: func(cmd *cobra.Command, args []string) (err error) {
 		cmdProject := cmd.Flag("proj")
 		cmdRegion := cmd.Flag("reg")
@@ -85,17 +86,20 @@ var UnPublishVerCmd = &cobra.Command{
 			info = "version " + version
 		} else if version != "" {
 			_, err = integrations.Unpublish(name, version)
+			info = "version " + version
 		} else if userLabel != "" {
 			_, err = integrations.UnpublishUserLabel(name, userLabel)
+			info = "user label " + userLabel
 		} else if snapshot != "" {
 			_, err = integrations.UnpublishSnapshot(name, snapshot)
+			info = "snapshot " + snapshot
 		}
 		if err == nil {
 			clilog.Info.Printf("Integration %s %s unpublished successfully\n", name, info)
 		}
 		return err
 	},
-	Example: `Unpublishes an integration vesion with the highest snapshot in SNAPHOST state: ` + GetExample(16) + `
+	Example: `Unpublishes an integration version with the highest snapshot in SNAPSHOT state: ` + GetExample(16) + `
 Unpublishes an integration version that matches user supplied user label: ` + GetExample(17),
 }
 
@@ -112,7 +116,7 @@ func init() {
 	UnPublishVerCmd.Flags().StringVarP(&snapshot, "snapshot", "s",
 		"", "Integration flow snapshot number")
 	UnPublishVerCmd.Flags().BoolVarP(&latest, "latest", "",
-		true, "Unpublishes the integeration version with the highest snapshot number in SNAPSHOT state; default is true")
+		true, "Unpublishes the integration version with the highest snapshot number in SNAPSHOT state; default is true")
 
 	_ = UnPublishVerCmd.MarkFlagRequired("name")
 }
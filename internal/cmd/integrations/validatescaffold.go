@@ -0,0 +1,314 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integrations
+
+import (
+	"encoding/json"
+	"fmt"
+	"internal/client/connections"
+	"internal/client/integrations"
+	"internal/client/sfdc"
+	"internal/clilog"
+	"internal/cmd/utils"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// ValidateScaffoldCmd to check a scaffold offline
+var ValidateScaffoldCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Check a scaffold is consistent before applying it",
+	Long: "Check a scaffold is consistent without calling any API, so no project or region is needed: " +
+		"the resource files are valid JSON, the code files match the tasks of the integration, the overrides " +
+		"only reference declared config variables and the sfdc channel and event subscription files follow " +
+		"the naming convention. With --strict-json, fields of the integration, overrides, connector, custom connector, " +
+		"endpoint, zone and sfdc files that are not part of their schema are problems; connector templates are " +
+		"checked when --values is set. The integration checks are skipped when the scaffold " +
+		"has no integration file. Apply runs the same checks with --validate-before-apply. Exits with a non-zero " +
+		"code when problems are found",
+	Args: func(cmd *cobra.Command, args []string) (err error) {
+		if err = setFileSplitter(); err != nil {
+			return err
+		}
+		cmd.Flags().VisitAll(func(f *pflag.Flag) {
+			clilog.Debug.Printf("%s: %s\n", f.Name, f.Value)
+		})
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		cmd.SilenceUsage = true
+
+		if valuesFile := utils.GetStringParam(cmd.Flag("values")); valuesFile != "" {
+			if templateValues, err = readTemplateValues(valuesFile); err != nil {
+				return err
+			}
+			defer func() { templateValues = nil }()
+		}
+		return checkScaffold(utils.GetStringParam(cmd.Flag("folder")), env)
+	},
+	Example: `Check the scaffold of an environment: ` + GetExample(48),
+}
+
+// scaffoldResourceFolders are the folders of an environment holding JSON resource files
+var scaffoldResourceFolders = []string{
+	"authconfigs", "connectors", "custom-connectors", "endpoints", "zones",
+	"sfdcinstances", "sfdcchannels", "tests", "test-configs", "config-variables",
+}
+
+// checkScaffold prints the problems and warnings of the scaffold and returns an error when there are problems
+func checkScaffold(srcFolder string, env string) error {
+	problems, warnings, err := validateScaffold(srcFolder, env)
+	if err != nil {
+		return err
+	}
+	for _, warning := range warnings {
+		clilog.Warning.Println(warning)
+	}
+	if len(problems) == 0 {
+		clilog.Info.Printf("No problems found in the scaffold %s\n", srcFolder)
+		return nil
+	}
+	for _, problem := range problems {
+		clilog.Error.Println(problem)
+	}
+	return fmt.Errorf("found %d problems in the scaffold %s", len(problems), srcFolder)
+}
+
+// validateScaffold checks the scaffold for the environment without calling any API. problems
+// make apply fail, warnings are worth a look
+func validateScaffold(srcFolder string, env string) (problems []string, warnings []string, err error) {
+	folder := srcFolder
+	if env != "" {
+		folder = path.Join(folder, env)
+	}
+	if stat, err := os.Stat(folder); err != nil || !stat.IsDir() {
+		return nil, nil, fmt.Errorf("problem with supplied path, %w", err)
+	}
+	integrationFolder := path.Join(srcFolder, "src")
+
+	for _, resourceFolder := range scaffoldResourceFolders {
+		problems = append(problems, validateJSONFiles(path.Join(folder, resourceFolder))...)
+	}
+
+	if entries, err := os.ReadDir(path.Join(folder, "sfdcchannels")); err == nil {
+		for _, entry := range entries {
+			if entry.IsDir() || filepath.Ext(entry.Name()) != jsonExt {
+				continue
+			}
+			if _, err := getSfdcChannelNames(entry.Name()); err != nil {
+				problems = append(problems, err.Error())
+			}
+		}
+	}
+
+	if entries, err := os.ReadDir(path.Join(folder, "connectors", subscriptionsFolderName)); err == nil {
+		for _, entry := range entries {
+			if entry.IsDir() || filepath.Ext(entry.Name()) != jsonExt {
+				continue
+			}
+			if _, _, err := getEventSubscriptionNames(entry.Name()); err != nil {
+				problems = append(problems, err.Error())
+			}
+		}
+	}
+
+	overridesBytes, err := readOverrides(getOverridesFiles(srcFolder, env))
+	if err != nil {
+		problems = append(problems, err.Error())
+	}
+
+	if strictJSON {
+		strictProblems, strictWarnings := validateStrictJSON(srcFolder, env)
+		problems = append(problems, strictProblems...)
+		warnings = append(warnings, strictWarnings...)
+	}
+
+	integrationNames := getIntegrationFiles(integrationFolder)
+	if len(integrationNames) == 0 {
+		// scaffolds of connectors and other resources only are applied without an integration
+		clilog.Info.Printf("No integration file found in %s, skipping the integration checks\n", integrationFolder)
+		return problems, warnings, nil
+	}
+	if len(integrationNames) > 1 {
+		warnings = append(warnings, fmt.Sprintf("found %d integration files in %s, only %s is applied",
+			len(integrationNames), integrationFolder, integrationNames[0]))
+	}
+	integrationFile := path.Join(integrationFolder, integrationNames[0])
+	integrationBytes, err := utils.ReadFile(integrationFile)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !json.Valid(integrationBytes) {
+		return append(problems, fmt.Sprintf("%s is not valid JSON", integrationFile)), warnings, nil
+	}
+
+	// the code files are checked by LintCodeFiles for both JavaScript and Jsonnet tasks
+	findings, err := integrations.Lint(integrationBytes, "", false)
+	if err != nil {
+		return nil, nil, err
+	}
+	codeFindings, err := integrations.LintCodeFiles(integrationBytes,
+		path.Join(integrationFolder, "javascript"), path.Join(integrationFolder, "datatransformer"))
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, finding := range append(findings, codeFindings...) {
+		problems = append(problems, fmt.Sprintf("%s: %s", integrationNames[0], finding))
+	}
+
+	if len(overridesBytes) > 0 {
+		undeclared, err := integrations.GetUndeclaredConfigVariables(integrationBytes, overridesBytes)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, key := range undeclared {
+			problems = append(problems, fmt.Sprintf("the overrides reference config variable %s, "+
+				"which is not declared by %s", key, integrationNames[0]))
+		}
+	}
+
+	// config variables can also be set when applying, so they only warrant warnings
+	name := getFilenameWithoutExtension(integrationNames[0])
+	configVarsFile := path.Join(folder, "config-variables", name+"-config.json")
+	if configVarBytes, err := utils.ReadFile(configVarsFile); err == nil {
+		if configVarBytes, err = utils.InterpolateEnv(configVarBytes); err != nil {
+			warnings = append(warnings, fmt.Sprintf("unable to interpolate config variables file %s: %v", configVarsFile, err))
+		} else if unused, missing, err := integrations.CheckConfigVariables(integrationBytes, configVarBytes); err == nil {
+			for _, key := range unused {
+				warnings = append(warnings, fmt.Sprintf("config variable %s is not used by integration %s", key, name))
+			}
+			if len(missing) > 0 {
+				warnings = append(warnings, fmt.Sprintf("integration %s has no value for the config variables %s in %s",
+					name, strings.Join(missing, ", "), configVarsFile))
+			}
+		}
+	}
+	return problems, warnings, nil
+}
+
+// validateJSONFiles returns a problem for each JSON file of the folder that is not valid JSON
+func validateJSONFiles(folder string) (problems []string) {
+	_ = filepath.Walk(folder, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() || filepath.Ext(path) != jsonExt {
+			return nil
+		}
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			problems = append(problems, err.Error())
+		} else if !json.Valid(contents) {
+			problems = append(problems, fmt.Sprintf("%s is not valid JSON", path))
+		}
+		return nil
+	})
+	return problems
+}
+
+// validateStrictJSON returns a problem for each integration, overrides, connector, custom
+// connector, endpoint, zone and sfdc file with a field that is not part of its schema. The
+// connector templates are checked once rendered with the template values, and are reported
+// as not checked without values. The authconfig files are not checked, their credential
+// types are not all known to the toolkit
+func validateStrictJSON(srcFolder string, env string) (problems []string, warnings []string) {
+	check := func(file string, checkJSON func([]byte) error) {
+		contents, err := utils.ReadFile(file)
+		if err != nil {
+			return
+		}
+		if contents, err = utils.InterpolateEnv(contents); err == nil {
+			contents, err = overridesToJSON(file, contents)
+		}
+		if err != nil || !json.Valid(contents) {
+			// reported by the other checks
+			return
+		}
+		if err = checkJSON(contents); err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", file, err))
+		}
+	}
+
+	integrationFolder := path.Join(srcFolder, "src")
+	for _, name := range getIntegrationFiles(integrationFolder) {
+		check(path.Join(integrationFolder, name), integrations.CheckJSON)
+	}
+	for _, overridesFile := range getOverridesFiles(srcFolder, env) {
+		check(overridesFile, integrations.CheckOverridesJSON)
+	}
+	checkFolder := func(folder string, checkJSON func([]byte) error) {
+		entries, err := os.ReadDir(folder)
+		if err != nil {
+			return
+		}
+		for _, entry := range entries {
+			if !entry.IsDir() && filepath.Ext(entry.Name()) == jsonExt {
+				check(path.Join(folder, entry.Name()), checkJSON)
+			}
+		}
+	}
+	folder := path.Join(srcFolder, env)
+	checkFolder(path.Join(folder, "connectors"), connections.CheckJSON)
+	if entries, err := os.ReadDir(path.Join(folder, "connectors")); err == nil {
+		for _, entry := range entries {
+			if entry.IsDir() || !strings.HasSuffix(entry.Name(), connectorTemplateExt) {
+				continue
+			}
+			templateFile := path.Join(folder, "connectors", entry.Name())
+			if templateValues == nil {
+				warnings = append(warnings, fmt.Sprintf("%s was not checked, pass --values to render it", templateFile))
+				continue
+			}
+			_, connectionBytes, err := renderConnectorTemplate(templateFile)
+			if err == nil {
+				err = connections.CheckJSON(connectionBytes)
+			}
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("%s: %v", templateFile, err))
+			}
+		}
+	}
+	checkFolder(path.Join(folder, "custom-connectors"), connections.CheckCustomJSON)
+	checkFolder(path.Join(folder, "endpoints"), connections.CheckEndpointJSON)
+	checkFolder(path.Join(folder, "zones"), connections.CheckZoneJSON)
+	checkFolder(path.Join(folder, "sfdcinstances"), sfdc.CheckInstanceJSON)
+	checkFolder(path.Join(folder, "sfdcchannels"), sfdc.CheckChannelJSON)
+	if _, err := os.Stat(path.Join(folder, "authconfigs")); err == nil {
+		warnings = append(warnings, "the authconfig files are not checked for unknown fields")
+	}
+	return problems, warnings
+}
+
+func init() {
+	var folder, valuesFile string
+
+	ValidateScaffoldCmd.Flags().StringVarP(&folder, "folder", "f",
+		"", "Folder containing scaffolding configuration")
+	ValidateScaffoldCmd.Flags().StringVarP(&env, "env", "e",
+		"", "Environment name for the scaffolding")
+	ValidateScaffoldCmd.Flags().BoolVarP(&useUnderscore, "use-underscore", "",
+		false, "Use underscore as a file splitter; default is __")
+	ValidateScaffoldCmd.Flags().StringVarP(&fileSplitter, "file-splitter", "",
+		utils.DefaultFileSplitter, "Separator used in custom connector and sfdc channel file names")
+	ValidateScaffoldCmd.Flags().BoolVarP(&strictJSON, "strict-json", "",
+		false, "Report the fields of the integration, overrides, connector, custom connector, endpoint, zone and "+
+			"sfdc files that are not part of their schema; authconfig files are not checked; default is false")
+	ValidateScaffoldCmd.Flags().StringVarP(&valuesFile, "values", "",
+		"", "YAML file with the values of the connector templates, used to check the rendered templates with --strict-json")
+
+	_ = ValidateScaffoldCmd.MarkFlagRequired("folder")
+}
@@ -36,4 +36,6 @@ func init() {
 	VerCmd.AddCommand(DownloadVerCmd)
 	VerCmd.AddCommand(DelVerCmd)
 	VerCmd.AddCommand(TestCasesCmd)
+	VerCmd.AddCommand(LabelVerCmd)
+	VerCmd.AddCommand(DiffVerCmd)
 }
@@ -0,0 +1,107 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integrations
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"internal/apiclient"
+	"internal/client/integrations"
+	"internal/clilog"
+	"internal/cmd/utils"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// exit codes returned by the wait command
+const (
+	waitTimeoutExitCode = 2
+	waitFailedExitCode  = 3
+)
+
+// WaitExecCmd to wait for an execution of an integration to complete
+var WaitExecCmd = &cobra.Command{
+	Use:   "wait",
+	Short: "Wait for an execution of an integration to complete",
+	Long: "Poll an execution of an integration until it completes and print its summary. The command exits " +
+		"with 0 when the execution SUCCEEDED, 2 when the timeout is reached, 3 when it FAILED or was CANCELLED " +
+		"and 1 if the execution could not be read",
+	Args: func(cmd *cobra.Command, args []string) (err error) {
+		cmdProject := cmd.Flag("proj")
+		cmdRegion := cmd.Flag("reg")
+
+		if err = apiclient.SetRegion(utils.GetStringParam(cmdRegion)); err != nil {
+			return err
+		}
+		cmd.Flags().VisitAll(func(f *pflag.Flag) {
+			clilog.Debug.Printf("%s: %s\n", f.Name, f.Value)
+		})
+		return apiclient.SetProjectID(utils.GetStringParam(cmdProject))
+	},
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		cmd.SilenceUsage = true
+
+		name := utils.GetStringParam(cmd.Flag("name"))
+		executionID := utils.GetStringParam(cmd.Flag("execution-id"))
+		timeout, _ := time.ParseDuration(utils.GetStringParam(cmd.Flag("timeout")))
+		interval, _ := time.ParseDuration(utils.GetStringParam(cmd.Flag("interval")))
+
+		apiclient.DisableCmdPrintHttpResponse()
+		summary, waitErr := integrations.WaitForExecution(name, executionID, timeout, interval)
+		if waitErr != nil && !errors.Is(waitErr, integrations.ErrWaitTimeout) {
+			return waitErr
+		}
+
+		respBody, err := json.Marshal(summary)
+		if err != nil {
+			return err
+		}
+		apiclient.EnableCmdPrintHttpResponse()
+		apiclient.ClientPrintHttpResponse.Set(true)
+		if err = apiclient.PrettyPrint(respBody); err != nil {
+			return err
+		}
+
+		switch {
+		case waitErr != nil:
+			return utils.NewExitCodeError(waitTimeoutExitCode, waitErr)
+		case summary.State != "SUCCEEDED":
+			return utils.NewExitCodeError(waitFailedExitCode,
+				fmt.Errorf("execution %s completed with state %s", executionID, summary.State))
+		}
+		return nil
+	},
+	Example: `Execute an integration and wait up to 5 minutes for it to complete: ` + GetExample(37),
+}
+
+func init() {
+	var name, executionID string
+	var timeout, interval time.Duration
+
+	WaitExecCmd.Flags().StringVarP(&name, "name", "n",
+		"", "Integration flow name")
+	WaitExecCmd.Flags().StringVarP(&executionID, "execution-id", "e",
+		"", "Execution id")
+	WaitExecCmd.Flags().DurationVarP(&timeout, "timeout", "",
+		5*time.Minute, "Maximum time to wait for the execution, for ex: 15m; 0 waits with no limit")
+	WaitExecCmd.Flags().DurationVarP(&interval, "interval", "",
+		10*time.Second, "Time between polls of the execution state")
+
+	_ = WaitExecCmd.MarkFlagRequired("name")
+	_ = WaitExecCmd.MarkFlagRequired("execution-id")
+}
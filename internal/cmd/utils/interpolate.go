@@ -0,0 +1,100 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// InterpolateEnv replaces ${VAR} references in content with the value of the
+// environment variable VAR. ${VAR:-default} falls back to default when VAR is
+// unset or empty, and the default may itself contain references. $${VAR} is
+// written out as a literal ${VAR}. Values are substituted as is, without any
+// json escaping
+func InterpolateEnv(content []byte) ([]byte, error) {
+	var missing []string
+
+	out, err := interpolate(string(content), &missing)
+	if err != nil {
+		return nil, err
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("environment variables not set: %s", strings.Join(missing, ", "))
+	}
+	return []byte(out), nil
+}
+
+func interpolate(s string, missing *[]string) (string, error) {
+	var b strings.Builder
+
+	for i := 0; i < len(s); {
+		if strings.HasPrefix(s[i:], "$${") {
+			b.WriteString("${")
+			i += 3
+			continue
+		}
+		if !strings.HasPrefix(s[i:], "${") {
+			b.WriteByte(s[i])
+			i++
+			continue
+		}
+
+		end := closingBrace(s, i+2)
+		if end < 0 {
+			return "", fmt.Errorf("unterminated variable reference at offset %d", i)
+		}
+
+		expr := s[i+2 : end]
+		name, defaultValue, hasDefault := strings.Cut(expr, ":-")
+		if name == "" {
+			return "", fmt.Errorf("empty variable reference at offset %d", i)
+		}
+
+		if value := os.Getenv(name); value != "" {
+			b.WriteString(value)
+		} else if hasDefault {
+			value, err := interpolate(defaultValue, missing)
+			if err != nil {
+				return "", err
+			}
+			b.WriteString(value)
+		} else if _, ok := os.LookupEnv(name); !ok {
+			*missing = append(*missing, name)
+		}
+		i = end + 1
+	}
+	return b.String(), nil
+}
+
+// closingBrace returns the index of the brace closing a reference whose
+// expression starts at start, skipping over nested references
+func closingBrace(s string, start int) int {
+	depth := 0
+	for i := start; i < len(s); i++ {
+		switch {
+		case strings.HasPrefix(s[i:], "${"):
+			depth++
+			i++
+		case s[i] == '}':
+			if depth == 0 {
+				return i
+			}
+			depth--
+		}
+	}
+	return -1
+}
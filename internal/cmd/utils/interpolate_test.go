@@ -0,0 +1,82 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestInterpolateEnv(t *testing.T) {
+	t.Setenv("AIM_HOST", "api.example.com")
+	t.Setenv("AIM_PORT", "8443")
+	t.Setenv("AIM_EMPTY", "")
+
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"no references", `{"key": "value $ {x}"}`, `{"key": "value $ {x}"}`},
+		{"simple", `{"url": "https://${AIM_HOST}:${AIM_PORT}"}`, `{"url": "https://api.example.com:8443"}`},
+		{"default unused", `${AIM_HOST:-localhost}`, `api.example.com`},
+		{"default used", `${AIM_UNSET:-localhost}`, `localhost`},
+		{"default for empty", `${AIM_EMPTY:-localhost}`, `localhost`},
+		{"empty default", `${AIM_UNSET:-}`, ``},
+		{"empty without default", `[${AIM_EMPTY}]`, `[]`},
+		{"nested default", `${AIM_UNSET:-${AIM_HOST}}`, `api.example.com`},
+		{"nested default of default", `${AIM_UNSET:-${AIM_OTHER:-fallback}}`, `fallback`},
+		{"escaped", `$${AIM_HOST} is ${AIM_HOST}`, `${AIM_HOST} is api.example.com`},
+		{"escaped unset", `$${AIM_UNSET}`, `${AIM_UNSET}`},
+		{"escaped inside default", `${AIM_UNSET:-$${AIM_HOST}}`, `${AIM_HOST}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := InterpolateEnv([]byte(tt.content))
+			if err != nil {
+				t.Fatalf("InterpolateEnv(%q) failed: %v", tt.content, err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("InterpolateEnv(%q) = %q, want %q", tt.content, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInterpolateEnvErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		errText string
+	}{
+		{"missing", `${AIM_UNSET_A} ${AIM_UNSET_B}`, "AIM_UNSET_A, AIM_UNSET_B"},
+		{"missing in default", `${AIM_UNSET_A:-${AIM_UNSET_B}}`, "AIM_UNSET_B"},
+		{"unterminated", `${AIM_UNSET_A`, "unterminated"},
+		{"empty name", `${}`, "empty variable"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := InterpolateEnv([]byte(tt.content))
+			if err == nil {
+				t.Fatalf("InterpolateEnv(%q) expected an error", tt.content)
+			}
+			if !strings.Contains(err.Error(), tt.errText) {
+				t.Errorf("InterpolateEnv(%q) error = %v, want it to contain %q", tt.content, err, tt.errText)
+			}
+		})
+	}
+}
@@ -15,12 +15,15 @@
 package utils
 
 import (
+	"bufio"
 	"fmt"
 	"internal/apiclient"
+	"internal/clilog"
 	"io"
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
@@ -245,6 +248,35 @@ func GetCloudBuildYaml() string {
 	return fmt.Sprintf(cloudBuild, v)
 }
 
+// ValidateFileSplitter checks the splitter can be used in scaffold file names
+func ValidateFileSplitter(fileSplitter string) error {
+	if fileSplitter == "" {
+		return fmt.Errorf("file splitter cannot be empty")
+	}
+	if strings.ContainsAny(fileSplitter, "./\\:*?\"<>| \t") {
+		return fmt.Errorf("file splitter %q cannot contain path separators, dots, spaces or reserved characters", fileSplitter)
+	}
+	return nil
+}
+
+// ExitCodeError is returned by commands that need to exit with a specific code
+type ExitCodeError struct {
+	Code int
+	Err  error
+}
+
+func NewExitCodeError(code int, err error) *ExitCodeError {
+	return &ExitCodeError{Code: code, Err: err}
+}
+
+func (e *ExitCodeError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ExitCodeError) Unwrap() error {
+	return e.Err
+}
+
 func ReadFile(filePath string) (byteValue []byte, err error) {
 	userFile, err := os.Open(filePath)
 	if err != nil {
@@ -259,6 +291,83 @@ func ReadFile(filePath string) (byteValue []byte, err error) {
 	return byteValue, err
 }
 
+// StdinPath is the file path that reads from standard input
+const StdinPath = "-"
+
+// ReadFileOrStdin reads the file, or standard input when the path is StdinPath
+func ReadFileOrStdin(filePath string) (byteValue []byte, err error) {
+	if filePath == StdinPath {
+		return io.ReadAll(os.Stdin)
+	}
+	return ReadFile(filePath)
+}
+
+// Confirm prompts the user on stderr before deleting several resources, such as the versions
+// matching a filter. Like ConfirmDelete, it returns an error when the answer is not yes or
+// stdin is not a terminal
+func Confirm(prompt string) error {
+	if !clilog.IsTerminal(os.Stdin) {
+		return fmt.Errorf("nothing was deleted, confirming the delete needs a terminal. " +
+			"Pass --force to delete without confirmation")
+	}
+	return confirm(os.Stdin, prompt)
+}
+
+func confirm(in io.Reader, prompt string) error {
+	fmt.Fprintf(os.Stderr, "%s [y/N]: ", prompt)
+	answer := strings.ToLower(readAnswer(in))
+	if answer != "y" && answer != "yes" {
+		return fmt.Errorf("nothing was deleted. Pass --force to delete without confirmation")
+	}
+	return nil
+}
+
+// ConfirmDelete prints the resource about to be deleted on stderr and asks the user to type
+// its name. It returns an error when the name does not match or stdin is not a terminal, so
+// scripts that do not pass --force fail instead of silently not deleting
+func ConfirmDelete(kind string, name string) error {
+	if !clilog.IsTerminal(os.Stdin) {
+		return fmt.Errorf("%s %s was not deleted, confirming the delete needs a terminal. "+
+			"Pass --force to delete it without confirmation", kind, name)
+	}
+	return confirmDelete(os.Stdin, kind, name)
+}
+
+func confirmDelete(in io.Reader, kind string, name string) error {
+	fmt.Fprintf(os.Stderr, "About to delete %s %s\n", kind, name)
+	fmt.Fprintf(os.Stderr, "Type the %s name to confirm: ", kind)
+	if name == "" || readAnswer(in) != name {
+		return fmt.Errorf("%s %s was not deleted, the name typed did not match. "+
+			"Pass --force to delete it without confirmation", kind, name)
+	}
+	return nil
+}
+
+// readAnswer returns the trimmed line the user typed, or an empty string if nothing could be read
+func readAnswer(in io.Reader) string {
+	answer, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && answer == "" {
+		return ""
+	}
+	return strings.TrimSpace(answer)
+}
+
+// ParseAge parses a duration that may also be expressed in days, for ex: 30d or 12h
+func ParseAge(age string) (time.Duration, error) {
+	if days, found := strings.CutSuffix(age, "d"); found {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid duration %q", age)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	d, err := time.ParseDuration(age)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid duration %q", age)
+	}
+	return d, nil
+}
+
 func GetStringParam(flag *pflag.Flag) (param string) {
 	param = ""
 	if flag != nil {
@@ -0,0 +1,95 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseAge(t *testing.T) {
+	tests := []struct {
+		age  string
+		want time.Duration
+	}{
+		{"30d", 30 * 24 * time.Hour},
+		{"0d", 0},
+		{"12h", 12 * time.Hour},
+		{"1h30m", 90 * time.Minute},
+	}
+	for _, tt := range tests {
+		got, err := ParseAge(tt.age)
+		if err != nil {
+			t.Fatalf("ParseAge(%q) failed: %v", tt.age, err)
+		}
+		if got != tt.want {
+			t.Errorf("ParseAge(%q) = %v, want %v", tt.age, got, tt.want)
+		}
+	}
+
+	for _, age := range []string{"", "d", "-1d", "1.5d", "-2h", "30days"} {
+		if _, err := ParseAge(age); err == nil {
+			t.Errorf("ParseAge(%q) expected an error", age)
+		}
+	}
+}
+
+func TestConfirmDeleteWithoutTerminal(t *testing.T) {
+	stdin, err := os.CreateTemp(t.TempDir(), "stdin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stdin.Close()
+	savedStdin := os.Stdin
+	os.Stdin = stdin
+	defer func() { os.Stdin = savedStdin }()
+
+	if err = ConfirmDelete("managed zone", "my-zone"); err == nil || !strings.Contains(err.Error(), "--force") {
+		t.Errorf("ConfirmDelete() error = %v, want an error suggesting --force", err)
+	}
+	if err = Confirm("Delete 2 versions?"); err == nil || !strings.Contains(err.Error(), "--force") {
+		t.Errorf("Confirm() error = %v, want an error suggesting --force", err)
+	}
+}
+
+func TestConfirm(t *testing.T) {
+	tests := map[string]bool{"y\n": true, "YES\n": true, "n\n": false, "\n": false, "": false}
+	for answer, want := range tests {
+		if err := confirm(strings.NewReader(answer), "Delete 2 versions?"); (err == nil) != want {
+			t.Errorf("confirm(%q) error = %v, want confirmed %v", answer, err, want)
+		}
+	}
+}
+
+func TestConfirmDelete(t *testing.T) {
+	tests := []struct {
+		answer string
+		want   bool
+	}{
+		{"my-zone\n", true},
+		{"  my-zone  \n", true},
+		{"my-zone", true},
+		{"y\n", false},
+		{"my-zone-2\n", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if err := confirmDelete(strings.NewReader(tt.answer), "managed zone", "my-zone"); (err == nil) != tt.want {
+			t.Errorf("confirmDelete(%q) error = %v, want confirmed %v", tt.answer, err, tt.want)
+		}
+	}
+}
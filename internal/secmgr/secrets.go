@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 	"internal/apiclient"
+	"strings"
 
 	secretmanager "cloud.google.com/go/secretmanager/apiv1"
 	"cloud.google.com/go/secretmanager/apiv1/secretmanagerpb"
@@ -27,7 +28,7 @@ import (
 func secretExists(project string, name string) (version string, err error) {
 	// Create the client.
 	ctx := context.Background()
-	client, err := secretmanager.NewClient(ctx)
+	client, err := newClient(ctx)
 	if err != nil {
 		return "", err
 	}
@@ -55,7 +56,7 @@ func Create(project string, secretId string, payload []byte) (version string, er
 
 	ctx := context.Background()
 
-	c, err := secretmanager.NewClient(ctx)
+	c, err := newClient(ctx)
 	if err != nil {
 		return "", err
 	}
@@ -104,3 +105,65 @@ func Create(project string, secretId string, payload []byte) (version string, er
 
 	return secretVersion.Name, nil
 }
+
+// AddVersion adds a new version with the payload to the secret of an existing secret version
+// and returns the name of the new version
+func AddVersion(secretVersion string, payload []byte) (version string, err error) {
+	secretName, _, found := strings.Cut(secretVersion, "/versions/")
+	if !found {
+		return "", fmt.Errorf("%s is not a secret version", secretVersion)
+	}
+
+	ctx := context.Background()
+
+	c, err := newClient(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer c.Close()
+
+	addSecretVersionReq := &secretmanagerpb.AddSecretVersionRequest{
+		Parent: secretName,
+		Payload: &secretmanagerpb.SecretPayload{
+			Data: payload,
+		},
+	}
+
+	newVersion, err := c.AddSecretVersion(ctx, addSecretVersionReq)
+	if err != nil {
+		return "", err
+	}
+
+	return newVersion.Name, nil
+}
+
+// Access returns the payload of a secret version
+func Access(secretVersion string) (payload []byte, err error) {
+	ctx := context.Background()
+
+	c, err := newClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	req := &secretmanagerpb.AccessSecretVersionRequest{
+		Name: secretVersion,
+	}
+
+	resp, err := c.AccessSecretVersion(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Payload.Data, nil
+}
+
+// newClient returns a Secret Manager client that trusts the proxy CA certificate
+func newClient(ctx context.Context) (*secretmanager.Client, error) {
+	opts, err := apiclient.GRPCClientOptions()
+	if err != nil {
+		return nil, err
+	}
+	return secretmanager.NewClient(ctx, opts...)
+}